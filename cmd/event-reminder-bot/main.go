@@ -69,6 +69,21 @@ func main() {
 	exitOnError(err)
 	sl.Print(ctx, "connected to db", "version", v)
 
+	// run migrate subcommand and exit
+	if fs.NArg() > 0 {
+		if fs.Arg(0) != "migrate" {
+			exitOnError(errors.New(migrateUsage))
+		}
+		exitOnError(migrate(ctx, dbc, fs.Args()[1:]))
+		return
+	}
+
+	if n, err := pendingMigrations(ctx, dbc); err != nil {
+		sl.Error(ctx, "checking migrations", "err", err)
+	} else if n > 0 {
+		sl.Print(ctx, "schema is outdated, run migrate up", "pending", n)
+	}
+
 	// log all sql queries
 	if *flDev {
 		pgdb.AddQueryHook(ql)
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"event-reminder-bot/pkg/db"
+)
+
+const migrateUsage = "usage: event-reminder-bot [flags] migrate up|down|status"
+
+// migrate runs migrate subcommand: up applies pending migrations, down reverts the last one, status lists all.
+func migrate(ctx context.Context, dbc db.DB, args []string) error {
+	if len(args) != 1 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := dbc.MigrateUp(ctx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			fmt.Println("schema is up to date")
+		}
+		for _, mg := range applied {
+			fmt.Printf("applied %04d_%s\n", mg.Version, mg.Name)
+		}
+	case "down":
+		mg, err := dbc.MigrateDown(ctx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("reverted %04d_%s\n", mg.Version, mg.Name)
+	case "status":
+		list, err := dbc.MigrationsStatus(ctx)
+		if err != nil {
+			return err
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, s := range list {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%04d\t%s\t%s\n", s.Version, s.Name, applied)
+		}
+		return w.Flush()
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
+
+// pendingMigrations returns number of migrations not applied yet.
+func pendingMigrations(ctx context.Context, dbc db.DB) (int, error) {
+	list, err := dbc.MigrationsStatus(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var res int
+	for _, s := range list {
+		if s.AppliedAt == nil {
+			res++
+		}
+	}
+
+	return res, nil
+}
@@ -1,11 +1,123 @@
 ﻿CREATE TABLE "events" (
                           "eventId" SERIAL NOT NULL,
-                          "userTgId" int4 NOT NULL,
+                          "userTgId" int8 NOT NULL,
                           "message" text NOT NULL,
                           "sendAt" timestamp with time zone NOT NULL,
                           "createdAt" timestamp with time zone NOT NULL DEFAULT now(),
                           "statusId" int4 NOT NULL DEFAULT 1,
                           "weekdays" integer[],
                           "periodicity" varchar(16) CHECK (periodicity IN ('hour', 'day', 'week', 'weekdays', NULL)),
+                          "notes" text,
+                          "deletedAt" timestamp with time zone,
+                          "resumeAt" timestamp with time zone,
+                          "skippedAt" timestamp with time zone,
+                          "previousStatusId" int4,
                           PRIMARY KEY("eventId")
-);
\ No newline at end of file
+);
+
+CREATE TABLE "tags" (
+	"tagId" SERIAL NOT NULL,
+	"userTgId" int8 NOT NULL,
+	"title" varchar(64) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "tags_pkey" PRIMARY KEY("tagId"),
+	CONSTRAINT "tags_userTgId_title_key" UNIQUE("userTgId", "title")
+);
+
+CREATE TABLE "eventTags" (
+	"eventId" int4 NOT NULL,
+	"tagId" int4 NOT NULL,
+	CONSTRAINT "eventTags_pkey" PRIMARY KEY("eventId", "tagId"),
+	CONSTRAINT "eventTags_eventId_fkey" FOREIGN KEY ("eventId") REFERENCES "events"("eventId") ON DELETE CASCADE,
+	CONSTRAINT "eventTags_tagId_fkey" FOREIGN KEY ("tagId") REFERENCES "tags"("tagId") ON DELETE CASCADE
+);
+
+CREATE INDEX "IX_FK_eventTags_tagId_eventTags" ON "eventTags" USING BTREE (
+	"tagId"
+);
+
+CREATE TABLE "vacations" (
+	"vacationId" SERIAL NOT NULL,
+	"userTgId" int8 NOT NULL,
+	"startsAt" timestamp with time zone NOT NULL,
+	"endsAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"statusId" int4 NOT NULL DEFAULT 1,
+	CONSTRAINT "vacations_pkey" PRIMARY KEY("vacationId")
+);
+
+CREATE INDEX "IX_vacations_userTgId" ON "vacations" USING BTREE (
+	"userTgId", "endsAt"
+);
+
+CREATE TABLE "conversations" (
+	"userTgId" int8 NOT NULL,
+	"state" varchar(32) NOT NULL,
+	"params" jsonb,
+	"expiresAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "conversations_pkey" PRIMARY KEY("userTgId")
+);
+
+CREATE INDEX "IX_conversations_expiresAt" ON "conversations" USING BTREE (
+	"expiresAt"
+);
+
+CREATE TABLE "users" (
+	"userTgId" int8 NOT NULL,
+	"name" varchar(128),
+	"languageCode" varchar(16),
+	"language" varchar(8),
+	"timeZone" varchar(64) NOT NULL DEFAULT 'Europe/Moscow',
+	"digestTime" int4 DEFAULT 480 CHECK ("digestTime" >= 0 AND "digestTime" < 1440),
+	"previewTime" int4 CHECK ("previewTime" >= 0 AND "previewTime" < 1440),
+	"weeklyTime" int4 CHECK ("weeklyTime" >= 0 AND "weeklyTime" < 1440),
+	"quietFrom" int4 CHECK ("quietFrom" >= 0 AND "quietFrom" < 1440),
+	"quietTo" int4 CHECK ("quietTo" >= 0 AND "quietTo" < 1440),
+	"snoozePresets" integer[] NOT NULL DEFAULT '{5,10}',
+	"vacationMode" varchar(16) NOT NULL DEFAULT 'summary' CHECK ("vacationMode" IN ('summary', 'shift')),
+	"icalToken" varchar(64),
+	"apiTokenHash" varchar(64),
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"lastSeenAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "users_pkey" PRIMARY KEY("userTgId")
+);
+
+CREATE UNIQUE INDEX "IX_users_icalToken" ON "users" USING BTREE (
+	"icalToken"
+);
+
+CREATE UNIQUE INDEX "IX_users_apiTokenHash" ON "users" USING BTREE (
+	"apiTokenHash"
+);
+
+CREATE TABLE "snoozes" (
+	"snoozeId" SERIAL NOT NULL,
+	"eventId" int4 NOT NULL,
+	"userTgId" int8 NOT NULL,
+	"sendAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "snoozes_pkey" PRIMARY KEY("snoozeId"),
+	CONSTRAINT "snoozes_eventId_fkey" FOREIGN KEY ("eventId") REFERENCES "events"("eventId") ON DELETE CASCADE
+);
+
+CREATE INDEX "IX_snoozes_sendAt" ON "snoozes" USING BTREE (
+	"sendAt"
+);
+
+CREATE TABLE "sendStats" (
+	"day" date NOT NULL,
+	"sent" int4 NOT NULL DEFAULT 0,
+	"failed" int4 NOT NULL DEFAULT 0,
+	CONSTRAINT "sendStats_pkey" PRIMARY KEY("day")
+);
+
+CREATE INDEX "IX_events_statusId_sendAt" ON "events" USING BTREE (
+	"statusId", "sendAt"
+);
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX "IX_events_fts" ON "events" USING GIN (
+	to_tsvector('russian', "message" || ' ' || coalesce("notes", ''))
+);
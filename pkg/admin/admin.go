@@ -0,0 +1,177 @@
+// Package admin is back-office JSON API for operators, requests are authenticated by token from config.
+package admin
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmkteam/embedlog"
+)
+
+const (
+	// Path is the admin API URL path on HTTP server.
+	Path = "/v1/admin"
+
+	// maxBroadcastLength is Telegram message limit.
+	maxBroadcastLength = 4096
+)
+
+// Stats are counters of users, events and reminder deliveries.
+type Stats struct {
+	Users        int            `json:"users"`
+	ActiveUsers  int            `json:"activeUsers"`
+	ActiveEvents int            `json:"activeEvents"`
+	PausedEvents int            `json:"pausedEvents"`
+	Periodic     map[string]int `json:"periodic"`
+	Sends        []SendStat     `json:"sends"`
+}
+
+// SendStat is reminder delivery counters of UTC day.
+type SendStat struct {
+	Day    string `json:"day"`
+	Sent   int    `json:"sent"`
+	Failed int    `json:"failed"`
+}
+
+type User struct {
+	ID             int64     `json:"id"`
+	Name           *string   `json:"name,omitempty"`
+	LanguageCode   *string   `json:"languageCode,omitempty"`
+	Language       *string   `json:"language,omitempty"`
+	TimeZone       string    `json:"timeZone"`
+	CreatedAt      time.Time `json:"createdAt"`
+	LastSeenAt     time.Time `json:"lastSeenAt"`
+	ActiveEvents   int       `json:"activeEvents"`
+	PausedEvents   int       `json:"pausedEvents"`
+	TrashedEvents  int       `json:"trashedEvents"`
+	PeriodicEvents int       `json:"periodicEvents"`
+	CalendarFeed   bool      `json:"calendarFeed"`
+	APIToken       bool      `json:"apiToken"`
+}
+
+type BroadcastInput struct {
+	Text string `json:"text"`
+}
+
+type Broadcast struct {
+	Recipients int `json:"recipients"`
+}
+
+type Server struct {
+	embedlog.Logger
+	bm    *botManager.BotManager
+	token string
+}
+
+func New(bm *botManager.BotManager, token string, logger embedlog.Logger) *Server {
+	return &Server{
+		Logger: logger,
+		bm:     bm,
+		token:  token,
+	}
+}
+
+// Register adds admin API handlers.
+func (s *Server) Register(e *echo.Echo) {
+	api := e.Group(Path, s.auth)
+	api.GET("/stats", s.stats)
+	api.GET("/users/:id", s.user)
+	api.POST("/broadcast", s.broadcast)
+}
+
+// auth authenticates request by "Authorization: Bearer <token>" header.
+func (s *Server) auth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+			return echo.ErrUnauthorized
+		}
+
+		return next(c)
+	}
+}
+
+func (s *Server) stats(c echo.Context) error {
+	stats, err := s.bm.AdminStats(c.Request().Context())
+	if err != nil {
+		s.Errorf("Ошибка загрузки статистики: %v", err)
+		return echo.ErrInternalServerError
+	}
+
+	res := Stats{
+		Users:        stats.Users,
+		ActiveUsers:  stats.ActiveUsers,
+		ActiveEvents: stats.ActiveEvents,
+		PausedEvents: stats.PausedEvents,
+		Periodic:     stats.Periodic,
+		Sends:        make([]SendStat, 0, len(stats.Sends)),
+	}
+	for _, st := range stats.Sends {
+		res.Sends = append(res.Sends, SendStat{Day: st.Day.Format(time.DateOnly), Sent: st.Sent, Failed: st.Failed})
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (s *Server) user(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid user id")
+	}
+
+	info, err := s.bm.AdminUser(c.Request().Context(), id)
+	if errors.Is(err, botManager.ErrNotFound) {
+		return echo.ErrNotFound
+	} else if err != nil {
+		s.Errorf("Ошибка загрузки пользователя %d: %v", id, err)
+		return echo.ErrInternalServerError
+	}
+
+	u := info.User
+	return c.JSON(http.StatusOK, User{
+		ID:             u.UserTgID,
+		Name:           u.Name,
+		LanguageCode:   u.LanguageCode,
+		Language:       u.Language,
+		TimeZone:       u.TimeZone,
+		CreatedAt:      u.CreatedAt,
+		LastSeenAt:     u.LastSeenAt,
+		ActiveEvents:   info.ActiveEvents,
+		PausedEvents:   info.PausedEvents,
+		TrashedEvents:  info.TrashedEvents,
+		PeriodicEvents: info.PeriodicEvents,
+		CalendarFeed:   u.IcalToken != nil,
+		APIToken:       u.APITokenHash != nil,
+	})
+}
+
+// broadcast starts sending text to all users, results are logged when it ends.
+func (s *Server) broadcast(c echo.Context) error {
+	var in BroadcastInput
+	if err := c.Bind(&in); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid broadcast")
+	}
+
+	text := strings.TrimSpace(in.Text)
+	if text == "" || utf8.RuneCountInString(text) > maxBroadcastLength {
+		return echo.NewHTTPError(http.StatusBadRequest, "text is empty or too long")
+	}
+
+	count, err := s.bm.Broadcast(c.Request().Context(), text, nil)
+	if errors.Is(err, botManager.ErrBroadcastRunning) {
+		return echo.NewHTTPError(http.StatusConflict, err.Error())
+	} else if err != nil {
+		s.Errorf("Ошибка запуска рассылки: %v", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.JSON(http.StatusAccepted, Broadcast{Recipients: count})
+}
@@ -0,0 +1,42 @@
+package admin
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestAuth(t *testing.T) {
+	s := &Server{token: "secret"}
+	h := s.auth(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		header string
+		want   int
+	}{
+		{"Bearer secret", http.StatusOK},
+		{"Bearer wrong", http.StatusUnauthorized},
+		{"secret", http.StatusUnauthorized},
+		{"", http.StatusUnauthorized},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodGet, Path+"/stats", nil)
+		req.Header.Set(echo.HeaderAuthorization, tt.header)
+		rec := httptest.NewRecorder()
+
+		err := h(e.NewContext(req, rec))
+		code := rec.Code
+		if he, ok := err.(*echo.HTTPError); ok {
+			code = he.Code
+		}
+		if code != tt.want {
+			t.Errorf("%q: got %d, want %d", tt.header, code, tt.want)
+		}
+	}
+}
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"time"
 
+	"event-reminder-bot/pkg/admin"
 	"event-reminder-bot/pkg/botService"
 	"event-reminder-bot/pkg/db"
 	botManager "event-reminder-bot/pkg/event-reminder-bot"
 	"event-reminder-bot/pkg/reminder"
+	"event-reminder-bot/pkg/rpc"
+	"event-reminder-bot/pkg/webapp"
 
 	"github.com/go-pg/pg/v10"
 	"github.com/go-telegram/bot"
@@ -16,6 +19,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/vmkteam/appkit"
 	"github.com/vmkteam/embedlog"
+	"github.com/vmkteam/zenrpc/v2"
 )
 
 type Config struct {
@@ -31,7 +35,15 @@ type Config struct {
 		DSN         string
 	}
 	Bot struct {
-		Token string
+		Token              string
+		CallbackSecret     string
+		TrashRetentionDays int
+		WebAppURL          string
+		PublicURL          string
+	}
+	Admin struct {
+		UserIDs []int64
+		Token   string
 	}
 }
 
@@ -48,6 +60,9 @@ type App struct {
 	bm         *botManager.BotManager
 	rm         *reminder.ReminderManager
 	bs         *botService.BotService
+	webapp     *webapp.Server
+	rpc        *zenrpc.Server
+	admin      *admin.Server
 	eventsRepo db.EventsRepo
 }
 
@@ -74,10 +89,24 @@ func New(appName string, sl embedlog.Logger, cfg Config, database db.DB, dbc *pg
 		return a
 	}
 
+	// buttons are signed with bot token unless a separate secret is set
+	callbackSecret := cfg.Bot.CallbackSecret
+	if callbackSecret == "" {
+		callbackSecret = cfg.Bot.Token
+	}
+
 	a.b = b
-	a.bm = botManager.NewBotManager(a.b, a.eventsRepo, sl)
+	a.bm = botManager.NewBotManager(a.b, a.eventsRepo, callbackSecret, sl)
+	a.bm.TrashRetention = time.Duration(cfg.Bot.TrashRetentionDays) * 24 * time.Hour
+	a.bm.PublicURL = cfg.Bot.PublicURL
+	a.bm.AdminIDs = cfg.Admin.UserIDs
 	a.rm = reminder.NewReminderManager(a.bm, a.eventsRepo, sl)
 	a.bs = botService.NewBotService(b, a.bm, a.rm)
+	a.webapp = webapp.New(a.bm, cfg.Bot.Token, sl)
+	a.rpc = rpc.New(a.bm, sl, cfg.Server.IsDevel)
+	if cfg.Admin.Token != "" {
+		a.admin = admin.New(a.bm, cfg.Admin.Token, sl)
+	}
 
 	return a
 }
@@ -94,6 +123,11 @@ func (a *App) Run(ctx context.Context) error {
 	}
 
 	a.bs.RegisterHandlers()
+	if a.cfg.Bot.WebAppURL != "" {
+		if err := a.bs.SetMenuButton(ctx, a.cfg.Bot.WebAppURL); err != nil {
+			a.Errorf("Ошибка установки кнопки меню: %v", err)
+		}
+	}
 
 	go a.b.Start(ctx)
 	a.Printf("Бот запущен")
@@ -8,7 +8,7 @@ import (
 
 const (
 	reminderSchedule = "* * * * *"
-	dailySchedule    = "0 8 * * *"
+	purgeSchedule    = "0 3 * * *"
 )
 
 var finished = true
@@ -26,9 +26,30 @@ func (a *App) registerCron(ctx context.Context) {
 		return nil
 	})
 
-	m.AddFunc("daily-events", dailySchedule, func(ctx context.Context) error {
+	m.AddFunc("resume-paused", reminderSchedule, func(ctx context.Context) error {
 		if a.bm != nil {
-			a.bm.SendDailyEvents(ctx)
+			return a.bm.ResumeDueEvents(ctx)
+		}
+		return nil
+	})
+
+	m.AddFunc("conversation-timeouts", reminderSchedule, func(ctx context.Context) error {
+		if a.bm != nil {
+			return a.bm.ExpireConversations(ctx)
+		}
+		return nil
+	})
+
+	m.AddFunc("digests", reminderSchedule, func(ctx context.Context) error {
+		if a.bm != nil {
+			return a.bm.SendDigests(ctx)
+		}
+		return nil
+	})
+
+	m.AddFunc("purge-trash", purgeSchedule, func(ctx context.Context) error {
+		if a.bm != nil {
+			return a.bm.PurgeTrash(ctx)
 		}
 		return nil
 	})
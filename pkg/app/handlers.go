@@ -2,13 +2,20 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	_ "net/http/pprof"
+	"strings"
+
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+	"event-reminder-bot/pkg/ical"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"github.com/vmkteam/appkit"
+	zm "github.com/vmkteam/zenrpc-middleware"
+	"github.com/vmkteam/zenrpc/v2"
 )
 
 // runHTTPServer is a function that starts http listener using labstack/echo.
@@ -27,6 +34,41 @@ func (a *App) registerHandlers() {
 		AllowMethods: []string{echo.GET, echo.PUT, echo.POST, echo.DELETE},
 		AllowHeaders: []string{"Authorization", "Authorization2", "Origin", "X-Requested-With", "Content-Type", "Accept", "Platform", "Version"},
 	}))
+
+	// Mini App is served only with configured bot, its API checks init data signed by bot token
+	if a.webapp != nil {
+		a.webapp.Register(a.echo)
+	}
+
+	// calendar subscription feeds are authenticated by secret token in URL
+	if a.bm != nil {
+		a.echo.GET(botManager.ICalPath+"/:token", a.icalFeed)
+	}
+
+	// JSON-RPC API is authenticated by personal API tokens, see /token bot command
+	if a.rpc != nil {
+		a.echo.Any(botManager.RPCPath, zm.EchoHandler(a.rpc))
+		a.echo.Any(botManager.RPCPath+"doc/", echo.WrapHandler(http.HandlerFunc(zenrpc.SMDBoxHandler)))
+	}
+
+	// admin API is off unless admin token is configured
+	if a.admin != nil {
+		a.admin.Register(a.echo)
+	}
+}
+
+// icalFeed serves iCalendar subscription feed by URL like /ical/<token>.ics.
+func (a *App) icalFeed(c echo.Context) error {
+	data, err := a.bm.ICalFeed(c.Request().Context(), strings.TrimSuffix(c.Param("token"), ".ics"))
+	switch {
+	case errors.Is(err, botManager.ErrNotFound):
+		return echo.ErrNotFound
+	case err != nil:
+		a.Errorf("Ошибка выгрузки календаря: %v", err)
+		return echo.ErrInternalServerError
+	}
+
+	return c.Blob(http.StatusOK, ical.ContentType, data)
 }
 
 // registerDebugHandlers adds /debug/pprof handlers into a.echo instance.
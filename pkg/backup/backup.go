@@ -0,0 +1,192 @@
+// Package backup converts user events and settings to JSON and CSV files and back without losses.
+package backup
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+)
+
+const (
+	// Version is format version of written backups. Backups of newer versions are not accepted.
+	Version = 1
+
+	// JSONContentType and CSVContentType are MIME types of backup files.
+	JSONContentType = "application/json"
+	CSVContentType  = "text/csv"
+)
+
+// Event statuses in backup files.
+const (
+	StatusActive  = "active"
+	StatusPaused  = "paused"
+	StatusDeleted = "deleted"
+)
+
+var ErrInvalidBackup = errors.New("invalid backup")
+
+// statuses maps backup statuses to statusId.
+var statuses = map[string]int{
+	StatusActive:  db.StatusEnabled,
+	StatusPaused:  db.StatusDisabled,
+	StatusDeleted: db.StatusDeleted,
+}
+
+// Backup is a copy of user settings and events: active, paused and trashed ones.
+type Backup struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exportedAt"`
+	Settings   Settings  `json:"settings"`
+	Events     []Event   `json:"events"`
+}
+
+// Settings are user settings. Times are minutes after midnight, nil times are off.
+type Settings struct {
+	// Language is interface language, nil means the language of Telegram client.
+	Language      *string `json:"language"`
+	TimeZone      string  `json:"timeZone"`
+	DigestTime    *int    `json:"digestTime"`
+	PreviewTime   *int    `json:"previewTime"`
+	WeeklyTime    *int    `json:"weeklyTime"`
+	QuietFrom     *int    `json:"quietFrom"`
+	QuietTo       *int    `json:"quietTo"`
+	SnoozePresets []int   `json:"snoozePresets"`
+	// VacationMode is empty in backups made before it became a setting.
+	VacationMode string `json:"vacationMode"`
+}
+
+// Event is a user event. ID is the event ID at export time, it is not kept on import.
+type Event struct {
+	ID          int        `json:"id"`
+	Status      string     `json:"status"`
+	SendAt      time.Time  `json:"sendAt"`
+	Periodicity *string    `json:"periodicity,omitempty"`
+	Weekdays    []int      `json:"weekdays"`
+	Title       string     `json:"title"`
+	Notes       *string    `json:"notes,omitempty"`
+	Tags        []string   `json:"tags"`
+	ResumeAt    *time.Time `json:"resumeAt,omitempty"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+}
+
+// New returns backup of user settings and events, tags are event tag titles by event ID.
+func New(u db.User, events []db.Event, tags map[int][]string, now time.Time) *Backup {
+	b := &Backup{
+		Version:    Version,
+		ExportedAt: now,
+		Settings: Settings{
+			Language:      u.Language,
+			TimeZone:      u.TimeZone,
+			DigestTime:    u.DigestTime,
+			PreviewTime:   u.PreviewTime,
+			WeeklyTime:    u.WeeklyTime,
+			QuietFrom:     u.QuietFrom,
+			QuietTo:       u.QuietTo,
+			SnoozePresets: u.SnoozePresets,
+			VacationMode:  u.VacationMode,
+		},
+		Events: make([]Event, 0, len(events)),
+	}
+
+	for _, e := range events {
+		var status string
+		for s, id := range statuses {
+			if id == e.StatusID {
+				status = s
+			}
+		}
+
+		b.Events = append(b.Events, Event{
+			ID:          e.ID,
+			Status:      status,
+			SendAt:      e.SendAt,
+			Periodicity: e.Periodicity,
+			Weekdays:    e.Weekdays,
+			Title:       e.Message,
+			Notes:       e.Notes,
+			Tags:        tags[e.ID],
+			ResumeAt:    e.ResumeAt,
+			DeletedAt:   e.DeletedAt,
+			CreatedAt:   e.CreatedAt,
+		})
+	}
+
+	return b
+}
+
+// User returns settings as user row.
+func (s Settings) User(userTgID int64) db.User {
+	return db.User{
+		UserTgID:      userTgID,
+		Language:      s.Language,
+		TimeZone:      s.TimeZone,
+		DigestTime:    s.DigestTime,
+		PreviewTime:   s.PreviewTime,
+		WeeklyTime:    s.WeeklyTime,
+		QuietFrom:     s.QuietFrom,
+		QuietTo:       s.QuietTo,
+		SnoozePresets: s.SnoozePresets,
+		VacationMode:  s.VacationMode,
+	}
+}
+
+// DBEvent returns event row without ID. Unknown status is an error.
+func (e Event) DBEvent(userTgID int64) (db.Event, error) {
+	statusID, ok := statuses[e.Status]
+	if !ok {
+		return db.Event{}, fmt.Errorf("unknown status %q", e.Status)
+	}
+
+	event := db.Event{
+		UserTgID:    userTgID,
+		Message:     e.Title,
+		SendAt:      e.SendAt,
+		CreatedAt:   e.CreatedAt,
+		StatusID:    statusID,
+		Weekdays:    e.Weekdays,
+		Periodicity: e.Periodicity,
+		Notes:       e.Notes,
+		DeletedAt:   e.DeletedAt,
+		ResumeAt:    e.ResumeAt,
+	}
+	if event.Weekdays == nil {
+		event.Weekdays = []int{}
+	}
+
+	return event, nil
+}
+
+// EncodeJSON writes backup as indented JSON.
+func EncodeJSON(w io.Writer, b *Backup) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(b)
+}
+
+// DecodeJSON reads JSON backup. Files of other formats and newer versions return ErrInvalidBackup.
+func DecodeJSON(r io.Reader) (*Backup, error) {
+	var b Backup
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+	}
+
+	if err := checkVersion(b.Version); err != nil {
+		return nil, err
+	}
+
+	return &b, nil
+}
+
+func checkVersion(v int) error {
+	if v < 1 || v > Version {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidBackup, v)
+	}
+
+	return nil
+}
@@ -0,0 +1,97 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+)
+
+func testBackup() *Backup {
+	at := time.Date(2026, time.October, 18, 9, 30, 0, 0, time.UTC)
+	lang, digest, notes, weekdays := "en", 8*60, "Line 1\nLine 2, \"quoted\"", db.PeriodicityWeekdays
+
+	u := db.User{Language: &lang, TimeZone: "Europe/Berlin", DigestTime: &digest, SnoozePresets: []int{5, -1}, VacationMode: db.VacationModeShift}
+	events := []db.Event{
+		{ID: 1, Message: "Standup #work", SendAt: at, CreatedAt: at.Add(-time.Hour), StatusID: db.StatusEnabled, Weekdays: []int{1, 3}, Periodicity: &weekdays, Notes: &notes},
+		{ID: 2, Message: "Paused", SendAt: at, CreatedAt: at, StatusID: db.StatusDisabled, Weekdays: []int{}, ResumeAt: &at},
+		{ID: 3, Message: "Trashed", SendAt: at, CreatedAt: at, StatusID: db.StatusDeleted, Weekdays: []int{}, DeletedAt: &at},
+	}
+
+	return New(u, events, map[int][]string{1: {"work", "daily"}}, at)
+}
+
+func TestRoundTrip(t *testing.T) {
+	formats := []struct {
+		name   string
+		encode func(*bytes.Buffer, *Backup) error
+		decode func(*bytes.Buffer) (*Backup, error)
+	}{
+		{"json", func(w *bytes.Buffer, b *Backup) error { return EncodeJSON(w, b) }, func(r *bytes.Buffer) (*Backup, error) { return DecodeJSON(r) }},
+		{"csv", func(w *bytes.Buffer, b *Backup) error { return EncodeCSV(w, b) }, func(r *bytes.Buffer) (*Backup, error) { return DecodeCSV(r) }},
+	}
+
+	want := testBackup()
+	for _, f := range formats {
+		var buf bytes.Buffer
+		if err := f.encode(&buf, want); err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+
+		got, err := f.decode(&buf)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%s: got %+v, want %+v", f.name, got, want)
+		}
+	}
+
+	if want.Events[1].Status != StatusPaused || want.Events[2].Status != StatusDeleted {
+		t.Errorf("statuses = %q, %q", want.Events[1].Status, want.Events[2].Status)
+	}
+	event, err := want.Events[2].DBEvent(42)
+	if err != nil || event.StatusID != db.StatusDeleted || event.UserTgID != 42 || event.DeletedAt == nil {
+		t.Errorf("DBEvent = %+v, %v", event, err)
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	inputs := []struct {
+		name   string
+		decode func(string) error
+		input  string
+	}{
+		{"json garbage", decodeJSON, "hello"},
+		{"json newer version", decodeJSON, `{"version": 2}`},
+		{"json no version", decodeJSON, `{"events": []}`},
+		{"csv header", decodeCSV, "a,b,c\n"},
+		{"csv no version", decodeCSV, strings.Join(csvHeader, ",") + "\n"},
+		{"csv bad time", decodeCSV, strings.Join(csvHeader, ",") + "\nevent,,,1,active,tomorrow,,,Title,,,,,\n"},
+		{"csv unknown record", decodeCSV, strings.Join(csvHeader, ",") + "\nfoo,,,,,,,,,,,,,\n"},
+	}
+
+	for _, in := range inputs {
+		if err := in.decode(in.input); !errors.Is(err, ErrInvalidBackup) {
+			t.Errorf("%s: got %v, want invalid backup", in.name, err)
+		}
+	}
+
+	if _, err := (Event{Status: "unknown"}).DBEvent(1); err == nil {
+		t.Error("unknown status is valid")
+	}
+}
+
+func decodeJSON(s string) error {
+	_, err := DecodeJSON(strings.NewReader(s))
+	return err
+}
+
+func decodeCSV(s string) error {
+	_, err := DecodeCSV(strings.NewReader(s))
+	return err
+}
@@ -0,0 +1,275 @@
+package backup
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CSV backup is one table with record kind in the first column:
+//   - "backup" rows keep version and export time in name and value columns;
+//   - "setting" rows keep user settings in name and value columns, empty value is off;
+//   - "event" rows keep events in the rest columns, lists are separated by listSeparator.
+const (
+	recordBackup  = "backup"
+	recordSetting = "setting"
+	recordEvent   = "event"
+
+	listSeparator = ";"
+)
+
+var csvHeader = []string{"record", "name", "value", "id", "status", "sendAt", "periodicity", "weekdays", "title", "notes", "tags", "resumeAt", "deletedAt", "createdAt"}
+
+// EncodeCSV writes backup as CSV table.
+func EncodeCSV(w io.Writer, b *Backup) error {
+	cw := csv.NewWriter(w)
+
+	row := func(values ...string) {
+		r := make([]string, len(csvHeader))
+		copy(r, values)
+		_ = cw.Write(r)
+	}
+
+	row(csvHeader...)
+	row(recordBackup, "version", strconv.Itoa(b.Version))
+	row(recordBackup, "exportedAt", formatTime(&b.ExportedAt))
+
+	s := b.Settings
+	row(recordSetting, "language", formatString(s.Language))
+	row(recordSetting, "timeZone", s.TimeZone)
+	row(recordSetting, "digestTime", formatInt(s.DigestTime))
+	row(recordSetting, "previewTime", formatInt(s.PreviewTime))
+	row(recordSetting, "weeklyTime", formatInt(s.WeeklyTime))
+	row(recordSetting, "quietFrom", formatInt(s.QuietFrom))
+	row(recordSetting, "quietTo", formatInt(s.QuietTo))
+	row(recordSetting, "snoozePresets", formatInts(s.SnoozePresets))
+	row(recordSetting, "vacationMode", s.VacationMode)
+
+	for _, e := range b.Events {
+		row(recordEvent, "", "",
+			strconv.Itoa(e.ID),
+			e.Status,
+			formatTime(&e.SendAt),
+			formatString(e.Periodicity),
+			formatInts(e.Weekdays),
+			e.Title,
+			formatString(e.Notes),
+			strings.Join(e.Tags, listSeparator),
+			formatTime(e.ResumeAt),
+			formatTime(e.DeletedAt),
+			formatTime(&e.CreatedAt),
+		)
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// DecodeCSV reads CSV backup. Malformed rows, files of other formats and newer versions return ErrInvalidBackup.
+func DecodeCSV(r io.Reader) (*Backup, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = len(csvHeader)
+
+	header, err := cr.Read()
+	if err != nil || !slices.Equal(header, csvHeader) {
+		return nil, fmt.Errorf("%w: unexpected header", ErrInvalidBackup)
+	}
+
+	b := &Backup{}
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidBackup, err)
+		}
+
+		line, _ := cr.FieldPos(0)
+		switch rec[0] {
+		case recordBackup:
+			err = b.setMeta(rec[1], rec[2])
+		case recordSetting:
+			err = b.Settings.set(rec[1], rec[2])
+		case recordEvent:
+			var e Event
+			e, err = parseEvent(rec)
+			b.Events = append(b.Events, e)
+		default:
+			err = fmt.Errorf("unknown record %q", rec[0])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: line %d: %v", ErrInvalidBackup, line, err)
+		}
+	}
+
+	if err := checkVersion(b.Version); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+func (b *Backup) setMeta(name, value string) (err error) {
+	switch name {
+	case "version":
+		b.Version, err = strconv.Atoi(value)
+	case "exportedAt":
+		b.ExportedAt, err = time.Parse(time.RFC3339Nano, value)
+	default:
+		err = fmt.Errorf("unknown backup field %q", name)
+	}
+
+	return err
+}
+
+func (s *Settings) set(name, value string) (err error) {
+	switch name {
+	case "language":
+		s.Language = parseString(value)
+	case "timeZone":
+		s.TimeZone = value
+	case "digestTime":
+		s.DigestTime, err = parseInt(value)
+	case "previewTime":
+		s.PreviewTime, err = parseInt(value)
+	case "weeklyTime":
+		s.WeeklyTime, err = parseInt(value)
+	case "quietFrom":
+		s.QuietFrom, err = parseInt(value)
+	case "quietTo":
+		s.QuietTo, err = parseInt(value)
+	case "snoozePresets":
+		s.SnoozePresets, err = parseInts(value)
+	case "vacationMode":
+		s.VacationMode = value
+	default:
+		err = fmt.Errorf("unknown setting %q", name)
+	}
+
+	return err
+}
+
+func parseEvent(rec []string) (e Event, err error) {
+	if e.ID, err = strconv.Atoi(rec[3]); err != nil {
+		return e, err
+	}
+	e.Status = rec[4]
+
+	sendAt, err := parseTime(rec[5])
+	if err != nil || sendAt == nil {
+		return e, fmt.Errorf("invalid sendAt %q", rec[5])
+	}
+	e.SendAt = *sendAt
+
+	e.Periodicity = parseString(rec[6])
+	if e.Weekdays, err = parseInts(rec[7]); err != nil {
+		return e, err
+	}
+	e.Title, e.Notes = rec[8], parseString(rec[9])
+	if rec[10] != "" {
+		e.Tags = strings.Split(rec[10], listSeparator)
+	}
+
+	if e.ResumeAt, err = parseTime(rec[11]); err != nil {
+		return e, err
+	}
+	if e.DeletedAt, err = parseTime(rec[12]); err != nil {
+		return e, err
+	}
+	if createdAt, err := parseTime(rec[13]); err != nil {
+		return e, err
+	} else if createdAt != nil {
+		e.CreatedAt = *createdAt
+	}
+
+	return e, nil
+}
+
+func formatString(s *string) string {
+	if s == nil {
+		return ""
+	}
+
+	return *s
+}
+
+func parseString(s string) *string {
+	if s == "" {
+		return nil
+	}
+
+	return &s
+}
+
+func formatInt(n *int) string {
+	if n == nil {
+		return ""
+	}
+
+	return strconv.Itoa(*n)
+}
+
+func parseInt(s string) (*int, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &n, nil
+}
+
+func formatInts(list []int) string {
+	s := make([]string, 0, len(list))
+	for _, n := range list {
+		s = append(s, strconv.Itoa(n))
+	}
+
+	return strings.Join(s, listSeparator)
+}
+
+func parseInts(s string) ([]int, error) {
+	list := []int{}
+	if s == "" {
+		return list, nil
+	}
+
+	for _, v := range strings.Split(s, listSeparator) {
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, n)
+	}
+
+	return list, nil
+}
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.Format(time.RFC3339Nano)
+}
+
+func parseTime(s string) (*time.Time, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return nil, err
+	}
+
+	return &t, nil
+}
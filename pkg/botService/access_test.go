@@ -0,0 +1,123 @@
+package botService
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	ownerID    int64 = 900000021
+	attackerID int64 = 900000022
+)
+
+// TestDBForgedReminderCallbacks sends reminder buttons with foreign event ID through callback dispatcher.
+func TestDBForgedReminderCallbacks(t *testing.T) {
+	dbc, logger := test.Setup(t)
+	ctx := context.Background()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	b, err := bot.New("test", bot.WithSkipGetMe(), bot.WithServerURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup := func() {
+		for _, q := range []string{
+			`DELETE FROM "snoozes" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "events" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "conversations" WHERE "userTgId" IN (?, ?)`,
+		} {
+			if _, err := dbc.Exec(q, ownerID, attackerID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	bm := botManager.NewBotManager(b, db.NewEventsRepo(dbc), "test", logger)
+	bs := NewBotService(b, bm, nil)
+	codec := botManager.NewCallbackCodec("test")
+
+	event, err := bm.EventsRepo.AddEvent(ctx, &db.Event{
+		UserTgID:    ownerID,
+		Message:     "secret",
+		SendAt:      time.Now().Add(48 * time.Hour).Truncate(time.Second),
+		StatusID:    db.StatusEnabled,
+		Weekdays:    []int{},
+		Periodicity: test.Ptr(db.PeriodicityDay),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := event.ID
+
+	tests := []struct {
+		name string
+		data callback.Data
+	}{
+		{"done", callback.New(botManager.ActionDone, id)},
+		{"snooze", callback.New(botManager.ActionSnooze, id, 60)},
+		{"snooze custom", callback.New(botManager.ActionSnoozeCustom, id)},
+		{"occurrence done", callback.New(botManager.ActionOccurrenceDone, id)},
+		{"occurrence skip", callback.New(botManager.ActionOccurrenceSkip, id)},
+		{"occurrence snooze", callback.New(botManager.ActionOccurrenceSnooze, id, 60)},
+		{"stop series", callback.New(botManager.ActionStopSeries, id)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs.callbackQueryHandler(ctx, b, &models.Update{CallbackQuery: &models.CallbackQuery{
+				ID:   "1",
+				From: models.User{ID: attackerID},
+				Data: codec.Encode(tt.data),
+				Message: models.MaybeInaccessibleMessage{
+					Message: &models.Message{ID: 1, Chat: models.Chat{ID: attackerID}, Text: "reminder"},
+				},
+			}})
+
+			got, err := bm.EventsRepo.EventByID(ctx, id)
+			switch {
+			case err != nil:
+				t.Fatal(err)
+			case got == nil:
+				t.Fatal("event was removed")
+			case got.StatusID != event.StatusID || !got.SendAt.Equal(event.SendAt):
+				t.Errorf("event changed: status %d, sendAt %v", got.StatusID, got.SendAt)
+			}
+
+			snoozes, err := bm.EventsRepo.CountSnoozes(ctx, &db.SnoozeSearch{EventID: &id})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if snoozes > 0 {
+				t.Errorf("occurrence snoozed %d times", snoozes)
+			}
+
+			c, err := bm.TakeConversation(ctx, attackerID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c != nil {
+				t.Errorf("conversation %q started for foreign event", c.State)
+			}
+		})
+	}
+}
@@ -4,14 +4,13 @@ import (
 	"context"
 	"errors"
 	"event-reminder-bot/pkg/db"
-	"fmt"
-	"log"
-	"strconv"
 	"strings"
-	"sync"
 	"time"
+	"unicode/utf8"
 
+	"event-reminder-bot/pkg/callback"
 	botManager "event-reminder-bot/pkg/event-reminder-bot"
+	"event-reminder-bot/pkg/i18n"
 	"event-reminder-bot/pkg/reminder"
 
 	"github.com/go-telegram/bot"
@@ -19,81 +18,181 @@ import (
 )
 
 const (
-	startCommand = "/start"
-	helpCommand  = "/help"
-	addCommand   = "/add"
-	listCommand  = "/list"
-
-	eventDetailPrefix = "event_detail_"
-	eventEditPrefix   = "event_edit_"
-	eventDeletePrefix = "event_delete_"
-	eventBackToList   = "back_to_list"
-
-	editDatePrefix        = "edit_date_"
-	editDescPrefix        = "edit_desc_"
-	editPeriodicityPrefix = "edit_periodicity_"
-
-	postponeHour   = "postpone_hour_"
-	postponeDay    = "postpone_day_"
-	postponeWeek   = "postpone_week_"
-	postponeCustom = "postpone_custom_"
+	startCommand  = "/start"
+	helpCommand   = "/help"
+	addCommand    = "/add"
+	listCommand   = "/list"
+	deleteCommand = "/delete"
+	snoozeCommand = "/snooze"
+
+	todayCommand    = "/today"
+	tomorrowCommand = "/tomorrow"
+	weekCommand     = "/week"
+	searchCommand   = "/search"
+	trashCommand    = "/trash"
+	vacationCommand = "/vacation"
+	cancelCommand   = "/cancel"
+	languageCommand = "/language"
+	settingsCommand = "/settings"
+	exportCommand   = "/export"
+	importCommand   = "/import"
+	tokenCommand    = "/token"
+
+	// admin commands are hidden from help and answered only for admins
+	statsCommand     = "/stats"
+	userCommand      = "/user"
+	broadcastCommand = "/broadcast"
 )
 
 type BotService struct {
-	b            *bot.Bot
-	bm           *botManager.BotManager
-	rm           *reminder.ReminderManager
-	snoozeStates map[int64]int
-	mu           sync.RWMutex
+	b  *bot.Bot
+	bm *botManager.BotManager
+	rm *reminder.ReminderManager
 }
 
 func NewBotService(b *bot.Bot, bm *botManager.BotManager, rm *reminder.ReminderManager) *BotService {
 	return &BotService{
-		b:            b,
-		bm:           bm,
-		rm:           rm,
-		snoozeStates: make(map[int64]int),
-		mu:           sync.RWMutex{},
+		b:  b,
+		bm: bm,
+		rm: rm,
+	}
+}
+
+// command describes bot text command. Help text is generated from the list of commands.
+// Args and description are message catalog keys.
+type command struct {
+	name        string
+	args        string
+	description string
+	matchType   bot.MatchType
+	handler     bot.HandlerFunc
+}
+
+// commands returns all registered bot commands in help order.
+func (bs *BotService) commands() []command {
+	return []command{
+		{name: addCommand, args: "help.add.args", description: "help.add.description", matchType: bot.MatchTypePrefix, handler: bs.AddHandler},
+		{name: listCommand, args: "help.tag.args", description: "help.list.description", matchType: bot.MatchTypePrefix, handler: bs.bm.ListHandler},
+		{name: todayCommand, args: "help.tag.args", description: "help.today.description", matchType: bot.MatchTypePrefix, handler: bs.bm.TodayHandler},
+		{name: tomorrowCommand, args: "help.tag.args", description: "help.tomorrow.description", matchType: bot.MatchTypePrefix, handler: bs.bm.TomorrowHandler},
+		{name: weekCommand, args: "help.tag.args", description: "help.week.description", matchType: bot.MatchTypePrefix, handler: bs.bm.WeekHandler},
+		{name: searchCommand, args: "help.search.args", description: "help.search.description", matchType: bot.MatchTypePrefix, handler: bs.bm.SearchHandler},
+		{name: deleteCommand, args: "help.delete.args", description: "help.delete.description", matchType: bot.MatchTypePrefix, handler: bs.bm.DeleteHandler},
+		{name: trashCommand, description: "help.trash.description", matchType: bot.MatchTypeExact, handler: bs.bm.TrashHandler},
+		{name: snoozeCommand, args: "help.snooze.args", description: "help.snooze.description", matchType: bot.MatchTypePrefix, handler: bs.bm.SnoozeHandler},
+		{name: vacationCommand, args: "help.vacation.args", description: "help.vacation.description", matchType: bot.MatchTypePrefix, handler: bs.bm.VacationHandler},
+		{name: languageCommand, args: "help.language.args", description: "help.language.description", matchType: bot.MatchTypePrefix, handler: bs.bm.LanguageHandler},
+		{name: exportCommand, args: "help.export.args", description: "help.export.description", matchType: bot.MatchTypePrefix, handler: bs.bm.ExportHandler},
+		{name: importCommand, description: "help.import.description", matchType: bot.MatchTypeExact, handler: bs.bm.ImportHelpHandler},
+		{name: tokenCommand, args: "help.token.args", description: "help.token.description", matchType: bot.MatchTypePrefix, handler: bs.bm.TokenHandler},
+		{name: settingsCommand, description: "help.settings.description", matchType: bot.MatchTypeExact, handler: bs.bm.SettingsHandler},
+		{name: cancelCommand, description: "help.cancel.description", matchType: bot.MatchTypeExact, handler: bs.bm.CancelHandler},
+		{name: helpCommand, description: "help.help.description", matchType: bot.MatchTypeExact, handler: bs.helpHandler},
+		{name: startCommand, matchType: bot.MatchTypeExact, handler: bs.startHandler},
+		{name: statsCommand, matchType: bot.MatchTypeExact, handler: bs.bm.StatsHandler},
+		{name: userCommand, matchType: bot.MatchTypePrefix, handler: bs.bm.AdminUserHandler},
+		{name: broadcastCommand, matchType: bot.MatchTypePrefix, handler: bs.bm.BroadcastHandler},
 	}
 }
 
 func (bs *BotService) RegisterHandlers() {
-	bs.b.RegisterHandler(bot.HandlerTypeMessageText, startCommand, bot.MatchTypeExact, botManager.StartHandler)
-	bs.b.RegisterHandler(bot.HandlerTypeMessageText, helpCommand, bot.MatchTypeExact, botManager.HelpHandler)
-	bs.b.RegisterHandler(bot.HandlerTypeMessageText, addCommand, bot.MatchTypePrefix, bs.AddHandler)
-	bs.b.RegisterHandler(bot.HandlerTypeMessageText, listCommand, bot.MatchTypeExact, bs.bm.ListHandler)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "done_", bot.MatchTypePrefix, bs.handleDoneCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "snooze_", bot.MatchTypePrefix, bs.handleSnoozeCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "period:", bot.MatchTypePrefix, bs.bm.HandlePeriodicityCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "weekday:", bot.MatchTypePrefix, bs.bm.HandleWeekdayCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "weekdays_done:", bot.MatchTypePrefix, bs.bm.HandleWeekdaysDoneCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "page_", bot.MatchTypePrefix, bs.handlePageCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, eventDetailPrefix, bot.MatchTypePrefix, bs.handleEventDetailCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, eventEditPrefix, bot.MatchTypePrefix, bs.handleEventEditCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, eventDeletePrefix, bot.MatchTypePrefix, bs.handleEventDeleteCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, eventBackToList, bot.MatchTypeExact, bs.handleBackToListCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, editDatePrefix, bot.MatchTypePrefix, bs.handleEditDateCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, editDescPrefix, bot.MatchTypePrefix, bs.handleEditDescCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, editPeriodicityPrefix, bot.MatchTypePrefix, bs.handleEditPeriodicityCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, postponeHour, bot.MatchTypePrefix, bs.handlePostponeCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, postponeDay, bot.MatchTypePrefix, bs.handlePostponeCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, postponeWeek, bot.MatchTypePrefix, bs.handlePostponeCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, postponeCustom, bot.MatchTypePrefix, bs.handlePostponeCustomCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "edit_period:", bot.MatchTypePrefix, bs.handleEditPeriodicityValueCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "edit_weekday:", bot.MatchTypePrefix, bs.handleEditWeekdayCallback)
-	bs.b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "edit_weekdays_done:", bot.MatchTypePrefix, bs.handleEditWeekdaysDoneCallback)
+	for _, c := range bs.commands() {
+		bs.b.RegisterHandler(bot.HandlerTypeMessageText, c.name, c.matchType, bs.withUser(c.handler))
+	}
+
+	bs.b.RegisterHandlerMatchFunc(func(update *models.Update) bool {
+		return update.CallbackQuery != nil
+	}, bs.withUser(bs.callbackQueryHandler))
 	bs.b.RegisterHandlerMatchFunc(func(update *models.Update) bool {
 		return update.Message != nil && update.Message.Text != ""
-	}, bs.textHandler)
+	}, bs.withUser(bs.textHandler))
+	bs.b.RegisterHandlerMatchFunc(func(update *models.Update) bool {
+		return update.Message != nil && update.Message.Document != nil
+	}, bs.withUser(bs.bm.ImportHandler))
+}
+
+// SetMenuButton shows button opening Mini App calendar at url in all chats with bot.
+func (bs *BotService) SetMenuButton(ctx context.Context, url string) error {
+	_, err := bs.b.SetChatMenuButton(ctx, &bot.SetChatMenuButtonParams{
+		MenuButton: models.MenuButtonWebApp{
+			Type:   models.MenuButtonTypeWebApp,
+			Text:   i18n.For(i18n.DefaultLang).T("button.calendar"),
+			WebApp: models.WebAppInfo{URL: url},
+		},
+	})
+
+	return err
+}
+
+// withUser saves Telegram profile of the user before handling update.
+func (bs *BotService) withUser(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		switch {
+		case update.Message != nil && update.Message.From != nil:
+			bs.bm.TouchUser(ctx, update.Message.Chat.ID, update.Message.From)
+		case update.CallbackQuery != nil:
+			bs.bm.TouchUser(ctx, update.CallbackQuery.From.ID, &update.CallbackQuery.From)
+		}
+
+		next(ctx, b, update)
+	}
+}
+
+// helpText returns list of commands with descriptions.
+func (bs *BotService) helpText(tr *i18n.Localizer) string {
+	var sb strings.Builder
+	sb.WriteString(tr.T("help.title") + "\n")
+	for _, c := range bs.commands() {
+		if c.description == "" {
+			continue
+		}
+
+		sb.WriteString(tr.T(c.description) + ": " + c.name)
+		if c.args != "" {
+			sb.WriteString(" " + tr.T(c.args))
+		}
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func (bs *BotService) startHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	tr := bs.bm.Tr(ctx, update.Message.Chat.ID)
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   tr.T("start.greeting") + "\n" + bs.helpText(tr),
+	})
+	bs.bm.OnError(err)
+}
+
+func (bs *BotService) helpHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   bs.helpText(bs.bm.Tr(ctx, update.Message.Chat.ID)),
+	})
+	bs.bm.OnError(err)
 }
 
 func (bs *BotService) AddHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	tr := bs.bm.Tr(ctx, update.Message.Chat.ID)
 	args := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/add"))
+	if args == "" {
+		text, keyboard := bs.bm.DatePicker(ctx, update.Message.Chat.ID, botManager.PickerAdd, 0)
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      update.Message.Chat.ID,
+			Text:        text,
+			ReplyMarkup: keyboard,
+		})
+		bs.bm.OnError(err)
+		return
+	}
+
 	parts := strings.SplitN(args, " ", 3)
 	if len(parts) < 3 {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   "❗ Формат: /add YYYY-MM-DD HH:MM Текст",
+			Text:   tr.T("add.usage"),
 		})
 		if err != nil {
 			return
@@ -103,21 +202,9 @@ func (bs *BotService) AddHandler(ctx context.Context, b *bot.Bot, update *models
 
 	_, err := bs.bm.AddEvent(ctx, update.Message.Chat.ID, parts)
 	if err != nil {
-		var text string
-		switch err.Error() {
-		case "invalid_format":
-			text = "❗ Недопустимый формат даты (используйте YYYY-MM-DD HH:MM)"
-		case "past_date":
-			text = "❗ Недопустимый формат даты (событие должно быть в будущем)"
-		case "text_too_long":
-			text = "❗ Текст события должен быть не длиннее 200 символов"
-		default:
-			text = fmt.Sprintf("Ошибка: %v", err)
-		}
-
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: update.Message.Chat.ID,
-			Text:   text,
+			Text:   addErrorText(tr, err),
 		})
 		if err != nil {
 			return
@@ -126,23 +213,73 @@ func (bs *BotService) AddHandler(ctx context.Context, b *bot.Bot, update *models
 	}
 }
 
-func processError(err error) string {
+// handleAddTitleInput adds event at time picked in calendar with title and notes from user input.
+func (bs *BotService) handleAddTitleInput(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams) {
+	if params.SendAt == nil {
+		return
+	}
+
+	at := params.SendAt.In(bs.bm.UserLocation(ctx, chatID))
+	_, err := bs.bm.AddEvent(ctx, chatID, []string{at.Format(time.DateOnly), at.Format("15:04"), text})
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   addErrorText(bs.bm.Tr(ctx, chatID), err),
+		})
+		bs.bm.OnError(err)
+	}
+}
+
+func addErrorText(tr *i18n.Localizer, err error) string {
+	switch err.Error() {
+	case "invalid_format":
+		return tr.T("add.invalid_format")
+	case "past_date":
+		return tr.T("add.past_date")
+	case "empty_title":
+		return tr.T("add.empty_title")
+	case "text_too_long":
+		return tr.T("add.title_too_long", botManager.MaxTitleLength)
+	case "notes_too_long":
+		return tr.T("add.notes_too_long", botManager.MaxNotesLength)
+	default:
+		return tr.T("add.error", err)
+	}
+}
+
+func processError(tr *i18n.Localizer, err error) string {
 	var text string
 	switch {
 	case errors.Is(err, botManager.ErrNotFound):
-		text = "❌ Событие не найдено"
+		text = tr.T("event.not_found")
 	case errors.Is(err, botManager.ErrAccessDenied):
-		text = "❌ У вас нет доступа к этому событию"
+		text = tr.T("event.access_denied")
 	case errors.Is(err, botManager.ErrInactive):
-		text = "❌ Событие неактивно"
+		text = tr.T("event.inactive")
 	case errors.Is(err, botManager.ErrPastDate):
-		text = "❗ Нельзя перенести событие в прошлое"
+		text = tr.T("snooze.past_date")
 	default:
-		text = fmt.Sprintf("❌ Ошибка: %v", err)
+		text = tr.T("common.error", err)
 	}
 	return text
 }
 
+// flowHandler handles user text input in a conversation state.
+type flowHandler func(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams)
+
+// flows returns text input handlers by conversation state.
+func (bs *BotService) flows() map[botManager.ConversationState]flowHandler {
+	return map[botManager.ConversationState]flowHandler{
+		botManager.StateSnoozeDate: bs.handleSnoozeDateInput,
+		botManager.StateEventDate:  bs.handleCustomDateInput,
+		botManager.StateEventTitle: bs.handleDescriptionInput,
+		botManager.StateEventNotes: bs.handleNotesInput,
+		botManager.StateResumeDate: bs.bm.PauseUntil,
+		botManager.StateTimeZone:   bs.bm.TimeZoneInput,
+		botManager.StateAddTitle:   bs.handleAddTitleInput,
+	}
+}
+
 func (bs *BotService) textHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 	if update.Message == nil {
 		return
@@ -151,49 +288,46 @@ func (bs *BotService) textHandler(ctx context.Context, b *bot.Bot, update *model
 	chatID := update.Message.Chat.ID
 	text := strings.TrimSpace(update.Message.Text)
 
-	bs.mu.RLock()
-	eventID, ok := bs.snoozeStates[chatID]
-	bs.mu.RUnlock()
-
-	if ok {
-		bs.mu.Lock()
-		delete(bs.snoozeStates, chatID)
-		bs.mu.Unlock()
+	c, err := bs.bm.TakeConversation(ctx, chatID)
+	if err != nil {
+		bs.bm.Errorf("Ошибка загрузки состояния диалога: %v", err)
+	}
 
-		loc, err := time.LoadLocation("Europe/Moscow")
-		if err != nil {
-			log.Println("Ошибка загрузки часового пояса:", err)
-			loc = time.Local
+	if c != nil {
+		if c.Expired() {
+			bs.bm.SendConversationTimeout(ctx, chatID)
+			return
 		}
 
-		newTime, err := time.ParseInLocation("2006-01-02 15:04", text, loc)
-		if err != nil {
-			_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   "❗ Недопустимый формат даты. Используйте: YYYY-MM-DD HH:MM\nНапример: 2025-11-10 22:35",
-			})
-			if err != nil {
-				return
-			}
+		if flow, ok := bs.flows()[c.State]; ok {
+			flow(ctx, b, chatID, text, c.Params)
 			return
 		}
+	}
 
-		err = bs.bm.SnoozeEvent(ctx, eventID, chatID, newTime)
+	bs.bm.DefaultHandler(ctx, b, update)
+}
+
+func (bs *BotService) handleSnoozeDateInput(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams) {
+	tr := bs.bm.Tr(ctx, chatID)
+	newTime, err := time.ParseInLocation("2006-01-02 15:04", text, bs.bm.UserLocation(ctx, chatID))
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("date.invalid_format"),
+		})
 		if err != nil {
-			responseText := processError(err)
-			_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-				ChatID: chatID,
-				Text:   responseText,
-			})
-			if err != nil {
-				return
-			}
 			return
 		}
+		return
+	}
 
+	err = bs.bm.SnoozeEvent(ctx, params.EventID, chatID, newTime)
+	if err != nil {
+		responseText := processError(tr, err)
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   fmt.Sprintf("✅ Событие перенесено на %s", newTime.Format("2006-01-02 15:04")),
+			Text:   responseText,
 		})
 		if err != nil {
 			return
@@ -201,368 +335,367 @@ func (bs *BotService) textHandler(ctx context.Context, b *bot.Bot, update *model
 		return
 	}
 
-	bs.bm.Mu.RLock()
-	editState, existsEdit := bs.bm.EditStates[chatID]
-	bs.bm.Mu.RUnlock()
-
-	if existsEdit {
-		bs.bm.Mu.Lock()
-		delete(bs.bm.EditStates, chatID)
-		bs.bm.Mu.Unlock()
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr.T("snooze.moved", tr.DateTime(newTime)),
+	})
+	bs.bm.OnError(err)
+}
 
-		switch editState.WaitingFor {
-		case "custom_date":
-			bs.handleCustomDateInput(ctx, b, chatID, text, editState.EventID)
-			return
-		case "description":
-			bs.handleDescriptionInput(ctx, b, chatID, text, editState.EventID)
-			return
-		}
+// callbackHandler handles verified callback query payload.
+type callbackHandler func(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data)
+
+// callbacks returns callback handlers by action.
+func (bs *BotService) callbacks() map[callback.Action]callbackHandler {
+	return map[callback.Action]callbackHandler{
+		botManager.ActionDone:             bs.handleDoneCallback,
+		botManager.ActionSnooze:           bs.handleSnoozeCallback,
+		botManager.ActionSnoozeCustom:     bs.handleSnoozeCustomCallback,
+		botManager.ActionPeriod:           bs.handleCallbackWithUserID(bs.bm.HandlePeriodicity),
+		botManager.ActionWeekday:          bs.handleCallbackWithUserID(bs.bm.HandleWeekday),
+		botManager.ActionWeekdaysDone:     bs.handleCallbackWithUserID(bs.bm.HandleWeekdaysDone),
+		botManager.ActionEventDetail:      bs.handleCallback(bs.bm.HandleEventDetail),
+		botManager.ActionEventEdit:        bs.handleCallback(bs.bm.HandleEventEdit),
+		botManager.ActionEventDelete:      bs.handleCallback(bs.bm.HandleEventDelete),
+		botManager.ActionBackToList:       bs.handleCallbackNoData(bs.bm.HandleBackToList),
+		botManager.ActionEditDate:         bs.handleCallback(bs.bm.HandleEditDate),
+		botManager.ActionEditDescription:  bs.handleCallbackWithUserID(bs.bm.HandleEditDescription),
+		botManager.ActionEditNotes:        bs.handleCallbackWithUserID(bs.bm.HandleEditNotes),
+		botManager.ActionEditPeriodicity:  bs.handleCallback(bs.bm.HandleEditPeriodicity),
+		botManager.ActionEditPeriod:       bs.handleCallback(bs.bm.HandleEditPeriod),
+		botManager.ActionEditWeekday:      bs.handleCallback(bs.bm.HandleEditWeekday),
+		botManager.ActionEditWeekdaysDone: bs.handleCallback(bs.bm.HandleEditWeekdaysDone),
+		botManager.ActionPostpone:         bs.handleCallback(bs.bm.HandlePostpone),
+		botManager.ActionPostponeCustom:   bs.handleCallbackWithUserID(bs.bm.HandlePostponeCustom),
+		botManager.ActionPostponePreset:   bs.handleCallback(bs.bm.HandlePostponePreset),
+		botManager.ActionListPage:         bs.handleCallback(bs.bm.HandleListPage),
+		botManager.ActionSearchPage:       bs.handleCallback(bs.bm.HandleSearchPage),
+		botManager.ActionDigestTag:        bs.handleCallback(bs.bm.HandleDigestTag),
+		botManager.ActionSelectStart:      bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectPage:       bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectToggle:     bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectDelete:     bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectPause:      bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectPostpone:   bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectShift:      bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectTagMenu:    bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectTag:        bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionSelectCancel:     bs.handleCallback(bs.bm.HandleSelect),
+		botManager.ActionUndo:             bs.handleCallback(bs.bm.HandleUndo),
+		botManager.ActionRestore:          bs.handleCallback(bs.bm.HandleRestore),
+		botManager.ActionPauseMenu:        bs.handleCallback(bs.bm.HandlePause),
+		botManager.ActionPause:            bs.handleCallback(bs.bm.HandlePause),
+		botManager.ActionPauseDate:        bs.handleCallback(bs.bm.HandlePause),
+		botManager.ActionResume:           bs.handleCallback(bs.bm.HandleResume),
+		botManager.ActionVacationCancel:   bs.handleCallback(bs.bm.HandleVacation),
+		botManager.ActionVacationMode:     bs.handleCallback(bs.bm.HandleVacation),
+		botManager.ActionLanguage:         bs.handleCallback(bs.bm.HandleLanguage),
+		botManager.ActionSettings:         bs.handleCallback(bs.bm.HandleSettings),
+		botManager.ActionSettingsSection:  bs.handleCallback(bs.bm.HandleSettings),
+		botManager.ActionSettingsSet:      bs.handleCallback(bs.bm.HandleSettings),
+		botManager.ActionOccurrenceDone:   bs.handleOccurrenceCallback,
+		botManager.ActionOccurrenceSkip:   bs.handleOccurrenceCallback,
+		botManager.ActionOccurrenceSnooze: bs.handleOccurrenceSnoozeCallback,
+		botManager.ActionStopSeries:       bs.handleStopSeriesCallback,
+		botManager.ActionPickerNoop:       bs.handleCallback(bs.bm.HandlePicker),
+		botManager.ActionPickerMonth:      bs.handleCallback(bs.bm.HandlePicker),
+		botManager.ActionPickerDay:        bs.handleCallback(bs.bm.HandlePicker),
+		botManager.ActionPickerHour:       bs.handleCallback(bs.bm.HandlePicker),
+		botManager.ActionPickerTime:       bs.handleCallback(bs.bm.HandlePicker),
+		botManager.ActionImportConfirm:    bs.handleCallback(bs.bm.HandleImport),
+		botManager.ActionImportCancel:     bs.handleCallback(bs.bm.HandleImport),
+		botManager.ActionBackupMerge:      bs.handleCallback(bs.bm.HandleBackup),
+		botManager.ActionBackupReplace:    bs.handleCallback(bs.bm.HandleBackup),
+		botManager.ActionBackupCancel:     bs.handleCallback(bs.bm.HandleBackup),
+		botManager.ActionBroadcastSend:    bs.handleCallback(bs.bm.HandleBroadcast),
+		botManager.ActionBroadcastCancel:  bs.handleCallback(bs.bm.HandleBroadcast),
 	}
-
-	botManager.DefaultHandler(ctx, b, update)
 }
 
-func (bs *BotService) handleDoneCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.CallbackQuery == nil {
-		return
+// callbackQueryHandler verifies callback data and dispatches it by action.
+// Buttons of old versions, forged or unknown payloads are answered with a hint instead of being ignored.
+func (bs *BotService) callbackQueryHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	query := update.CallbackQuery
+
+	data, err := bs.bm.DecodeCallback(query.Data)
+	if errors.Is(err, callback.ErrSignature) {
+		bs.bm.Printf("Недействительная подпись кнопки от пользователя %d", query.From.ID)
 	}
 
-	data := update.CallbackQuery.Data
-	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	handler, ok := bs.callbacks()[data.Action]
+	if err != nil || !ok || query.Message.Message == nil {
+		_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: query.ID,
+			Text:            bs.bm.Tr(ctx, query.From.ID).T("callback.outdated"),
+			ShowAlert:       true,
+		})
+		bs.bm.OnError(err)
+		return
+	}
 
-	parts := strings.Split(data, "_")
-	if len(parts) == 2 {
-		eventID, err := strconv.Atoi(parts[1])
-		if err != nil {
-			_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            "❌ Ошибка обработки",
-			})
-			if err != nil {
-				return
-			}
-			return
-		}
+	handler(ctx, b, update, data)
+}
 
-		err = bs.bm.DeleteEventByID(ctx, eventID)
-		if err != nil {
-			_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            "❌ Ошибка при удалении события",
-				ShowAlert:       true,
-			})
-			if err != nil {
-				return
-			}
-			return
-		}
+func (bs *BotService) handleDoneCallback(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
+	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	tr := bs.bm.Tr(ctx, chatID)
 
+	err := bs.bm.DeleteEventByID(ctx, chatID, data.Arg(0))
+	if err != nil {
 		_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: update.CallbackQuery.ID,
-			Text:            "✅ Событие выполнено",
+			Text:            tr.T("event.delete_error"),
+			ShowAlert:       true,
 		})
 		if err != nil {
 			return
 		}
+		return
+	}
 
-		_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
-			ChatID:      chatID,
-			MessageID:   update.CallbackQuery.Message.Message.ID,
-			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
-		})
-		if err != nil {
-			return
-		}
+	_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: update.CallbackQuery.ID,
+		Text:            tr.T("reminder.done_answer"),
+	})
+	if err != nil {
+		return
+	}
 
-		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-			ChatID:    chatID,
-			MessageID: update.CallbackQuery.Message.Message.ID,
-			Text:      update.CallbackQuery.Message.Message.Text + "\n\n✅ Выполнено",
-		})
-		if err != nil {
-			return
-		}
+	_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      chatID,
+		MessageID:   update.CallbackQuery.Message.Message.ID,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
+	})
+	if err != nil {
+		return
 	}
-}
 
-func (bs *BotService) handleSnoozeCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.CallbackQuery == nil {
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: update.CallbackQuery.Message.Message.ID,
+		Text:      update.CallbackQuery.Message.Message.Text + "\n\n" + tr.T("reminder.done_mark"),
+	})
+	if err != nil {
 		return
 	}
+}
 
-	data := update.CallbackQuery.Data
+func (bs *BotService) handleSnoozeCallback(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
 	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	eventID, preset := data.Arg(0), data.Arg(1)
+	tr := bs.bm.Tr(ctx, chatID)
 
-	parts := strings.Split(data, "_")
-
-	if len(parts) == 3 && parts[1] != "custom" {
-		eventID, err := strconv.Atoi(parts[1])
-		if err != nil {
-			_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            "❌ Ошибка обработки",
-			})
-			if err != nil {
-				return
-			}
-			return
-		}
-
-		minutes, err := strconv.Atoi(parts[2])
-		if err != nil {
-			_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            "❌ Ошибка обработки",
-			})
-			if err != nil {
-				return
-			}
-			return
-		}
-
-		newTime := time.Now().Add(time.Duration(minutes) * time.Minute)
-
-		err = bs.bm.SnoozeEvent(ctx, eventID, chatID, newTime)
-		if err != nil {
-			response := processError(err)
-			_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            response,
-				ShowAlert:       true,
-			})
-			if err != nil {
-				return
-			}
-			return
-		}
+	newTime, ok := bs.bm.SnoozeTime(ctx, chatID, preset)
+	if !ok {
+		return
+	}
 
+	err := bs.bm.SnoozeEvent(ctx, eventID, chatID, newTime)
+	if err != nil {
+		response := processError(tr, err)
 		_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: update.CallbackQuery.ID,
-			Text:            fmt.Sprintf("✅ Отложено на %d мин", minutes),
-		})
-		if err != nil {
-			return
-		}
-
-		_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
-			ChatID:      chatID,
-			MessageID:   update.CallbackQuery.Message.Message.ID,
-			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
-		})
-		if err != nil {
-			return
-		}
-
-		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-			ChatID:    chatID,
-			MessageID: update.CallbackQuery.Message.Message.ID,
-			Text:      update.CallbackQuery.Message.Message.Text + fmt.Sprintf("\n\n⏱️ Отложено на %d мин", minutes),
+			Text:            response,
+			ShowAlert:       true,
 		})
 		if err != nil {
 			return
 		}
+		return
+	}
 
-	} else if len(parts) == 3 && parts[1] == "custom" {
-		eventID, err := strconv.Atoi(parts[2])
-		if err != nil {
-			_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-				CallbackQueryID: update.CallbackQuery.ID,
-				Text:            "❌ Ошибка обработки",
-			})
-			if err != nil {
-				return
-			}
-			return
-		}
+	_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: update.CallbackQuery.ID,
+		Text:            tr.T("reminder.snoozed_answer", tr.DateTime(newTime)),
+	})
+	if err != nil {
+		return
+	}
 
-		bs.mu.Lock()
-		bs.snoozeStates[chatID] = eventID
-		bs.mu.Unlock()
+	_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      chatID,
+		MessageID:   update.CallbackQuery.Message.Message.ID,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
+	})
+	if err != nil {
+		return
+	}
 
-		_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-			CallbackQueryID: update.CallbackQuery.ID,
-		})
-		if err != nil {
-			return
-		}
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: update.CallbackQuery.Message.Message.ID,
+		Text:      update.CallbackQuery.Message.Message.Text + "\n\n" + tr.T("reminder.snoozed_mark", tr.DateTime(newTime)),
+	})
+	if err != nil {
+		return
+	}
+}
 
-		_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
-			ChatID:      chatID,
-			MessageID:   update.CallbackQuery.Message.Message.ID,
-			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
-		})
-		if err != nil {
-			return
-		}
+// handleOccurrenceCallback marks periodic reminder occurrence as done or skipped. The series is not changed.
+func (bs *BotService) handleOccurrenceCallback(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
+	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	tr := bs.bm.Tr(ctx, chatID)
 
-		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-			ChatID:    chatID,
-			MessageID: update.CallbackQuery.Message.Message.ID,
-			Text:      update.CallbackQuery.Message.Message.Text + "\n\n⏳ Ожидание ввода времени...",
-		})
-		if err != nil {
-			return
-		}
+	if err := bs.bm.CompleteOccurrence(ctx, chatID, data.Arg(0)); err != nil {
+		bs.answerCallbackError(ctx, b, update, processError(tr, err))
+		return
+	}
 
-		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "📅 Введите новую дату и время в формате:\nYYYY-MM-DD HH:MM\n\nНапример: 2025-12-31 23:59",
-		})
-		if err != nil {
-			return
-		}
+	answer, mark := tr.T("reminder.done_answer"), tr.T("reminder.done_mark")
+	if data.Action == botManager.ActionOccurrenceSkip {
+		answer, mark = tr.T("reminder.skipped_answer"), tr.T("reminder.skipped_mark")
 	}
+
+	bs.markReminder(ctx, b, update, answer, mark, nil)
 }
 
-func (bs *BotService) handlePageCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	data := update.CallbackQuery.Data
+// handleOccurrenceSnoozeCallback delivers periodic reminder occurrence once more later, next occurrence time is kept.
+func (bs *BotService) handleOccurrenceSnoozeCallback(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
 	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	tr := bs.bm.Tr(ctx, chatID)
 
-	parts := strings.Split(data, "_")
-	if len(parts) != 2 {
+	newTime, ok := bs.bm.SnoozeTime(ctx, chatID, data.Arg(1))
+	if !ok {
 		return
 	}
 
-	page, err := strconv.Atoi(parts[1])
-	if err != nil || page < 1 {
+	if err := bs.bm.SnoozeOccurrence(ctx, chatID, data.Arg(0), newTime); err != nil {
+		bs.answerCallbackError(ctx, b, update, processError(tr, err))
 		return
 	}
 
-	pageSize := 10
+	bs.markReminder(ctx, b, update, tr.T("reminder.snoozed_answer", tr.DateTime(newTime)), tr.T("reminder.snoozed_mark", tr.DateTime(newTime)), nil)
+}
 
-	events, total, err := bs.bm.GetUserEventsPaged(ctx, chatID, page, pageSize)
-	if err != nil {
-		bs.bm.Errorf("Ошибка загрузки событий: %v", err)
-		return
-	}
+// handleStopSeriesCallback moves periodic event to trash and offers undo.
+func (bs *BotService) handleStopSeriesCallback(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
+	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	eventID := data.Arg(0)
+	tr := bs.bm.Tr(ctx, chatID)
 
-	if len(events) == 0 {
+	if err := bs.bm.DeleteEventByID(ctx, chatID, eventID); err != nil {
+		bs.answerCallbackError(ctx, b, update, processError(tr, err))
 		return
 	}
 
-	periodicCount, err := bs.bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
-	if err != nil {
-		bs.bm.Errorf("Ошибка подсчета периодических событий: %v", err)
-		periodicCount = 0
-	}
-
-	start := (page - 1) * pageSize
-
-	var msg strings.Builder
-	msg.WriteString("📅 Список событий:\n\n")
-	msg.WriteString(fmt.Sprintf("📊 Периодических уведомлений: %d/%d\n\n", periodicCount, botManager.MaxPeriodic))
-
-	for i, e := range events {
-		msg.WriteString(fmt.Sprintf("%d. %s — ", start+i+1, e.Text))
-		msg.WriteString(fmt.Sprintf("%s\n", e.DateTime.Format("2006-01-02 15:04")))
-
-		if e.Periodicity != nil {
-			switch *e.Periodicity {
-			case "hour":
-				msg.WriteString("🔄 Каждый час\n")
-			case "day":
-				msg.WriteString("🔄 Ежедневно\n")
-			case "week":
-				msg.WriteString("🔄 Еженедельно\n")
-			case "weekdays":
-				var days []string
-				for _, day := range e.Weekdays {
-					days = append(days, botManager.DayName(day))
-				}
-				msg.WriteString(fmt.Sprintf("🔄 По дням: %s\n", strings.Join(days, ", ")))
-			}
-		} else {
-			msg.WriteString("⏹️ Без повтора\n")
-		}
-	}
+	bs.markReminder(ctx, b, update, tr.T("reminder.stopped_answer"), tr.T("reminder.stopped_mark"), bs.bm.UndoKeyboard(tr, eventID))
+}
 
-	var buttons [][]models.InlineKeyboardButton
+func (bs *BotService) answerCallbackError(ctx context.Context, b *bot.Bot, update *models.Update, text string) {
+	_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: update.CallbackQuery.ID,
+		Text:            text,
+		ShowAlert:       true,
+	})
+	bs.bm.OnError(err)
+}
 
-	row := []models.InlineKeyboardButton{}
-	for i := range events {
-		eventNum := start + i + 1
-		row = append(row, models.InlineKeyboardButton{
-			Text:         fmt.Sprintf("%d", eventNum),
-			CallbackData: fmt.Sprintf("%s%d", botManager.EventDetailPrefix, events[i].ID),
-		})
+// markReminder answers callback and appends mark to reminder text, replacing its keyboard.
+func (bs *BotService) markReminder(ctx context.Context, b *bot.Bot, update *models.Update, answer, mark string, keyboard *models.InlineKeyboardMarkup) {
+	_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: update.CallbackQuery.ID,
+		Text:            answer,
+	})
+	bs.bm.OnError(err)
 
-		if len(row) == 5 {
-			buttons = append(buttons, row)
-			row = []models.InlineKeyboardButton{}
-		}
+	if keyboard == nil {
+		keyboard = &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
 	}
-	if len(row) > 0 {
-		buttons = append(buttons, row)
+
+	message := update.CallbackQuery.Message.Message
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      message.Chat.ID,
+		MessageID:   message.ID,
+		Text:        message.Text + "\n\n" + mark,
+		ReplyMarkup: keyboard,
+	})
+	bs.bm.OnError(err)
+}
+
+func (bs *BotService) handleSnoozeCustomCallback(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
+	chatID := update.CallbackQuery.Message.Message.Chat.ID
+	tr := bs.bm.Tr(ctx, chatID)
+
+	if _, err := bs.bm.UserEvent(ctx, chatID, data.Arg(0)); err != nil {
+		bs.answerCallbackError(ctx, b, update, processError(tr, err))
+		return
 	}
 
-	var navRow []models.InlineKeyboardButton
-	if page > 1 {
-		navRow = append(navRow, models.InlineKeyboardButton{
-			Text:         "⬅️ Назад",
-			CallbackData: fmt.Sprintf("page_%d", page-1),
+	err := bs.bm.StartConversation(ctx, chatID, botManager.StateSnoozeDate, db.ConversationParams{EventID: data.Arg(0)})
+	if err != nil {
+		bs.bm.Errorf("%v", err)
+		_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+			CallbackQueryID: update.CallbackQuery.ID,
+			Text:            tr.T("common.process_error"),
 		})
+		bs.bm.OnError(err)
+		return
 	}
-	if start+pageSize < total {
-		navRow = append(navRow, models.InlineKeyboardButton{
-			Text:         "➡️ Далее",
-			CallbackData: fmt.Sprintf("page_%d", page+1),
-		})
+
+	_, err = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: update.CallbackQuery.ID,
+	})
+	if err != nil {
+		return
 	}
-	if len(navRow) > 0 {
-		buttons = append(buttons, navRow)
+
+	_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+		ChatID:      chatID,
+		MessageID:   update.CallbackQuery.Message.Message.ID,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}},
+	})
+	if err != nil {
+		return
 	}
 
 	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:    chatID,
 		MessageID: update.CallbackQuery.Message.Message.ID,
-		Text:      msg.String(),
-		ReplyMarkup: &models.InlineKeyboardMarkup{
-			InlineKeyboard: buttons,
-		},
+		Text:      update.CallbackQuery.Message.Message.Text + "\n\n" + tr.T("snooze.waiting"),
 	})
-}
+	if err != nil {
+		return
+	}
 
-func (bs *BotService) handleCallback(handler func(context.Context, *bot.Bot, string, int64, int)) func(context.Context, *bot.Bot, *models.Update) {
-	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
-		if update.CallbackQuery == nil {
-			return
-		}
+	text, keyboard := bs.bm.DatePicker(ctx, chatID, botManager.PickerSnooze, data.Arg(0))
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+	if err != nil {
+		return
+	}
+}
 
+func (bs *BotService) handleCallback(handler func(context.Context, *bot.Bot, callback.Data, int64, int)) callbackHandler {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
 		_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: update.CallbackQuery.ID,
 		})
 		bs.bm.OnError(err)
 
-		handler(ctx, b, update.CallbackQuery.Data,
+		handler(ctx, b, data,
 			update.CallbackQuery.Message.Message.Chat.ID,
 			update.CallbackQuery.Message.Message.ID)
 	}
 }
 
-func (bs *BotService) handleCallbackWithUserID(handler func(context.Context, *bot.Bot, string, int64, int)) func(context.Context, *bot.Bot, *models.Update) {
-	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
-		if update.CallbackQuery == nil {
-			return
-		}
-
+func (bs *BotService) handleCallbackWithUserID(handler func(context.Context, *bot.Bot, callback.Data, int64, int)) callbackHandler {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update, data callback.Data) {
 		_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: update.CallbackQuery.ID,
 		})
 		bs.bm.OnError(err)
 
-		handler(ctx, b, update.CallbackQuery.Data,
+		handler(ctx, b, data,
 			update.CallbackQuery.From.ID,
 			update.CallbackQuery.Message.Message.ID)
 	}
 }
 
-func (bs *BotService) handleCallbackNoData(handler func(context.Context, *bot.Bot, int64, int)) func(context.Context, *bot.Bot, *models.Update) {
-	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
-		if update.CallbackQuery == nil {
-			return
-		}
-
+func (bs *BotService) handleCallbackNoData(handler func(context.Context, *bot.Bot, int64, int)) callbackHandler {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update, _ callback.Data) {
 		_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
 			CallbackQueryID: update.CallbackQuery.ID,
 		})
@@ -574,65 +707,14 @@ func (bs *BotService) handleCallbackNoData(handler func(context.Context, *bot.Bo
 	}
 }
 
-func (bs *BotService) handleEventDetailCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEventDetail)(ctx, b, update)
-}
-
-func (bs *BotService) handleEventEditCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEventEdit)(ctx, b, update)
-}
-
-func (bs *BotService) handleEventDeleteCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEventDelete)(ctx, b, update)
-}
-
-func (bs *BotService) handleBackToListCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallbackNoData(bs.bm.HandleBackToList)(ctx, b, update)
-}
-
-func (bs *BotService) handleEditDateCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEditDate)(ctx, b, update)
-}
-
-func (bs *BotService) handleEditDescCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallbackWithUserID(bs.bm.HandleEditDescription)(ctx, b, update)
-}
-
-func (bs *BotService) handleEditPeriodicityCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEditPeriodicity)(ctx, b, update)
-}
-
-func (bs *BotService) handlePostponeCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandlePostpone)(ctx, b, update)
-}
-
-func (bs *BotService) handlePostponeCustomCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallbackWithUserID(bs.bm.HandlePostponeCustom)(ctx, b, update)
-}
-
-func (bs *BotService) handleEditPeriodicityValueCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEditPeriodicityCallback)(ctx, b, update)
-}
-
-func (bs *BotService) handleEditWeekdayCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEditWeekday)(ctx, b, update)
-}
-
-func (bs *BotService) handleEditWeekdaysDoneCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	bs.handleCallback(bs.bm.HandleEditWeekdaysDone)(ctx, b, update)
-}
-func (bs *BotService) handleCustomDateInput(ctx context.Context, b *bot.Bot, chatID int64, text string, eventID int) {
-	loc, err := time.LoadLocation("Europe/Moscow")
-	if err != nil {
-		log.Println("Ошибка загрузки часового пояса:", err)
-		loc = time.Local
-	}
-
-	newTime, err := time.ParseInLocation("2006-01-02 15:04", text, loc)
+func (bs *BotService) handleCustomDateInput(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams) {
+	eventID := params.EventID
+	tr := bs.bm.Tr(ctx, chatID)
+	newTime, err := time.ParseInLocation("2006-01-02 15:04", text, bs.bm.UserLocation(ctx, chatID))
 	if err != nil {
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❗ Недопустимый формат даты. Используйте: YYYY-MM-DD HH:MM\nНапример: 2025-12-31 23:59",
+			Text:   tr.T("date.invalid_format"),
 		})
 		if err != nil {
 			return
@@ -643,19 +725,7 @@ func (bs *BotService) handleCustomDateInput(ctx context.Context, b *bot.Bot, cha
 	if newTime.Before(time.Now()) {
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❗ Дата не может быть в прошлом",
-		})
-		if err != nil {
-			return
-		}
-		return
-	}
-
-	event, err := bs.bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
+			Text:   tr.T("date.past"),
 		})
 		if err != nil {
 			return
@@ -663,14 +733,13 @@ func (bs *BotService) handleCustomDateInput(ctx context.Context, b *bot.Bot, cha
 		return
 	}
 
-	if event.UserTgID != chatID {
+	event, err := bs.bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
+			Text:   processError(tr, err),
 		})
-		if err != nil {
-			return
-		}
+		bs.bm.OnError(err)
 		return
 	}
 
@@ -679,7 +748,7 @@ func (bs *BotService) handleCustomDateInput(ctx context.Context, b *bot.Bot, cha
 	if err != nil {
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ошибка при обновлении события",
+			Text:   tr.T("event.update_error"),
 		})
 		if err != nil {
 			return
@@ -689,18 +758,20 @@ func (bs *BotService) handleCustomDateInput(ctx context.Context, b *bot.Bot, cha
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   fmt.Sprintf("✅ Дата изменена на %s", newTime.Format("2006-01-02 15:04")),
+		Text:   tr.T("event.date_changed", tr.DateTime(newTime)),
 	})
 	if err != nil {
 		return
 	}
 }
 
-func (bs *BotService) handleDescriptionInput(ctx context.Context, b *bot.Bot, chatID int64, text string, eventID int) {
-	if len(text) > 200 {
+func (bs *BotService) handleDescriptionInput(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams) {
+	eventID := params.EventID
+	tr := bs.bm.Tr(ctx, chatID)
+	if utf8.RuneCountInString(text) > botManager.MaxTitleLength {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❗ Описание не может быть длиннее 200 символов",
+			Text:   tr.T("edit.title_too_long", botManager.MaxTitleLength),
 		})
 		if err != nil {
 			return
@@ -708,22 +779,46 @@ func (bs *BotService) handleDescriptionInput(ctx context.Context, b *bot.Bot, ch
 		return
 	}
 
-	event, err := bs.bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
+	event, err := bs.bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
+			Text:   processError(tr, err),
+		})
+		bs.bm.OnError(err)
+		return
+	}
+
+	event.Message = text
+	_, err = bs.bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.Message))
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("event.update_error"),
 		})
 		if err != nil {
 			return
 		}
 		return
 	}
+	bs.bm.SyncTags(ctx, event)
 
-	if event.UserTgID != chatID {
-		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr.T("edit.title_changed"),
+	})
+	if err != nil {
+		return
+	}
+}
+
+func (bs *BotService) handleNotesInput(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams) {
+	eventID := params.EventID
+	tr := bs.bm.Tr(ctx, chatID)
+	if utf8.RuneCountInString(text) > botManager.MaxNotesLength {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
+			Text:   tr.T("edit.notes_too_long", botManager.MaxNotesLength),
 		})
 		if err != nil {
 			return
@@ -731,22 +826,37 @@ func (bs *BotService) handleDescriptionInput(ctx context.Context, b *bot.Bot, ch
 		return
 	}
 
-	event.Message = text
-	_, err = bs.bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.Message))
+	event, err := bs.bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   processError(tr, err),
+		})
+		bs.bm.OnError(err)
+		return
+	}
+
+	event.Notes = &text
+	if text == "-" {
+		event.Notes = nil
+	}
+
+	_, err = bs.bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.Notes))
 	if err != nil {
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ошибка при обновлении события",
+			Text:   tr.T("event.update_error"),
 		})
 		if err != nil {
 			return
 		}
 		return
 	}
+	bs.bm.SyncTags(ctx, event)
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   "✅ Описание изменено!",
+		Text:   tr.T("edit.notes_changed"),
 	})
 	if err != nil {
 		return
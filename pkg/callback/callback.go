@@ -0,0 +1,165 @@
+// Package callback encodes inline keyboard callback data into compact signed payloads.
+//
+// Payload is base64url of: version byte, action byte, varint arguments and truncated HMAC-SHA256 of all previous bytes.
+package callback
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	// Version of the payload format. Payloads of other versions are rejected as outdated.
+	Version byte = 1
+
+	// MaxLength is Telegram limit of callback data in bytes.
+	MaxLength = 64
+
+	macSize = 8
+)
+
+var (
+	ErrMalformed = errors.New("malformed callback data")
+	ErrOutdated  = errors.New("outdated callback data")
+	ErrSignature = errors.New("invalid callback signature")
+	ErrUnknown   = errors.New("unknown callback action")
+)
+
+var encoding = base64.RawURLEncoding
+
+// Action is a callback action ID. Action IDs are stored in chat history, so they must never be reused.
+type Action byte
+
+// Data is a decoded callback payload.
+type Data struct {
+	Action Action
+	Args   []int
+}
+
+// New returns payload for action with arguments.
+func New(action Action, args ...int) Data {
+	return Data{Action: action, Args: args}
+}
+
+// Arg returns i-th argument or 0 if it is missing.
+func (d Data) Arg(i int) int {
+	if i < 0 || i >= len(d.Args) {
+		return 0
+	}
+
+	return d.Args[i]
+}
+
+type action struct {
+	name string
+	args int
+}
+
+// Codec encodes and decodes callback data for registered actions.
+type Codec struct {
+	key     []byte
+	actions map[Action]action
+}
+
+// NewCodec returns codec signing payloads with secret.
+func NewCodec(secret string) *Codec {
+	return &Codec{
+		key:     []byte(secret),
+		actions: make(map[Action]action),
+	}
+}
+
+// Register adds action with fixed number of arguments. It panics if action is already registered.
+func (c *Codec) Register(a Action, name string, args int) {
+	if _, ok := c.actions[a]; ok {
+		panic(fmt.Sprintf("callback: action %d is already registered", a))
+	}
+
+	c.actions[a] = action{name: name, args: args}
+}
+
+// Name returns registered action name for logs.
+func (c *Codec) Name(a Action) string {
+	if act, ok := c.actions[a]; ok {
+		return act.name
+	}
+
+	return fmt.Sprintf("unknown(%d)", a)
+}
+
+// Encode returns signed callback data.
+// It panics if action is not registered, arguments do not match or payload exceeds MaxLength, since it is a programming error.
+func (c *Codec) Encode(d Data) string {
+	act, ok := c.actions[d.Action]
+	if !ok {
+		panic(fmt.Sprintf("callback: action %d is not registered", d.Action))
+	}
+	if len(d.Args) != act.args {
+		panic(fmt.Sprintf("callback: action %s expects %d args, got %d", act.name, act.args, len(d.Args)))
+	}
+
+	buf := []byte{Version, byte(d.Action)}
+	for _, arg := range d.Args {
+		buf = binary.AppendVarint(buf, int64(arg))
+	}
+	buf = append(buf, c.sign(buf)...)
+
+	res := encoding.EncodeToString(buf)
+	if len(res) > MaxLength {
+		panic(fmt.Sprintf("callback: action %s payload is %d bytes long", act.name, len(res)))
+	}
+
+	return res
+}
+
+// Decode verifies callback data and returns payload.
+func (c *Codec) Decode(s string) (Data, error) {
+	if len(s) > MaxLength {
+		return Data{}, ErrMalformed
+	}
+
+	buf, err := encoding.DecodeString(s)
+	if err != nil || len(buf) < 2+macSize {
+		return Data{}, ErrMalformed
+	}
+
+	if buf[0] != Version {
+		return Data{}, ErrOutdated
+	}
+
+	body, mac := buf[:len(buf)-macSize], buf[len(buf)-macSize:]
+	if !hmac.Equal(mac, c.sign(body)) {
+		return Data{}, ErrSignature
+	}
+
+	d := Data{Action: Action(body[1])}
+	act, ok := c.actions[d.Action]
+	if !ok {
+		return Data{}, ErrUnknown
+	}
+
+	rest := body[2:]
+	for range act.args {
+		v, n := binary.Varint(rest)
+		if n <= 0 {
+			return Data{}, ErrMalformed
+		}
+		d.Args = append(d.Args, int(v))
+		rest = rest[n:]
+	}
+	if len(rest) != 0 {
+		return Data{}, ErrMalformed
+	}
+
+	return d, nil
+}
+
+func (c *Codec) sign(body []byte) []byte {
+	h := hmac.New(sha256.New, c.key)
+	h.Write(body)
+	return h.Sum(nil)[:macSize]
+}
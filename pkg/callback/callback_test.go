@@ -0,0 +1,112 @@
+package callback
+
+import (
+	"errors"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+)
+
+const (
+	testAction   Action = 1
+	testNoArgs   Action = 2
+	unregistered Action = 3
+)
+
+func newTestCodec(secret string) *Codec {
+	c := NewCodec(secret)
+	c.Register(testAction, "test", 2)
+	c.Register(testNoArgs, "noargs", 0)
+	return c
+}
+
+func TestRoundTrip(t *testing.T) {
+	c := newTestCodec("secret")
+
+	tests := []Data{
+		New(testAction, 0, 0),
+		New(testAction, 1, -1),
+		New(testAction, math.MaxInt32, 7*24),
+		New(testAction, math.MaxInt64, math.MinInt64),
+		New(testNoArgs),
+	}
+
+	for _, want := range tests {
+		s := c.Encode(want)
+		if len(s) > MaxLength {
+			t.Errorf("%v: encoded length %d > %d", want, len(s), MaxLength)
+		}
+
+		got, err := c.Decode(s)
+		if err != nil {
+			t.Fatalf("%v: %v", want, err)
+		}
+		if got.Action != want.Action || !slices.Equal(got.Args, want.Args) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	c := newTestCodec("secret")
+	valid := c.Encode(New(testAction, 42, 5))
+
+	raw, _ := encoding.DecodeString(valid)
+	tampered := slices.Clone(raw)
+	tampered[2] ^= 1
+
+	oldVersion := slices.Clone(raw)
+	oldVersion[0] = Version + 1
+
+	other := NewCodec("secret")
+	other.Register(unregistered, "unregistered", 0)
+
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"legacy", "event_detail_42", ErrOutdated},
+		{"empty", "", ErrMalformed},
+		{"not base64", "!!!", ErrMalformed},
+		{"too long", strings.Repeat("A", MaxLength+1), ErrMalformed},
+		{"tampered args", encoding.EncodeToString(tampered), ErrSignature},
+		{"old version", encoding.EncodeToString(oldVersion), ErrOutdated},
+		{"other secret", newTestCodec("other").Encode(New(testAction, 42, 5)), ErrSignature},
+		{"unknown action", other.Encode(New(unregistered)), ErrUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := c.Decode(tt.data)
+			if !errors.Is(err, tt.want) {
+				t.Errorf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncodePanics(t *testing.T) {
+	c := newTestCodec("secret")
+
+	tests := []struct {
+		name string
+		data Data
+	}{
+		{"unregistered", New(unregistered)},
+		{"missing args", New(testAction, 1)},
+		{"extra args", New(testNoArgs, 1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("expected panic")
+				}
+			}()
+			c.Encode(tt.data)
+		})
+	}
+}
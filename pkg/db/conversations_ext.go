@@ -0,0 +1,53 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// SetConversation creates or replaces user conversation state.
+func (er EventsRepo) SetConversation(ctx context.Context, conversation *Conversation) error {
+	_, err := er.db.ModelContext(ctx, conversation).
+		ExcludeColumn(Columns.Conversation.CreatedAt).
+		OnConflict(`("userTgId") DO UPDATE`).
+		Set(`"state" = EXCLUDED."state", "params" = EXCLUDED."params", "expiresAt" = EXCLUDED."expiresAt", "createdAt" = now()`).
+		Insert()
+
+	return err
+}
+
+// PopConversation deletes user conversation state and returns it or nil.
+func (er EventsRepo) PopConversation(ctx context.Context, userTgID int64) (*Conversation, error) {
+	conversation := &Conversation{UserTgID: userTgID}
+	_, err := er.db.ModelContext(ctx, conversation).
+		WherePK().
+		Returning("*").
+		Delete()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if conversation.State == "" {
+		return nil, nil
+	}
+
+	return conversation, nil
+}
+
+// PopExpiredConversations deletes expired conversation states and returns them.
+func (er EventsRepo) PopExpiredConversations(ctx context.Context) ([]Conversation, error) {
+	var conversations []Conversation
+	_, err := er.db.ModelContext(ctx, &conversations).
+		Where(`t."expiresAt" <= now()`).
+		Returning("*").
+		Delete()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return conversations, err
+}
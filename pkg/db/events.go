@@ -3,6 +3,7 @@ package db
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/go-pg/pg/v10"
 	"github.com/go-pg/pg/v10/orm"
@@ -20,13 +21,24 @@ func NewEventsRepo(db orm.DB) EventsRepo {
 	return EventsRepo{
 		db: db,
 		filters: map[string][]Filter{
-			Tables.Event.Name: {StatusFilter},
+			Tables.Event.Name:    {StatusFilter},
+			Tables.Vacation.Name: {StatusFilter},
 		},
 		sort: map[string][]SortField{
-			Tables.Event.Name: {{Column: Columns.Event.CreatedAt, Direction: SortDesc}},
+			Tables.Event.Name:    {{Column: Columns.Event.CreatedAt, Direction: SortDesc}},
+			Tables.Tag.Name:      {{Column: Columns.Tag.Title, Direction: SortAsc}},
+			Tables.Vacation.Name: {{Column: Columns.Vacation.StartsAt, Direction: SortAsc}},
+			Tables.SendStat.Name: {{Column: Columns.SendStat.Day, Direction: SortDesc}},
 		},
 		join: map[string][]string{
-			Tables.Event.Name: {TableColumns},
+			Tables.Event.Name:        {TableColumns},
+			Tables.EventTag.Name:     {TableColumns, Columns.EventTag.Event, Columns.EventTag.Tag},
+			Tables.Tag.Name:          {TableColumns},
+			Tables.Vacation.Name:     {TableColumns},
+			Tables.Conversation.Name: {TableColumns},
+			Tables.User.Name:         {TableColumns},
+			Tables.Snooze.Name:       {TableColumns, Columns.Snooze.Event},
+			Tables.SendStat.Name:     {TableColumns},
 		},
 	}
 }
@@ -125,3 +137,508 @@ func (er EventsRepo) DeleteEvent(ctx context.Context, id int) (deleted bool, err
 
 	return er.UpdateEvent(ctx, event, WithColumns(Columns.Event.StatusID))
 }
+
+/*** EventTag ***/
+
+// FullEventTag returns full joins with all columns
+func (er EventsRepo) FullEventTag() OpFunc {
+	return WithColumns(er.join[Tables.EventTag.Name]...)
+}
+
+// EventTagsByFilters returns EventTag list.
+func (er EventsRepo) EventTagsByFilters(ctx context.Context, search *EventTagSearch, pager Pager, ops ...OpFunc) (eventTags []EventTag, err error) {
+	err = buildQuery(ctx, er.db, &eventTags, search, er.filters[Tables.EventTag.Name], pager, ops...).Select()
+	return
+}
+
+// CountEventTags returns count
+func (er EventsRepo) CountEventTags(ctx context.Context, search *EventTagSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &EventTag{}, search, er.filters[Tables.EventTag.Name], PagerOne, ops...).Count()
+}
+
+// AddEventTag adds EventTag to DB.
+func (er EventsRepo) AddEventTag(ctx context.Context, eventTag *EventTag, ops ...OpFunc) (*EventTag, error) {
+	q := er.db.ModelContext(ctx, eventTag)
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return eventTag, err
+}
+
+// DeleteEventTag deletes EventTag from DB.
+func (er EventsRepo) DeleteEventTag(ctx context.Context, eventTag *EventTag) (deleted bool, err error) {
+	res, err := er.db.ModelContext(ctx, eventTag).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** Tag ***/
+
+// FullTag returns full joins with all columns
+func (er EventsRepo) FullTag() OpFunc {
+	return WithColumns(er.join[Tables.Tag.Name]...)
+}
+
+// DefaultTagSort returns default sort.
+func (er EventsRepo) DefaultTagSort() OpFunc {
+	return WithSort(er.sort[Tables.Tag.Name]...)
+}
+
+// TagByID is a function that returns Tag by ID(s) or nil.
+func (er EventsRepo) TagByID(ctx context.Context, id int, ops ...OpFunc) (*Tag, error) {
+	return er.OneTag(ctx, &TagSearch{ID: &id}, ops...)
+}
+
+// OneTag is a function that returns one Tag by filters. It could return pg.ErrMultiRows.
+func (er EventsRepo) OneTag(ctx context.Context, search *TagSearch, ops ...OpFunc) (*Tag, error) {
+	obj := &Tag{}
+	err := buildQuery(ctx, er.db, obj, search, er.filters[Tables.Tag.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// TagsByFilters returns Tag list.
+func (er EventsRepo) TagsByFilters(ctx context.Context, search *TagSearch, pager Pager, ops ...OpFunc) (tags []Tag, err error) {
+	err = buildQuery(ctx, er.db, &tags, search, er.filters[Tables.Tag.Name], pager, ops...).Select()
+	return
+}
+
+// CountTags returns count
+func (er EventsRepo) CountTags(ctx context.Context, search *TagSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &Tag{}, search, er.filters[Tables.Tag.Name], PagerOne, ops...).Count()
+}
+
+// AddTag adds Tag to DB.
+func (er EventsRepo) AddTag(ctx context.Context, tag *Tag, ops ...OpFunc) (*Tag, error) {
+	q := er.db.ModelContext(ctx, tag)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Tag.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return tag, err
+}
+
+// UpdateTag updates Tag in DB.
+func (er EventsRepo) UpdateTag(ctx context.Context, tag *Tag, ops ...OpFunc) (bool, error) {
+	q := er.db.ModelContext(ctx, tag).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Tag.ID, Columns.Tag.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteTag deletes Tag from DB.
+func (er EventsRepo) DeleteTag(ctx context.Context, id int) (deleted bool, err error) {
+	tag := &Tag{ID: id}
+
+	res, err := er.db.ModelContext(ctx, tag).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** Vacation ***/
+
+// FullVacation returns full joins with all columns
+func (er EventsRepo) FullVacation() OpFunc {
+	return WithColumns(er.join[Tables.Vacation.Name]...)
+}
+
+// DefaultVacationSort returns default sort.
+func (er EventsRepo) DefaultVacationSort() OpFunc {
+	return WithSort(er.sort[Tables.Vacation.Name]...)
+}
+
+// VacationByID is a function that returns Vacation by ID(s) or nil.
+func (er EventsRepo) VacationByID(ctx context.Context, id int, ops ...OpFunc) (*Vacation, error) {
+	return er.OneVacation(ctx, &VacationSearch{ID: &id}, ops...)
+}
+
+// OneVacation is a function that returns one Vacation by filters. It could return pg.ErrMultiRows.
+func (er EventsRepo) OneVacation(ctx context.Context, search *VacationSearch, ops ...OpFunc) (*Vacation, error) {
+	obj := &Vacation{}
+	err := buildQuery(ctx, er.db, obj, search, er.filters[Tables.Vacation.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// VacationsByFilters returns Vacation list.
+func (er EventsRepo) VacationsByFilters(ctx context.Context, search *VacationSearch, pager Pager, ops ...OpFunc) (vacations []Vacation, err error) {
+	err = buildQuery(ctx, er.db, &vacations, search, er.filters[Tables.Vacation.Name], pager, ops...).Select()
+	return
+}
+
+// CountVacations returns count
+func (er EventsRepo) CountVacations(ctx context.Context, search *VacationSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &Vacation{}, search, er.filters[Tables.Vacation.Name], PagerOne, ops...).Count()
+}
+
+// AddVacation adds Vacation to DB.
+func (er EventsRepo) AddVacation(ctx context.Context, vacation *Vacation, ops ...OpFunc) (*Vacation, error) {
+	q := er.db.ModelContext(ctx, vacation)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Vacation.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return vacation, err
+}
+
+// UpdateVacation updates Vacation in DB.
+func (er EventsRepo) UpdateVacation(ctx context.Context, vacation *Vacation, ops ...OpFunc) (bool, error) {
+	q := er.db.ModelContext(ctx, vacation).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Vacation.ID, Columns.Vacation.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteVacation set statusId to deleted in DB.
+func (er EventsRepo) DeleteVacation(ctx context.Context, id int) (deleted bool, err error) {
+	vacation := &Vacation{ID: id, StatusID: StatusDeleted}
+
+	return er.UpdateVacation(ctx, vacation, WithColumns(Columns.Vacation.StatusID))
+}
+
+/*** Conversation ***/
+
+// FullConversation returns full joins with all columns
+func (er EventsRepo) FullConversation() OpFunc {
+	return WithColumns(er.join[Tables.Conversation.Name]...)
+}
+
+// ConversationByID is a function that returns Conversation by ID(s) or nil.
+func (er EventsRepo) ConversationByID(ctx context.Context, userTgID int64, ops ...OpFunc) (*Conversation, error) {
+	return er.OneConversation(ctx, &ConversationSearch{UserTgID: &userTgID}, ops...)
+}
+
+// OneConversation is a function that returns one Conversation by filters. It could return pg.ErrMultiRows.
+func (er EventsRepo) OneConversation(ctx context.Context, search *ConversationSearch, ops ...OpFunc) (*Conversation, error) {
+	obj := &Conversation{}
+	err := buildQuery(ctx, er.db, obj, search, er.filters[Tables.Conversation.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// ConversationsByFilters returns Conversation list.
+func (er EventsRepo) ConversationsByFilters(ctx context.Context, search *ConversationSearch, pager Pager, ops ...OpFunc) (conversations []Conversation, err error) {
+	err = buildQuery(ctx, er.db, &conversations, search, er.filters[Tables.Conversation.Name], pager, ops...).Select()
+	return
+}
+
+// CountConversations returns count
+func (er EventsRepo) CountConversations(ctx context.Context, search *ConversationSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &Conversation{}, search, er.filters[Tables.Conversation.Name], PagerOne, ops...).Count()
+}
+
+// AddConversation adds Conversation to DB.
+func (er EventsRepo) AddConversation(ctx context.Context, conversation *Conversation, ops ...OpFunc) (*Conversation, error) {
+	q := er.db.ModelContext(ctx, conversation)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Conversation.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return conversation, err
+}
+
+// UpdateConversation updates Conversation in DB.
+func (er EventsRepo) UpdateConversation(ctx context.Context, conversation *Conversation, ops ...OpFunc) (bool, error) {
+	q := er.db.ModelContext(ctx, conversation).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Conversation.UserTgID, Columns.Conversation.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteConversation deletes Conversation from DB.
+func (er EventsRepo) DeleteConversation(ctx context.Context, userTgID int64) (deleted bool, err error) {
+	conversation := &Conversation{UserTgID: userTgID}
+
+	res, err := er.db.ModelContext(ctx, conversation).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** User ***/
+
+// FullUser returns full joins with all columns
+func (er EventsRepo) FullUser() OpFunc {
+	return WithColumns(er.join[Tables.User.Name]...)
+}
+
+// UserByID is a function that returns User by ID(s) or nil.
+func (er EventsRepo) UserByID(ctx context.Context, userTgID int64, ops ...OpFunc) (*User, error) {
+	return er.OneUser(ctx, &UserSearch{UserTgID: &userTgID}, ops...)
+}
+
+// OneUser is a function that returns one User by filters. It could return pg.ErrMultiRows.
+func (er EventsRepo) OneUser(ctx context.Context, search *UserSearch, ops ...OpFunc) (*User, error) {
+	obj := &User{}
+	err := buildQuery(ctx, er.db, obj, search, er.filters[Tables.User.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// UsersByFilters returns User list.
+func (er EventsRepo) UsersByFilters(ctx context.Context, search *UserSearch, pager Pager, ops ...OpFunc) (users []User, err error) {
+	err = buildQuery(ctx, er.db, &users, search, er.filters[Tables.User.Name], pager, ops...).Select()
+	return
+}
+
+// CountUsers returns count
+func (er EventsRepo) CountUsers(ctx context.Context, search *UserSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &User{}, search, er.filters[Tables.User.Name], PagerOne, ops...).Count()
+}
+
+// AddUser adds User to DB.
+func (er EventsRepo) AddUser(ctx context.Context, user *User, ops ...OpFunc) (*User, error) {
+	q := er.db.ModelContext(ctx, user)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.User.CreatedAt, Columns.User.LastSeenAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return user, err
+}
+
+// UpdateUser updates User in DB.
+func (er EventsRepo) UpdateUser(ctx context.Context, user *User, ops ...OpFunc) (bool, error) {
+	q := er.db.ModelContext(ctx, user).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.User.UserTgID, Columns.User.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteUser deletes User from DB.
+func (er EventsRepo) DeleteUser(ctx context.Context, userTgID int64) (deleted bool, err error) {
+	user := &User{UserTgID: userTgID}
+
+	res, err := er.db.ModelContext(ctx, user).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** Snooze ***/
+
+// FullSnooze returns full joins with all columns
+func (er EventsRepo) FullSnooze() OpFunc {
+	return WithColumns(er.join[Tables.Snooze.Name]...)
+}
+
+// DefaultSnoozeSort returns default sort.
+func (er EventsRepo) DefaultSnoozeSort() OpFunc {
+	return WithSort(er.sort[Tables.Snooze.Name]...)
+}
+
+// SnoozeByID is a function that returns Snooze by ID(s) or nil.
+func (er EventsRepo) SnoozeByID(ctx context.Context, id int, ops ...OpFunc) (*Snooze, error) {
+	return er.OneSnooze(ctx, &SnoozeSearch{ID: &id}, ops...)
+}
+
+// OneSnooze is a function that returns one Snooze by filters. It could return pg.ErrMultiRows.
+func (er EventsRepo) OneSnooze(ctx context.Context, search *SnoozeSearch, ops ...OpFunc) (*Snooze, error) {
+	obj := &Snooze{}
+	err := buildQuery(ctx, er.db, obj, search, er.filters[Tables.Snooze.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// SnoozesByFilters returns Snooze list.
+func (er EventsRepo) SnoozesByFilters(ctx context.Context, search *SnoozeSearch, pager Pager, ops ...OpFunc) (snoozes []Snooze, err error) {
+	err = buildQuery(ctx, er.db, &snoozes, search, er.filters[Tables.Snooze.Name], pager, ops...).Select()
+	return
+}
+
+// CountSnoozes returns count
+func (er EventsRepo) CountSnoozes(ctx context.Context, search *SnoozeSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &Snooze{}, search, er.filters[Tables.Snooze.Name], PagerOne, ops...).Count()
+}
+
+// AddSnooze adds Snooze to DB.
+func (er EventsRepo) AddSnooze(ctx context.Context, snooze *Snooze, ops ...OpFunc) (*Snooze, error) {
+	q := er.db.ModelContext(ctx, snooze)
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Snooze.CreatedAt)
+	}
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return snooze, err
+}
+
+// UpdateSnooze updates Snooze in DB.
+func (er EventsRepo) UpdateSnooze(ctx context.Context, snooze *Snooze, ops ...OpFunc) (bool, error) {
+	q := er.db.ModelContext(ctx, snooze).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.Snooze.ID, Columns.Snooze.CreatedAt)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteSnooze deletes Snooze from DB.
+func (er EventsRepo) DeleteSnooze(ctx context.Context, id int) (deleted bool, err error) {
+	snooze := &Snooze{ID: id}
+
+	res, err := er.db.ModelContext(ctx, snooze).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+/*** SendStat ***/
+
+// FullSendStat returns full joins with all columns
+func (er EventsRepo) FullSendStat() OpFunc {
+	return WithColumns(er.join[Tables.SendStat.Name]...)
+}
+
+// DefaultSendStatSort returns default sort.
+func (er EventsRepo) DefaultSendStatSort() OpFunc {
+	return WithSort(er.sort[Tables.SendStat.Name]...)
+}
+
+// SendStatByID is a function that returns SendStat by ID(s) or nil.
+func (er EventsRepo) SendStatByID(ctx context.Context, day time.Time, ops ...OpFunc) (*SendStat, error) {
+	return er.OneSendStat(ctx, &SendStatSearch{Day: &day}, ops...)
+}
+
+// OneSendStat is a function that returns one SendStat by filters. It could return pg.ErrMultiRows.
+func (er EventsRepo) OneSendStat(ctx context.Context, search *SendStatSearch, ops ...OpFunc) (*SendStat, error) {
+	obj := &SendStat{}
+	err := buildQuery(ctx, er.db, obj, search, er.filters[Tables.SendStat.Name], PagerTwo, ops...).Select()
+
+	if errors.Is(err, pg.ErrMultiRows) {
+		return nil, err
+	} else if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return obj, err
+}
+
+// SendStatsByFilters returns SendStat list.
+func (er EventsRepo) SendStatsByFilters(ctx context.Context, search *SendStatSearch, pager Pager, ops ...OpFunc) (sendStats []SendStat, err error) {
+	err = buildQuery(ctx, er.db, &sendStats, search, er.filters[Tables.SendStat.Name], pager, ops...).Select()
+	return
+}
+
+// CountSendStats returns count
+func (er EventsRepo) CountSendStats(ctx context.Context, search *SendStatSearch, ops ...OpFunc) (int, error) {
+	return buildQuery(ctx, er.db, &SendStat{}, search, er.filters[Tables.SendStat.Name], PagerOne, ops...).Count()
+}
+
+// AddSendStat adds SendStat to DB.
+func (er EventsRepo) AddSendStat(ctx context.Context, sendStat *SendStat, ops ...OpFunc) (*SendStat, error) {
+	q := er.db.ModelContext(ctx, sendStat)
+	applyOps(q, ops...)
+	_, err := q.Insert()
+
+	return sendStat, err
+}
+
+// UpdateSendStat updates SendStat in DB.
+func (er EventsRepo) UpdateSendStat(ctx context.Context, sendStat *SendStat, ops ...OpFunc) (bool, error) {
+	q := er.db.ModelContext(ctx, sendStat).WherePK()
+	if len(ops) == 0 {
+		q = q.ExcludeColumn(Columns.SendStat.Day)
+	}
+	applyOps(q, ops...)
+	res, err := q.Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
+
+// DeleteSendStat deletes SendStat from DB.
+func (er EventsRepo) DeleteSendStat(ctx context.Context, day time.Time) (deleted bool, err error) {
+	sendStat := &SendStat{Day: day}
+
+	res, err := er.db.ModelContext(ctx, sendStat).WherePK().Delete()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, err
+}
@@ -2,15 +2,18 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
+
+	"github.com/go-pg/pg/v10"
+	"github.com/go-pg/pg/v10/orm"
 )
 
+// CountUserPeriodicEvents returns count of active and paused periodic user events.
 func (er EventsRepo) CountUserPeriodicEvents(ctx context.Context, userTgID int64) (int, error) {
-	StatusEnabled := StatusEnabled
 	search := &EventSearch{
 		UserTgID: &userTgID,
-		StatusID: &StatusEnabled,
 	}
 
 	search.WithPeriodicityNotNull()
@@ -35,24 +38,6 @@ func (es *EventSearch) WithPeriodicityNotNull() *EventSearch {
 	return es
 }
 
-func (r *EventsRepo) AllUsersWithEventsToday(ctx context.Context) ([]int64, error) {
-	var users []int64
-
-	query := `
-        SELECT DISTINCT "userTgId"
-        FROM "events"
-        WHERE "statusId" = 1
-          AND DATE("sendAt" AT TIME ZONE 'Europe/Moscow') = DATE(NOW() AT TIME ZONE 'Europe/Moscow')
-    `
-
-	_, err := r.db.QueryContext(ctx, &users, query)
-	if err != nil {
-		return nil, err
-	}
-
-	return users, nil
-}
-
 func (er EventsRepo) EventsToSend(ctx context.Context) ([]Event, error) {
 	now := time.Now()
 	statusId := StatusEnabled
@@ -64,3 +49,148 @@ func (er EventsRepo) EventsToSend(ctx context.Context) ([]Event, error) {
 
 	return er.EventsByFilters(ctx, search, PagerNoLimit)
 }
+
+// EventsToResume returns paused events with resume time in the past.
+func (er EventsRepo) EventsToResume(ctx context.Context) ([]Event, error) {
+	statusID := StatusDisabled
+	search := &EventSearch{
+		StatusID: &statusID,
+	}
+	search.With(`t."resumeAt" <= now()`)
+
+	return er.EventsByFilters(ctx, search, PagerNoLimit)
+}
+
+// searchSimilarity is the minimal pg_trgm word similarity for typo-tolerant search.
+const searchSimilarity = 0.3
+
+// eventsTSVector must match IX_events_fts index expression.
+const eventsTSVector = `to_tsvector('russian', t."message" || ' ' || coalesce(t."notes", ''))`
+
+// SearchUserEvents returns enabled user events matching query by full-text search or by trigram similarity of the title.
+func (er EventsRepo) SearchUserEvents(ctx context.Context, userTgID int64, query string, pager Pager) ([]Event, int, error) {
+	statusID := StatusEnabled
+	search := &EventSearch{
+		UserTgID: &userTgID,
+		StatusID: &statusID,
+	}
+	search.With(`(`+eventsTSVector+` @@ websearch_to_tsquery('russian', ?) OR word_similarity(?, t."message") >= ?)`, query, query, searchSimilarity)
+
+	total, err := er.CountEvents(ctx, search)
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка подсчета результатов поиска: %w", err)
+	}
+
+	events, err := er.EventsByFilters(ctx, search, pager, func(q *orm.Query) {
+		q.OrderExpr(`ts_rank(`+eventsTSVector+`, websearch_to_tsquery('russian', ?)) DESC`, query).
+			OrderExpr(`word_similarity(?, t."message") DESC`, query).
+			OrderExpr(`t."sendAt" ASC`)
+	})
+	if err != nil {
+		return nil, 0, fmt.Errorf("ошибка поиска событий: %w", err)
+	}
+
+	return events, total, nil
+}
+
+// BulkUpdateEvents applies fn to every non-deleted user event from ids in one transaction.
+// It returns the number of processed events.
+func (er EventsRepo) BulkUpdateEvents(ctx context.Context, userTgID int64, ids []int, fn func(r EventsRepo, event *Event) error) (int, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	var processed int
+	err := er.RunInTx(ctx, func(r EventsRepo) error {
+		search := &EventSearch{UserTgID: &userTgID, IDs: ids}
+
+		events, err := r.EventsByFilters(ctx, search, PagerNoLimit, func(q *orm.Query) { q.For("UPDATE") })
+		if err != nil {
+			return fmt.Errorf("ошибка загрузки событий: %w", err)
+		}
+
+		for i := range events {
+			if err = fn(r, &events[i]); err != nil {
+				return fmt.Errorf("ошибка обновления события %d: %w", events[i].ID, err)
+			}
+		}
+		processed = len(events)
+
+		return nil
+	})
+
+	return processed, err
+}
+
+// TrashEvent sets statusId to deleted and remembers deletion time and previous status, so the event could be restored from trash.
+func (er EventsRepo) TrashEvent(ctx context.Context, id int) (bool, error) {
+	res, err := er.db.ModelContext(ctx, (*Event)(nil)).
+		Set(`"previousStatusId" = t."statusId"`).
+		Set(`"statusId" = ?`, StatusDeleted).
+		Set(`"deletedAt" = ?`, time.Now()).
+		Where(`t."eventId" = ?`, id).
+		Where(`t."statusId" != ?`, StatusDeleted).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// TrashedEvents returns user events deleted after since, most recent first.
+func (er EventsRepo) TrashedEvents(ctx context.Context, userTgID int64, since time.Time, pager Pager) ([]Event, error) {
+	var events []Event
+	q := er.db.ModelContext(ctx, &events).
+		Where(`t."userTgId" = ?`, userTgID).
+		Where(`t."statusId" = ?`, StatusDeleted).
+		Where(`t."deletedAt" >= ?`, since).
+		OrderExpr(`t."deletedAt" DESC`)
+
+	err := pager.Apply(q).Select()
+	return events, err
+}
+
+// TrashedEventByID returns deleted user event or nil.
+func (er EventsRepo) TrashedEventByID(ctx context.Context, userTgID int64, id int) (*Event, error) {
+	event := &Event{}
+	err := er.db.ModelContext(ctx, event).
+		Where(`t."eventId" = ?`, id).
+		Where(`t."userTgId" = ?`, userTgID).
+		Where(`t."statusId" = ?`, StatusDeleted).
+		Select()
+	if errors.Is(err, pg.ErrNoRows) {
+		return nil, nil
+	}
+
+	return event, err
+}
+
+// RestoreEvent moves deleted event back from trash with its status and send time.
+func (er EventsRepo) RestoreEvent(ctx context.Context, event *Event) (bool, error) {
+	event.DeletedAt, event.PreviousStatusID = nil, nil
+
+	res, err := er.db.ModelContext(ctx, event).
+		Column(Columns.Event.StatusID, Columns.Event.SendAt, Columns.Event.DeletedAt, Columns.Event.PreviousStatusID).
+		WherePK().
+		Where(`t."statusId" = ?`, StatusDeleted).
+		Update()
+	if err != nil {
+		return false, err
+	}
+
+	return res.RowsAffected() > 0, nil
+}
+
+// PurgeDeletedEvents removes from DB events moved to trash before given time.
+// Deleted events without deletion time were never in trash and are kept.
+func (er EventsRepo) PurgeDeletedEvents(ctx context.Context, before time.Time) (int, error) {
+	res, err := er.db.ExecContext(ctx,
+		`DELETE FROM "events" WHERE "statusId" = ? AND "deletedAt" IS NOT NULL AND "deletedAt" < ?`,
+		StatusDeleted, before)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
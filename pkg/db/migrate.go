@@ -0,0 +1,244 @@
+package db
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+// migrationsLock is advisory lock name, so concurrent runs do not apply the same migration twice.
+const migrationsLock = "schemaMigrations"
+
+var (
+	ErrIrreversibleMigration = errors.New("migration cannot be reverted")
+	ErrNoMigrations          = errors.New("no applied migrations")
+	ErrUnknownMigrations     = errors.New("database has migrations unknown to this binary")
+
+	migrationNameRe = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+)
+
+// Migration is a versioned schema change from migrations directory: NNNN_name.up.sql with optional NNNN_name.down.sql.
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is a migration with time it was applied, nil AppliedAt means pending migration.
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type schemaMigration struct {
+	tableName struct{} `pg:"schemaMigrations,alias:t,discard_unknown_columns"`
+
+	Version   int       `pg:"version,pk"`
+	Name      string    `pg:"name,use_zero"`
+	AppliedAt time.Time `pg:"appliedAt,use_zero"`
+}
+
+// Migrations returns embedded migrations ordered by version.
+func Migrations() ([]Migration, error) {
+	return loadMigrations(migrationsFS, "migrations")
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		m := migrationNameRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			return nil, fmt.Errorf("invalid migration file name %q", e.Name())
+		}
+
+		b, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		version, _ := strconv.Atoi(m[1])
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: m[2]}
+			byVersion[version] = mg
+		} else if mg.Name != m[2] {
+			return nil, fmt.Errorf("migration %d has different names: %s and %s", version, mg.Name, m[2])
+		}
+
+		if m[3] == "up" {
+			mg.Up = string(b)
+		} else {
+			mg.Down = string(b)
+		}
+	}
+
+	res := make([]Migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		if mg.Up == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up script", mg.Version, mg.Name)
+		}
+		res = append(res, *mg)
+	}
+	slices.SortFunc(res, func(a, b Migration) int { return a.Version - b.Version })
+
+	for i, mg := range res {
+		if mg.Version != i+1 {
+			return nil, fmt.Errorf("migration %d_%s is out of sequence, want version %d", mg.Version, mg.Name, i+1)
+		}
+	}
+
+	return res, nil
+}
+
+// MigrationsStatus returns all migrations with their applied time.
+func (db *DB) MigrationsStatus(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var applied []schemaMigration
+	if err = db.RunInLock(ctx, migrationsLock, func(tx *pg.Tx) error {
+		applied, err = appliedMigrations(ctx, tx)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	res := make([]MigrationStatus, len(migrations))
+	for i, mg := range migrations {
+		res[i].Migration = mg
+		if j := slices.IndexFunc(applied, func(s schemaMigration) bool { return s.Version == mg.Version }); j >= 0 {
+			res[i].AppliedAt = &applied[j].AppliedAt
+		}
+	}
+
+	return res, nil
+}
+
+// MigrateUp applies all pending migrations in one transaction and returns them.
+func (db *DB) MigrateUp(ctx context.Context) ([]Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []Migration
+	err = db.RunInLock(ctx, migrationsLock, func(tx *pg.Tx) error {
+		applied, err := appliedMigrations(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if len(applied) > len(migrations) {
+			return ErrUnknownMigrations
+		}
+
+		for _, mg := range migrations[len(applied):] {
+			if _, err = tx.ExecContext(ctx, mg.Up); err != nil {
+				return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+			}
+
+			_, err = tx.ModelContext(ctx, &schemaMigration{Version: mg.Version, Name: mg.Name, AppliedAt: time.Now()}).Insert()
+			if err != nil {
+				return err
+			}
+
+			res = append(res, mg)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// MigrateDown reverts the last applied migration and returns it.
+func (db *DB) MigrateDown(ctx context.Context) (*Migration, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var res *Migration
+	err = db.RunInLock(ctx, migrationsLock, func(tx *pg.Tx) error {
+		applied, err := appliedMigrations(ctx, tx)
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			return ErrNoMigrations
+		}
+		if len(applied) > len(migrations) {
+			return ErrUnknownMigrations
+		}
+
+		mg := migrations[len(applied)-1]
+		if mg.Down == "" {
+			return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, ErrIrreversibleMigration)
+		}
+
+		if _, err = tx.ExecContext(ctx, mg.Down); err != nil {
+			return fmt.Errorf("migration %d_%s: %w", mg.Version, mg.Name, err)
+		}
+
+		if _, err = tx.ModelContext(ctx, &schemaMigration{Version: mg.Version}).WherePK().Delete(); err != nil {
+			return err
+		}
+
+		res = &mg
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// appliedMigrations creates migrations table if needed and returns applied migrations ordered by version.
+func appliedMigrations(ctx context.Context, tx *pg.Tx) ([]schemaMigration, error) {
+	_, err := tx.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS "schemaMigrations" (
+	"version" int4 NOT NULL,
+	"name" varchar(128) NOT NULL,
+	"appliedAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "schemaMigrations_pkey" PRIMARY KEY("version")
+)`)
+	if err != nil {
+		return nil, err
+	}
+
+	var res []schemaMigration
+	err = tx.ModelContext(ctx, &res).Order("version").Select()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, s := range res {
+		if s.Version != i+1 {
+			return nil, fmt.Errorf("applied migrations are out of sequence at version %d", s.Version)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,67 @@
+package db
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, mg := range migrations {
+		if i > 0 && mg.Down == "" {
+			t.Errorf("%04d_%s: no down script", mg.Version, mg.Name)
+		}
+	}
+}
+
+// TestMigrationsIdempotent checks that up scripts create objects only if they do not exist,
+// so a database created from docs/botsrv.sql could adopt migrations.
+func TestMigrationsIdempotent(t *testing.T) {
+	migrations, err := Migrations()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	re := regexp.MustCompile(`(CREATE TABLE|CREATE (?:UNIQUE )?INDEX|CREATE EXTENSION|ADD COLUMN)( IF NOT EXISTS)?`)
+	for _, mg := range migrations {
+		for _, m := range re.FindAllStringSubmatch(mg.Up, -1) {
+			if m[2] == "" {
+				t.Errorf("%04d_%s: %s without IF NOT EXISTS", mg.Version, mg.Name, m[1])
+			}
+		}
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := &fstest.MapFile{Data: []byte("SELECT 1;")}
+
+	tests := []struct {
+		name  string
+		files []string
+		err   string
+	}{
+		{"valid", []string{"0001_init.up.sql", "0002_add.up.sql", "0002_add.down.sql"}, ""},
+		{"gap", []string{"0001_init.up.sql", "0003_add.up.sql"}, "out of sequence"},
+		{"no up", []string{"0001_init.up.sql", "0002_add.down.sql"}, "no up script"},
+		{"names", []string{"0001_init.up.sql", "0001_other.down.sql"}, "different names"},
+		{"file name", []string{"0001_init.sql"}, "invalid migration file name"},
+	}
+
+	for _, tt := range tests {
+		fsys := fstest.MapFS{}
+		for _, name := range tt.files {
+			fsys["migrations/"+name] = file
+		}
+
+		_, err := loadMigrations(fsys, "migrations")
+		if tt.err == "" && err != nil || tt.err != "" && (err == nil || !strings.Contains(err.Error(), tt.err)) {
+			t.Errorf("%s: got %v, want %q", tt.name, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+-- Initial schema. Like all migrations it is idempotent, so a database created from docs/botsrv.sql
+-- of any version, including the one before migrations, adopts them with "migrate up".
+
+CREATE TABLE IF NOT EXISTS "events" (
+	"eventId" SERIAL NOT NULL,
+	"userTgId" int4 NOT NULL,
+	"message" text NOT NULL,
+	"sendAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"statusId" int4 NOT NULL DEFAULT 1,
+	"weekdays" integer[],
+	"periodicity" varchar(16) CHECK (periodicity IN ('hour', 'day', 'week', 'weekdays', NULL)),
+	PRIMARY KEY("eventId")
+);
+
+-- the table exists in databases created before migrations, so new columns are added separately
+ALTER TABLE "events"
+	ADD COLUMN IF NOT EXISTS "notes" text,
+	ADD COLUMN IF NOT EXISTS "deletedAt" timestamp with time zone,
+	ADD COLUMN IF NOT EXISTS "resumeAt" timestamp with time zone;
+
+CREATE TABLE IF NOT EXISTS "tags" (
+	"tagId" SERIAL NOT NULL,
+	"userTgId" int8 NOT NULL,
+	"title" varchar(64) NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "tags_pkey" PRIMARY KEY("tagId"),
+	CONSTRAINT "tags_userTgId_title_key" UNIQUE("userTgId", "title")
+);
+
+CREATE TABLE IF NOT EXISTS "eventTags" (
+	"eventId" int4 NOT NULL,
+	"tagId" int4 NOT NULL,
+	CONSTRAINT "eventTags_pkey" PRIMARY KEY("eventId", "tagId"),
+	CONSTRAINT "eventTags_eventId_fkey" FOREIGN KEY ("eventId") REFERENCES "events"("eventId") ON DELETE CASCADE,
+	CONSTRAINT "eventTags_tagId_fkey" FOREIGN KEY ("tagId") REFERENCES "tags"("tagId") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_FK_eventTags_tagId_eventTags" ON "eventTags" USING BTREE (
+	"tagId"
+);
+
+CREATE TABLE IF NOT EXISTS "vacations" (
+	"vacationId" SERIAL NOT NULL,
+	"userTgId" int8 NOT NULL,
+	"startsAt" timestamp with time zone NOT NULL,
+	"endsAt" timestamp with time zone NOT NULL,
+	"mode" varchar(16) NOT NULL DEFAULT 'summary' CHECK ("mode" IN ('summary', 'shift')),
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"statusId" int4 NOT NULL DEFAULT 1,
+	CONSTRAINT "vacations_pkey" PRIMARY KEY("vacationId")
+);
+
+CREATE INDEX IF NOT EXISTS "IX_vacations_userTgId" ON "vacations" USING BTREE (
+	"userTgId", "endsAt"
+);
+
+CREATE TABLE IF NOT EXISTS "conversations" (
+	"userTgId" int8 NOT NULL,
+	"state" varchar(32) NOT NULL,
+	"params" jsonb,
+	"expiresAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "conversations_pkey" PRIMARY KEY("userTgId")
+);
+
+CREATE INDEX IF NOT EXISTS "IX_conversations_expiresAt" ON "conversations" USING BTREE (
+	"expiresAt"
+);
+
+CREATE TABLE IF NOT EXISTS "users" (
+	"userTgId" int8 NOT NULL,
+	"name" varchar(128),
+	"languageCode" varchar(16),
+	"language" varchar(8),
+	"timeZone" varchar(64) NOT NULL DEFAULT 'Europe/Moscow',
+	"digestTime" int4 DEFAULT 480 CHECK ("digestTime" >= 0 AND "digestTime" < 1440),
+	"quietFrom" int4 CHECK ("quietFrom" >= 0 AND "quietFrom" < 1440),
+	"quietTo" int4 CHECK ("quietTo" >= 0 AND "quietTo" < 1440),
+	"snoozePresets" integer[] NOT NULL DEFAULT '{5,10}',
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	"lastSeenAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "users_pkey" PRIMARY KEY("userTgId")
+);
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS "IX_events_fts" ON "events" USING GIN (
+	to_tsvector('russian', "message" || ' ' || coalesce("notes", ''))
+);
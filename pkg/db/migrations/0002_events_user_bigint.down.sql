@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS "IX_events_statusId_sendAt";
+
+ALTER TABLE "events" ALTER COLUMN "userTgId" TYPE int4;
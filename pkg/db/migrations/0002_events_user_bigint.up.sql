@@ -0,0 +1,6 @@
+-- Telegram user and group IDs exceed int4.
+ALTER TABLE "events" ALTER COLUMN "userTgId" TYPE int8;
+
+CREATE INDEX IF NOT EXISTS "IX_events_statusId_sendAt" ON "events" USING BTREE (
+	"statusId", "sendAt"
+);
@@ -0,0 +1,9 @@
+-- Evening preview and Sunday weekly review times.
+ALTER TABLE "users"
+	ADD COLUMN IF NOT EXISTS "previewTime" int4 CHECK ("previewTime" >= 0 AND "previewTime" < 1440),
+	ADD COLUMN IF NOT EXISTS "weeklyTime" int4 CHECK ("weeklyTime" >= 0 AND "weeklyTime" < 1440);
+
+-- Digests are scheduled from users table, so users known only by their events get default settings.
+INSERT INTO "users" ("userTgId")
+SELECT DISTINCT "userTgId" FROM "events"
+ON CONFLICT ("userTgId") DO NOTHING;
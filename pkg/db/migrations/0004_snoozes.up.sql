@@ -0,0 +1,14 @@
+-- Snoozed occurrences of periodic events, re-delivered without moving the series.
+CREATE TABLE IF NOT EXISTS "snoozes" (
+	"snoozeId" SERIAL NOT NULL,
+	"eventId" int4 NOT NULL,
+	"userTgId" int8 NOT NULL,
+	"sendAt" timestamp with time zone NOT NULL,
+	"createdAt" timestamp with time zone NOT NULL DEFAULT now(),
+	CONSTRAINT "snoozes_pkey" PRIMARY KEY("snoozeId"),
+	CONSTRAINT "snoozes_eventId_fkey" FOREIGN KEY ("eventId") REFERENCES "events"("eventId") ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS "IX_snoozes_sendAt" ON "snoozes" USING BTREE (
+	"sendAt"
+);
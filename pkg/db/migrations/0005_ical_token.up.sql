@@ -0,0 +1,7 @@
+-- Secret token of private iCalendar subscription feed.
+ALTER TABLE "users"
+	ADD COLUMN IF NOT EXISTS "icalToken" varchar(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "IX_users_icalToken" ON "users" USING BTREE (
+	"icalToken"
+);
@@ -0,0 +1,7 @@
+-- SHA-256 hash of personal JSON-RPC API token, the token itself is shown to the user only once.
+ALTER TABLE "users"
+	ADD COLUMN IF NOT EXISTS "apiTokenHash" varchar(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS "IX_users_apiTokenHash" ON "users" USING BTREE (
+	"apiTokenHash"
+);
@@ -0,0 +1,7 @@
+-- Daily counters of delivered and failed reminders for operators.
+CREATE TABLE IF NOT EXISTS "sendStats" (
+	"day" date NOT NULL,
+	"sent" int4 NOT NULL DEFAULT 0,
+	"failed" int4 NOT NULL DEFAULT 0,
+	CONSTRAINT "sendStats_pkey" PRIMARY KEY("day")
+);
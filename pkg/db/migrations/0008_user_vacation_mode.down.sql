@@ -0,0 +1,9 @@
+ALTER TABLE "vacations"
+	ADD COLUMN "mode" varchar(16) NOT NULL DEFAULT 'summary' CHECK ("mode" IN ('summary', 'shift'));
+
+UPDATE "vacations" v SET "mode" = u."vacationMode"
+FROM "users" u
+WHERE u."userTgId" = v."userTgId";
+
+ALTER TABLE "users"
+	DROP COLUMN "vacationMode";
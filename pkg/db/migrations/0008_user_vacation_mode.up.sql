@@ -0,0 +1,15 @@
+-- Vacation mode is a user setting instead of a property of each vacation.
+ALTER TABLE "users"
+	ADD COLUMN IF NOT EXISTS "vacationMode" varchar(16) NOT NULL DEFAULT 'summary' CHECK ("vacationMode" IN ('summary', 'shift'));
+
+DO $$
+BEGIN
+	IF EXISTS (SELECT 1 FROM information_schema.columns WHERE table_name = 'vacations' AND column_name = 'mode') THEN
+		UPDATE "users" u SET "vacationMode" = v."mode"
+		FROM "vacations" v
+		WHERE v."userTgId" = u."userTgId" AND v."statusId" = 1 AND v."endsAt" > now();
+	END IF;
+END $$;
+
+ALTER TABLE "vacations"
+	DROP COLUMN IF EXISTS "mode";
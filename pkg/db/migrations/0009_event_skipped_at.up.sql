@@ -0,0 +1,3 @@
+-- Time of the first occurrence of periodic event skipped by current vacation.
+ALTER TABLE "events"
+	ADD COLUMN IF NOT EXISTS "skippedAt" timestamp with time zone;
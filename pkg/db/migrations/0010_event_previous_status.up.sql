@@ -0,0 +1,3 @@
+-- Status of deleted event before it was moved to trash, restored event gets it back.
+ALTER TABLE "events"
+	ADD COLUMN IF NOT EXISTS "previousStatusId" int4;
@@ -10,20 +10,128 @@ import (
 
 var Columns = struct {
 	Event struct {
-		ID, UserTgID, Message, SendAt, CreatedAt, StatusID, Weekdays, Periodicity string
+		ID, UserTgID, Message, SendAt, CreatedAt, StatusID, Weekdays, Periodicity, Notes, DeletedAt, ResumeAt, SkippedAt, PreviousStatusID string
+	}
+	EventTag struct {
+		EventID, TagID string
+
+		Event, Tag string
+	}
+	Tag struct {
+		ID, UserTgID, Title, CreatedAt string
+	}
+	Vacation struct {
+		ID, UserTgID, StartsAt, EndsAt, CreatedAt, StatusID string
+	}
+	Conversation struct {
+		UserTgID, State, Params, ExpiresAt, CreatedAt string
+	}
+	User struct {
+		UserTgID, Name, LanguageCode, Language, TimeZone, DigestTime, PreviewTime, WeeklyTime, QuietFrom, QuietTo, SnoozePresets, VacationMode, IcalToken, APITokenHash, CreatedAt, LastSeenAt string
+	}
+	Snooze struct {
+		ID, EventID, UserTgID, SendAt, CreatedAt string
+
+		Event string
+	}
+	SendStat struct {
+		Day, Sent, Failed string
 	}
 }{
 	Event: struct {
-		ID, UserTgID, Message, SendAt, CreatedAt, StatusID, Weekdays, Periodicity string
+		ID, UserTgID, Message, SendAt, CreatedAt, StatusID, Weekdays, Periodicity, Notes, DeletedAt, ResumeAt, SkippedAt, PreviousStatusID string
+	}{
+		ID:               "eventId",
+		UserTgID:         "userTgId",
+		Message:          "message",
+		SendAt:           "sendAt",
+		CreatedAt:        "createdAt",
+		StatusID:         "statusId",
+		Weekdays:         "weekdays",
+		Periodicity:      "periodicity",
+		Notes:            "notes",
+		DeletedAt:        "deletedAt",
+		ResumeAt:         "resumeAt",
+		SkippedAt:        "skippedAt",
+		PreviousStatusID: "previousStatusId",
+	},
+	EventTag: struct {
+		EventID, TagID string
+
+		Event, Tag string
+	}{
+		EventID: "eventId",
+		TagID:   "tagId",
+
+		Event: "Event",
+		Tag:   "Tag",
+	},
+	Tag: struct {
+		ID, UserTgID, Title, CreatedAt string
+	}{
+		ID:        "tagId",
+		UserTgID:  "userTgId",
+		Title:     "title",
+		CreatedAt: "createdAt",
+	},
+	Vacation: struct {
+		ID, UserTgID, StartsAt, EndsAt, CreatedAt, StatusID string
+	}{
+		ID:        "vacationId",
+		UserTgID:  "userTgId",
+		StartsAt:  "startsAt",
+		EndsAt:    "endsAt",
+		CreatedAt: "createdAt",
+		StatusID:  "statusId",
+	},
+	Conversation: struct {
+		UserTgID, State, Params, ExpiresAt, CreatedAt string
+	}{
+		UserTgID:  "userTgId",
+		State:     "state",
+		Params:    "params",
+		ExpiresAt: "expiresAt",
+		CreatedAt: "createdAt",
+	},
+	User: struct {
+		UserTgID, Name, LanguageCode, Language, TimeZone, DigestTime, PreviewTime, WeeklyTime, QuietFrom, QuietTo, SnoozePresets, VacationMode, IcalToken, APITokenHash, CreatedAt, LastSeenAt string
+	}{
+		UserTgID:      "userTgId",
+		Name:          "name",
+		LanguageCode:  "languageCode",
+		Language:      "language",
+		TimeZone:      "timeZone",
+		DigestTime:    "digestTime",
+		PreviewTime:   "previewTime",
+		WeeklyTime:    "weeklyTime",
+		QuietFrom:     "quietFrom",
+		QuietTo:       "quietTo",
+		SnoozePresets: "snoozePresets",
+		VacationMode:  "vacationMode",
+		IcalToken:     "icalToken",
+		APITokenHash:  "apiTokenHash",
+		CreatedAt:     "createdAt",
+		LastSeenAt:    "lastSeenAt",
+	},
+	Snooze: struct {
+		ID, EventID, UserTgID, SendAt, CreatedAt string
+
+		Event string
+	}{
+		ID:        "snoozeId",
+		EventID:   "eventId",
+		UserTgID:  "userTgId",
+		SendAt:    "sendAt",
+		CreatedAt: "createdAt",
+
+		Event: "Event",
+	},
+	SendStat: struct {
+		Day, Sent, Failed string
 	}{
-		ID:          "eventId",
-		UserTgID:    "userTgId",
-		Message:     "message",
-		SendAt:      "sendAt",
-		CreatedAt:   "createdAt",
-		StatusID:    "statusId",
-		Weekdays:    "weekdays",
-		Periodicity: "periodicity",
+		Day:    "day",
+		Sent:   "sent",
+		Failed: "failed",
 	},
 }
 
@@ -31,6 +139,27 @@ var Tables = struct {
 	Event struct {
 		Name, Alias string
 	}
+	EventTag struct {
+		Name, Alias string
+	}
+	Tag struct {
+		Name, Alias string
+	}
+	Vacation struct {
+		Name, Alias string
+	}
+	Conversation struct {
+		Name, Alias string
+	}
+	User struct {
+		Name, Alias string
+	}
+	Snooze struct {
+		Name, Alias string
+	}
+	SendStat struct {
+		Name, Alias string
+	}
 }{
 	Event: struct {
 		Name, Alias string
@@ -38,17 +167,145 @@ var Tables = struct {
 		Name:  "events",
 		Alias: "t",
 	},
+	EventTag: struct {
+		Name, Alias string
+	}{
+		Name:  "eventTags",
+		Alias: "t",
+	},
+	Tag: struct {
+		Name, Alias string
+	}{
+		Name:  "tags",
+		Alias: "t",
+	},
+	Vacation: struct {
+		Name, Alias string
+	}{
+		Name:  "vacations",
+		Alias: "t",
+	},
+	Conversation: struct {
+		Name, Alias string
+	}{
+		Name:  "conversations",
+		Alias: "t",
+	},
+	User: struct {
+		Name, Alias string
+	}{
+		Name:  "users",
+		Alias: "t",
+	},
+	Snooze: struct {
+		Name, Alias string
+	}{
+		Name:  "snoozes",
+		Alias: "t",
+	},
+	SendStat: struct {
+		Name, Alias string
+	}{
+		Name:  "sendStats",
+		Alias: "t",
+	},
 }
 
 type Event struct {
 	tableName struct{} `pg:"events,alias:t,discard_unknown_columns"`
 
-	ID          int       `pg:"eventId,pk"`
-	UserTgID    int64     `pg:"userTgId,use_zero"`
-	Message     string    `pg:"message,use_zero"`
-	SendAt      time.Time `pg:"sendAt,use_zero"`
-	CreatedAt   time.Time `pg:"createdAt,use_zero"`
-	StatusID    int       `pg:"statusId,use_zero"`
-	Weekdays    []int     `pg:"weekdays,array"`
-	Periodicity *string   `pg:"periodicity"`
+	ID               int        `pg:"eventId,pk"`
+	UserTgID         int64      `pg:"userTgId,use_zero"`
+	Message          string     `pg:"message,use_zero"`
+	SendAt           time.Time  `pg:"sendAt,use_zero"`
+	CreatedAt        time.Time  `pg:"createdAt,use_zero"`
+	StatusID         int        `pg:"statusId,use_zero"`
+	Weekdays         []int      `pg:"weekdays,array"`
+	Periodicity      *string    `pg:"periodicity"`
+	Notes            *string    `pg:"notes"`
+	DeletedAt        *time.Time `pg:"deletedAt"`
+	ResumeAt         *time.Time `pg:"resumeAt"`
+	SkippedAt        *time.Time `pg:"skippedAt"`
+	PreviousStatusID *int       `pg:"previousStatusId"`
+}
+
+type EventTag struct {
+	tableName struct{} `pg:"eventTags,alias:t,discard_unknown_columns"`
+
+	EventID int `pg:"eventId,pk"`
+	TagID   int `pg:"tagId,pk"`
+
+	Event *Event `pg:"fk:eventId,rel:has-one"`
+	Tag   *Tag   `pg:"fk:tagId,rel:has-one"`
+}
+
+type Tag struct {
+	tableName struct{} `pg:"tags,alias:t,discard_unknown_columns"`
+
+	ID        int       `pg:"tagId,pk"`
+	UserTgID  int64     `pg:"userTgId,use_zero"`
+	Title     string    `pg:"title,use_zero"`
+	CreatedAt time.Time `pg:"createdAt,use_zero"`
+}
+
+type Vacation struct {
+	tableName struct{} `pg:"vacations,alias:t,discard_unknown_columns"`
+
+	ID        int       `pg:"vacationId,pk"`
+	UserTgID  int64     `pg:"userTgId,use_zero"`
+	StartsAt  time.Time `pg:"startsAt,use_zero"`
+	EndsAt    time.Time `pg:"endsAt,use_zero"`
+	CreatedAt time.Time `pg:"createdAt,use_zero"`
+	StatusID  int       `pg:"statusId,use_zero"`
+}
+
+type Conversation struct {
+	tableName struct{} `pg:"conversations,alias:t,discard_unknown_columns"`
+
+	UserTgID  int64               `pg:"userTgId,pk"`
+	State     string              `pg:"state,use_zero"`
+	Params    *ConversationParams `pg:"params"`
+	ExpiresAt time.Time           `pg:"expiresAt,use_zero"`
+	CreatedAt time.Time           `pg:"createdAt,use_zero"`
+}
+
+type User struct {
+	tableName struct{} `pg:"users,alias:t,discard_unknown_columns"`
+
+	UserTgID      int64     `pg:"userTgId,pk"`
+	Name          *string   `pg:"name"`
+	LanguageCode  *string   `pg:"languageCode"`
+	Language      *string   `pg:"language"`
+	TimeZone      string    `pg:"timeZone,use_zero"`
+	DigestTime    *int      `pg:"digestTime"`
+	PreviewTime   *int      `pg:"previewTime"`
+	WeeklyTime    *int      `pg:"weeklyTime"`
+	QuietFrom     *int      `pg:"quietFrom"`
+	QuietTo       *int      `pg:"quietTo"`
+	SnoozePresets []int     `pg:"snoozePresets,array"`
+	VacationMode  string    `pg:"vacationMode,use_zero"`
+	IcalToken     *string   `pg:"icalToken"`
+	APITokenHash  *string   `pg:"apiTokenHash"`
+	CreatedAt     time.Time `pg:"createdAt,use_zero"`
+	LastSeenAt    time.Time `pg:"lastSeenAt,use_zero"`
+}
+
+type Snooze struct {
+	tableName struct{} `pg:"snoozes,alias:t,discard_unknown_columns"`
+
+	ID        int       `pg:"snoozeId,pk"`
+	EventID   int       `pg:"eventId,use_zero"`
+	UserTgID  int64     `pg:"userTgId,use_zero"`
+	SendAt    time.Time `pg:"sendAt,use_zero"`
+	CreatedAt time.Time `pg:"createdAt,use_zero"`
+
+	Event *Event `pg:"fk:eventId,rel:has-one"`
+}
+
+type SendStat struct {
+	tableName struct{} `pg:"sendStats,alias:t,discard_unknown_columns"`
+
+	Day    time.Time `pg:"day,pk"`
+	Sent   int       `pg:"sent,use_zero"`
+	Failed int       `pg:"failed,use_zero"`
 }
@@ -1 +1,9 @@
 package db
+
+import "time"
+
+// ConversationParams is a payload of conversation state.
+type ConversationParams struct {
+	EventID int        `json:"eventId,omitempty"`
+	SendAt  *time.Time `json:"sendAt,omitempty"`
+}
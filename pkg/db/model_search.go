@@ -62,6 +62,11 @@ type EventSearch struct {
 	CreatedAt        *time.Time
 	StatusID         *int
 	Periodicity      *string
+	Notes            *string
+	DeletedAt        *time.Time
+	ResumeAt         *time.Time
+	SkippedAt        *time.Time
+	PreviousStatusID *int
 	IDs              []int
 	SendAtBefore     *time.Time
 	MessageILike     *string
@@ -93,6 +98,21 @@ func (es *EventSearch) Apply(query *orm.Query) *orm.Query {
 	if es.Periodicity != nil {
 		es.where(query, Tables.Event.Alias, Columns.Event.Periodicity, es.Periodicity)
 	}
+	if es.Notes != nil {
+		es.where(query, Tables.Event.Alias, Columns.Event.Notes, es.Notes)
+	}
+	if es.DeletedAt != nil {
+		es.where(query, Tables.Event.Alias, Columns.Event.DeletedAt, es.DeletedAt)
+	}
+	if es.ResumeAt != nil {
+		es.where(query, Tables.Event.Alias, Columns.Event.ResumeAt, es.ResumeAt)
+	}
+	if es.SkippedAt != nil {
+		es.where(query, Tables.Event.Alias, Columns.Event.SkippedAt, es.SkippedAt)
+	}
+	if es.PreviousStatusID != nil {
+		es.where(query, Tables.Event.Alias, Columns.Event.PreviousStatusID, es.PreviousStatusID)
+	}
 	if len(es.IDs) > 0 {
 		Filter{Columns.Event.ID, es.IDs, SearchTypeArray, false}.Apply(query)
 	}
@@ -119,3 +139,347 @@ func (es *EventSearch) Q() applier {
 		return es.Apply(query), nil
 	}
 }
+
+type EventTagSearch struct {
+	search
+
+	EventID *int
+	TagID   *int
+	TagIDs  []int
+}
+
+func (ets *EventTagSearch) Apply(query *orm.Query) *orm.Query {
+	if ets == nil {
+		return query
+	}
+	if ets.EventID != nil {
+		ets.where(query, Tables.EventTag.Alias, Columns.EventTag.EventID, ets.EventID)
+	}
+	if ets.TagID != nil {
+		ets.where(query, Tables.EventTag.Alias, Columns.EventTag.TagID, ets.TagID)
+	}
+	if len(ets.TagIDs) > 0 {
+		Filter{Columns.EventTag.TagID, ets.TagIDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	ets.apply(query)
+
+	return query
+}
+
+func (ets *EventTagSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ets == nil {
+			return query, nil
+		}
+		return ets.Apply(query), nil
+	}
+}
+
+type TagSearch struct {
+	search
+
+	ID         *int
+	UserTgID   *int64
+	Title      *string
+	CreatedAt  *time.Time
+	IDs        []int
+	TitleILike *string
+}
+
+func (ts *TagSearch) Apply(query *orm.Query) *orm.Query {
+	if ts == nil {
+		return query
+	}
+	if ts.ID != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.ID, ts.ID)
+	}
+	if ts.UserTgID != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.UserTgID, ts.UserTgID)
+	}
+	if ts.Title != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.Title, ts.Title)
+	}
+	if ts.CreatedAt != nil {
+		ts.where(query, Tables.Tag.Alias, Columns.Tag.CreatedAt, ts.CreatedAt)
+	}
+	if len(ts.IDs) > 0 {
+		Filter{Columns.Tag.ID, ts.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if ts.TitleILike != nil {
+		Filter{Columns.Tag.Title, *ts.TitleILike, SearchTypeILike, false}.Apply(query)
+	}
+
+	ts.apply(query)
+
+	return query
+}
+
+func (ts *TagSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ts == nil {
+			return query, nil
+		}
+		return ts.Apply(query), nil
+	}
+}
+
+type VacationSearch struct {
+	search
+
+	ID       *int
+	UserTgID *int64
+	StartsAt *time.Time
+	EndsAt   *time.Time
+	StatusID *int
+	IDs      []int
+}
+
+func (vs *VacationSearch) Apply(query *orm.Query) *orm.Query {
+	if vs == nil {
+		return query
+	}
+	if vs.ID != nil {
+		vs.where(query, Tables.Vacation.Alias, Columns.Vacation.ID, vs.ID)
+	}
+	if vs.UserTgID != nil {
+		vs.where(query, Tables.Vacation.Alias, Columns.Vacation.UserTgID, vs.UserTgID)
+	}
+	if vs.StartsAt != nil {
+		vs.where(query, Tables.Vacation.Alias, Columns.Vacation.StartsAt, vs.StartsAt)
+	}
+	if vs.EndsAt != nil {
+		vs.where(query, Tables.Vacation.Alias, Columns.Vacation.EndsAt, vs.EndsAt)
+	}
+	if vs.StatusID != nil {
+		vs.where(query, Tables.Vacation.Alias, Columns.Vacation.StatusID, vs.StatusID)
+	}
+	if len(vs.IDs) > 0 {
+		Filter{Columns.Vacation.ID, vs.IDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	vs.apply(query)
+
+	return query
+}
+
+func (vs *VacationSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if vs == nil {
+			return query, nil
+		}
+		return vs.Apply(query), nil
+	}
+}
+
+type ConversationSearch struct {
+	search
+
+	UserTgID  *int64
+	State     *string
+	ExpiresAt *time.Time
+	UserTgIDs []int64
+}
+
+func (cs *ConversationSearch) Apply(query *orm.Query) *orm.Query {
+	if cs == nil {
+		return query
+	}
+	if cs.UserTgID != nil {
+		cs.where(query, Tables.Conversation.Alias, Columns.Conversation.UserTgID, cs.UserTgID)
+	}
+	if cs.State != nil {
+		cs.where(query, Tables.Conversation.Alias, Columns.Conversation.State, cs.State)
+	}
+	if cs.ExpiresAt != nil {
+		cs.where(query, Tables.Conversation.Alias, Columns.Conversation.ExpiresAt, cs.ExpiresAt)
+	}
+	if len(cs.UserTgIDs) > 0 {
+		Filter{Columns.Conversation.UserTgID, cs.UserTgIDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	cs.apply(query)
+
+	return query
+}
+
+func (cs *ConversationSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if cs == nil {
+			return query, nil
+		}
+		return cs.Apply(query), nil
+	}
+}
+
+type UserSearch struct {
+	search
+
+	UserTgID     *int64
+	Name         *string
+	LanguageCode *string
+	Language     *string
+	TimeZone     *string
+	DigestTime   *int
+	PreviewTime  *int
+	WeeklyTime   *int
+	QuietFrom    *int
+	QuietTo      *int
+	VacationMode *string
+	IcalToken    *string
+	APITokenHash *string
+	LastSeenAt   *time.Time
+	UserTgIDs    []int64
+}
+
+func (us *UserSearch) Apply(query *orm.Query) *orm.Query {
+	if us == nil {
+		return query
+	}
+	if us.UserTgID != nil {
+		us.where(query, Tables.User.Alias, Columns.User.UserTgID, us.UserTgID)
+	}
+	if us.Name != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Name, us.Name)
+	}
+	if us.LanguageCode != nil {
+		us.where(query, Tables.User.Alias, Columns.User.LanguageCode, us.LanguageCode)
+	}
+	if us.Language != nil {
+		us.where(query, Tables.User.Alias, Columns.User.Language, us.Language)
+	}
+	if us.TimeZone != nil {
+		us.where(query, Tables.User.Alias, Columns.User.TimeZone, us.TimeZone)
+	}
+	if us.DigestTime != nil {
+		us.where(query, Tables.User.Alias, Columns.User.DigestTime, us.DigestTime)
+	}
+	if us.PreviewTime != nil {
+		us.where(query, Tables.User.Alias, Columns.User.PreviewTime, us.PreviewTime)
+	}
+	if us.WeeklyTime != nil {
+		us.where(query, Tables.User.Alias, Columns.User.WeeklyTime, us.WeeklyTime)
+	}
+	if us.QuietFrom != nil {
+		us.where(query, Tables.User.Alias, Columns.User.QuietFrom, us.QuietFrom)
+	}
+	if us.QuietTo != nil {
+		us.where(query, Tables.User.Alias, Columns.User.QuietTo, us.QuietTo)
+	}
+	if us.VacationMode != nil {
+		us.where(query, Tables.User.Alias, Columns.User.VacationMode, us.VacationMode)
+	}
+	if us.IcalToken != nil {
+		us.where(query, Tables.User.Alias, Columns.User.IcalToken, us.IcalToken)
+	}
+	if us.APITokenHash != nil {
+		us.where(query, Tables.User.Alias, Columns.User.APITokenHash, us.APITokenHash)
+	}
+	if us.LastSeenAt != nil {
+		us.where(query, Tables.User.Alias, Columns.User.LastSeenAt, us.LastSeenAt)
+	}
+	if len(us.UserTgIDs) > 0 {
+		Filter{Columns.User.UserTgID, us.UserTgIDs, SearchTypeArray, false}.Apply(query)
+	}
+
+	us.apply(query)
+
+	return query
+}
+
+func (us *UserSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if us == nil {
+			return query, nil
+		}
+		return us.Apply(query), nil
+	}
+}
+
+type SnoozeSearch struct {
+	search
+
+	ID           *int
+	EventID      *int
+	UserTgID     *int64
+	SendAt       *time.Time
+	CreatedAt    *time.Time
+	IDs          []int
+	SendAtBefore *time.Time
+}
+
+func (ss *SnoozeSearch) Apply(query *orm.Query) *orm.Query {
+	if ss == nil {
+		return query
+	}
+	if ss.ID != nil {
+		ss.where(query, Tables.Snooze.Alias, Columns.Snooze.ID, ss.ID)
+	}
+	if ss.EventID != nil {
+		ss.where(query, Tables.Snooze.Alias, Columns.Snooze.EventID, ss.EventID)
+	}
+	if ss.UserTgID != nil {
+		ss.where(query, Tables.Snooze.Alias, Columns.Snooze.UserTgID, ss.UserTgID)
+	}
+	if ss.SendAt != nil {
+		ss.where(query, Tables.Snooze.Alias, Columns.Snooze.SendAt, ss.SendAt)
+	}
+	if ss.CreatedAt != nil {
+		ss.where(query, Tables.Snooze.Alias, Columns.Snooze.CreatedAt, ss.CreatedAt)
+	}
+	if len(ss.IDs) > 0 {
+		Filter{Columns.Snooze.ID, ss.IDs, SearchTypeArray, false}.Apply(query)
+	}
+	if ss.SendAtBefore != nil {
+		Filter{Columns.Snooze.SendAt, *ss.SendAtBefore, SearchTypeLE, false}.Apply(query)
+	}
+
+	ss.apply(query)
+
+	return query
+}
+
+func (ss *SnoozeSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ss == nil {
+			return query, nil
+		}
+		return ss.Apply(query), nil
+	}
+}
+
+type SendStatSearch struct {
+	search
+
+	Day    *time.Time
+	Sent   *int
+	Failed *int
+}
+
+func (ss *SendStatSearch) Apply(query *orm.Query) *orm.Query {
+	if ss == nil {
+		return query
+	}
+	if ss.Day != nil {
+		ss.where(query, Tables.SendStat.Alias, Columns.SendStat.Day, ss.Day)
+	}
+	if ss.Sent != nil {
+		ss.where(query, Tables.SendStat.Alias, Columns.SendStat.Sent, ss.Sent)
+	}
+	if ss.Failed != nil {
+		ss.where(query, Tables.SendStat.Alias, Columns.SendStat.Failed, ss.Failed)
+	}
+
+	ss.apply(query)
+
+	return query
+}
+
+func (ss *SendStatSearch) Q() applier {
+	return func(query *orm.Query) (*orm.Query, error) {
+		if ss == nil {
+			return query, nil
+		}
+		return ss.Apply(query), nil
+	}
+}
@@ -27,3 +27,63 @@ func (e Event) Validate() (errors map[string]string, valid bool) {
 
 	return errors, len(errors) == 0
 }
+
+func (t Tag) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(t.Title) > 64 {
+		errors[Columns.Tag.Title] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (c Conversation) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if utf8.RuneCountInString(c.State) > 32 {
+		errors[Columns.Conversation.State] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (u User) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	if u.Name != nil && utf8.RuneCountInString(*u.Name) > 128 {
+		errors[Columns.User.Name] = ErrMaxLength
+	}
+
+	if u.LanguageCode != nil && utf8.RuneCountInString(*u.LanguageCode) > 16 {
+		errors[Columns.User.LanguageCode] = ErrMaxLength
+	}
+
+	if u.Language != nil && utf8.RuneCountInString(*u.Language) > 8 {
+		errors[Columns.User.Language] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.TimeZone) > 64 {
+		errors[Columns.User.TimeZone] = ErrMaxLength
+	}
+
+	if utf8.RuneCountInString(u.VacationMode) > 16 {
+		errors[Columns.User.VacationMode] = ErrMaxLength
+	}
+
+	if u.IcalToken != nil && utf8.RuneCountInString(*u.IcalToken) > 64 {
+		errors[Columns.User.IcalToken] = ErrMaxLength
+	}
+
+	if u.APITokenHash != nil && utf8.RuneCountInString(*u.APITokenHash) > 64 {
+		errors[Columns.User.APITokenHash] = ErrMaxLength
+	}
+
+	return errors, len(errors) == 0
+}
+
+func (ss SendStat) Validate() (errors map[string]string, valid bool) {
+	errors = map[string]string{}
+
+	return errors, len(errors) == 0
+}
@@ -26,6 +26,13 @@ const (
 	PeriodicityWeekdays = "weekdays"
 )
 
+const (
+	// VacationModeSummary sends skipped one-off events as a single summary on return.
+	VacationModeSummary = "summary"
+	// VacationModeShift moves skipped one-off events to the end of vacation.
+	VacationModeShift = "shift"
+)
+
 var (
 	StatusFilter        = Filter{Field: "statusId", Value: []int{StatusEnabled, StatusDisabled}, SearchType: SearchTypeArray}
 	StatusEnabledFilter = Filter{Field: "statusId", Value: []int{StatusEnabled}, SearchType: SearchTypeArray}
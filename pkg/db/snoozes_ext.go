@@ -0,0 +1,22 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// SnoozesToSend returns snoozed occurrences due at now with their events.
+func (er EventsRepo) SnoozesToSend(ctx context.Context, now time.Time) ([]Snooze, error) {
+	return er.SnoozesByFilters(ctx, &SnoozeSearch{SendAtBefore: &now}, PagerNoLimit, er.FullSnooze())
+}
+
+// DeleteEventSnoozes removes pending snoozed occurrences of event.
+func (er EventsRepo) DeleteEventSnoozes(ctx context.Context, eventID int) error {
+	_, err := er.db.ModelContext(ctx, (*Snooze)(nil)).
+		Where(`?.? = ?`, pg.Ident(Tables.Snooze.Alias), pg.Ident(Columns.Snooze.EventID), eventID).
+		Delete()
+
+	return err
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Stats are service-wide counters for operators.
+type Stats struct {
+	Users int
+	// ActiveUsers are users seen since the time passed to Stats.
+	ActiveUsers  int
+	ActiveEvents int
+	PausedEvents int
+	// Periodic is count of active periodic events by periodicity.
+	Periodic map[string]int
+}
+
+// UserStats are event counters of one user.
+type UserStats struct {
+	ActiveEvents   int
+	PausedEvents   int
+	TrashedEvents  int
+	PeriodicEvents int
+}
+
+// AddSendResult counts delivered or failed reminder in stats of the current UTC day.
+func (er EventsRepo) AddSendResult(ctx context.Context, failed bool) error {
+	sent, failures := 1, 0
+	if failed {
+		sent, failures = 0, 1
+	}
+
+	_, err := er.db.ExecContext(ctx, `
+		INSERT INTO "sendStats" ("day", "sent", "failed") VALUES ((now() AT TIME ZONE 'UTC')::date, ?, ?)
+		ON CONFLICT ("day") DO UPDATE SET "sent" = "sendStats"."sent" + EXCLUDED."sent", "failed" = "sendStats"."failed" + EXCLUDED."failed"`,
+		sent, failures,
+	)
+
+	return err
+}
+
+// SendStats returns delivery counters of the last days including the current UTC day, newest first.
+func (er EventsRepo) SendStats(ctx context.Context, days int) ([]SendStat, error) {
+	var stats []SendStat
+	err := er.db.ModelContext(ctx, &stats).
+		Where(`"t"."day" > (now() AT TIME ZONE 'UTC')::date - ?::int`, days).
+		OrderExpr(`"t"."day" DESC`).
+		Select()
+
+	return stats, err
+}
+
+// Stats returns counters of users and events. Users seen since activeSince are counted as active.
+func (er EventsRepo) Stats(ctx context.Context, activeSince time.Time) (*Stats, error) {
+	var (
+		res Stats
+		err error
+	)
+
+	if res.Users, err = er.db.ModelContext(ctx, (*User)(nil)).Count(); err != nil {
+		return nil, err
+	}
+	res.ActiveUsers, err = er.db.ModelContext(ctx, (*User)(nil)).Where(`"t"."lastSeenAt" >= ?`, activeSince).Count()
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []struct {
+		StatusID int `pg:"statusId"`
+		Count    int
+	}
+	err = er.db.ModelContext(ctx, (*Event)(nil)).
+		Column(Columns.Event.StatusID).
+		ColumnExpr("count(*) AS count").
+		Group(Columns.Event.StatusID).
+		Select(&statuses)
+	if err != nil {
+		return nil, err
+	}
+	for _, s := range statuses {
+		switch s.StatusID {
+		case StatusEnabled:
+			res.ActiveEvents = s.Count
+		case StatusDisabled:
+			res.PausedEvents = s.Count
+		}
+	}
+
+	var periodic []struct {
+		Periodicity string
+		Count       int
+	}
+	err = er.db.ModelContext(ctx, (*Event)(nil)).
+		Column(Columns.Event.Periodicity).
+		ColumnExpr("count(*) AS count").
+		Where(`"t"."statusId" = ?`, StatusEnabled).
+		Where(`"t"."periodicity" IS NOT NULL`).
+		Group(Columns.Event.Periodicity).
+		Select(&periodic)
+	if err != nil {
+		return nil, err
+	}
+	res.Periodic = make(map[string]int, len(periodic))
+	for _, p := range periodic {
+		res.Periodic[p.Periodicity] = p.Count
+	}
+
+	return &res, nil
+}
+
+// UserStats returns event counters of the user, trashed events are counted too.
+func (er EventsRepo) UserStats(ctx context.Context, userTgID int64) (*UserStats, error) {
+	var res UserStats
+	_, err := er.db.QueryOneContext(ctx, &res, `
+		SELECT
+			count(*) FILTER (WHERE "statusId" = ?1) AS active_events,
+			count(*) FILTER (WHERE "statusId" = ?2) AS paused_events,
+			count(*) FILTER (WHERE "statusId" = ?3) AS trashed_events,
+			count(*) FILTER (WHERE "statusId" = ?1 AND "periodicity" IS NOT NULL) AS periodic_events
+		FROM "events"
+		WHERE "userTgId" = ?0`,
+		userTgID, StatusEnabled, StatusDisabled, StatusDeleted,
+	)
+
+	return &res, err
+}
+
+// UserIDs returns Telegram IDs of all users.
+func (er EventsRepo) UserIDs(ctx context.Context) ([]int64, error) {
+	var ids []int64
+	err := er.db.ModelContext(ctx, (*User)(nil)).
+		Column(Columns.User.UserTgID).
+		Order(Columns.User.UserTgID).
+		Select(&ids)
+
+	return ids, err
+}
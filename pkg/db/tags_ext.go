@@ -0,0 +1,94 @@
+package db
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// RunInTx runs fn in a transaction unless the repository already works within one.
+func (er EventsRepo) RunInTx(ctx context.Context, fn func(EventsRepo) error) error {
+	conn, ok := er.db.(*pg.DB)
+	if !ok {
+		return fn(er)
+	}
+
+	return conn.RunInTransaction(ctx, func(tx *pg.Tx) error {
+		return fn(er.WithTransaction(tx))
+	})
+}
+
+// SetEventTags replaces event tags with given titles, creating missing user tags.
+func (er EventsRepo) SetEventTags(ctx context.Context, event *Event, titles []string) error {
+	return er.RunInTx(ctx, func(r EventsRepo) error {
+		tagIDs := make([]int, 0, len(titles))
+		for _, title := range titles {
+			tag := &Tag{UserTgID: event.UserTgID, Title: title}
+			_, err := r.db.ModelContext(ctx, tag).
+				ExcludeColumn(Columns.Tag.CreatedAt).
+				OnConflict(`("userTgId", "title") DO UPDATE SET "title" = EXCLUDED."title"`).
+				Returning(`"tagId"`).
+				Insert()
+			if err != nil {
+				return fmt.Errorf("ошибка сохранения тега %q: %w", title, err)
+			}
+			tagIDs = append(tagIDs, tag.ID)
+		}
+
+		q := r.db.ModelContext(ctx, (*EventTag)(nil)).Where(`?.? = ?`, pg.Ident(Tables.EventTag.Alias), pg.Ident(Columns.EventTag.EventID), event.ID)
+		if len(tagIDs) > 0 {
+			q = q.Where(`?.? NOT IN (?)`, pg.Ident(Tables.EventTag.Alias), pg.Ident(Columns.EventTag.TagID), pg.In(tagIDs))
+		}
+		if _, err := q.Delete(); err != nil {
+			return fmt.Errorf("ошибка удаления тегов события: %w", err)
+		}
+
+		for _, tagID := range tagIDs {
+			if _, err := r.AddEventTag(ctx, &EventTag{EventID: event.ID, TagID: tagID}, OnConflict("DO NOTHING")); err != nil {
+				return fmt.Errorf("ошибка привязки тега: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UserTags returns user tags which have at least one enabled event.
+func (er EventsRepo) UserTags(ctx context.Context, userTgID int64) ([]Tag, error) {
+	search := &TagSearch{UserTgID: &userTgID}
+	search.With(`EXISTS (SELECT 1 FROM "eventTags" et JOIN "events" e USING ("eventId") WHERE et."tagId" = t."tagId" AND e."statusId" = ?)`, StatusEnabled)
+
+	return er.TagsByFilters(ctx, search, PagerNoLimit, er.DefaultTagSort())
+}
+
+// TagByTitle returns user tag by title or nil.
+func (er EventsRepo) TagByTitle(ctx context.Context, userTgID int64, title string) (*Tag, error) {
+	return er.OneTag(ctx, &TagSearch{UserTgID: &userTgID, Title: &title})
+}
+
+// WithTagID adds filter by tag to event search.
+func (es *EventSearch) WithTagID(tagID int) *EventSearch {
+	es.With(`t."eventId" IN (SELECT et."eventId" FROM "eventTags" et WHERE et."tagId" = ?)`, tagID)
+	return es
+}
+
+// UserEventTags returns tag titles of all user events by event ID.
+func (er EventsRepo) UserEventTags(ctx context.Context, userTgID int64) (map[int][]string, error) {
+	search := &EventTagSearch{}
+	search.With(`t."eventId" IN (SELECT e."eventId" FROM "events" e WHERE e."userTgId" = ?)`, userTgID)
+
+	eventTags, err := er.EventTagsByFilters(ctx, search, PagerNoLimit, WithColumns(TableColumns, Columns.EventTag.Tag))
+	if err != nil {
+		return nil, err
+	}
+
+	tags := make(map[int][]string)
+	for _, et := range eventTags {
+		if et.Tag != nil {
+			tags[et.EventID] = append(tags[et.EventID], et.Tag.Title)
+		}
+	}
+
+	return tags, nil
+}
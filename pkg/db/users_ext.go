@@ -0,0 +1,59 @@
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-pg/pg/v10"
+)
+
+// TouchUser creates user or updates Telegram profile fields and last seen time. Settings are kept as is.
+func (er EventsRepo) TouchUser(ctx context.Context, user *User) (*User, error) {
+	_, err := er.db.ModelContext(ctx, user).
+		ExcludeColumn(
+			Columns.User.Language, Columns.User.TimeZone, Columns.User.DigestTime, Columns.User.PreviewTime,
+			Columns.User.WeeklyTime, Columns.User.QuietFrom, Columns.User.QuietTo, Columns.User.SnoozePresets,
+			Columns.User.VacationMode, Columns.User.IcalToken, Columns.User.APITokenHash, Columns.User.CreatedAt, Columns.User.LastSeenAt,
+		).
+		OnConflict(`("userTgId") DO UPDATE`).
+		Set(`"name" = EXCLUDED."name", "languageCode" = EXCLUDED."languageCode", "lastSeenAt" = now()`).
+		Returning("*").
+		Insert()
+
+	return user, err
+}
+
+// UsersByIDs returns users by Telegram ID.
+func (er EventsRepo) UsersByIDs(ctx context.Context, userTgIDs []int64) (map[int64]User, error) {
+	if len(userTgIDs) == 0 {
+		return map[int64]User{}, nil
+	}
+
+	users, err := er.UsersByFilters(ctx, &UserSearch{UserTgIDs: userTgIDs}, PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64]User, len(users))
+	for _, u := range users {
+		res[u.UserTgID] = u
+	}
+
+	return res, nil
+}
+
+// ScheduledUsers returns users whose time of day in column, in minutes after midnight, is equal to at in their time zone.
+// Non-zero weekday also requires the day of week in Monday=1..Sunday=7 notation.
+func (er EventsRepo) ScheduledUsers(ctx context.Context, column string, at time.Time, weekday int) ([]User, error) {
+	local := `(?::timestamptz AT TIME ZONE "t"."timeZone")`
+
+	var users []User
+	q := er.db.ModelContext(ctx, &users).
+		Where(`"t".? = extract(hour from `+local+`) * 60 + extract(minute from `+local+`)`, pg.Ident(column), at, at)
+	if weekday != 0 {
+		q.Where(`extract(isodow from `+local+`) = ?`, at, weekday)
+	}
+
+	err := q.Select()
+	return users, err
+}
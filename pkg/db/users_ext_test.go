@@ -0,0 +1,82 @@
+package db_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+)
+
+const (
+	moscowUserID   int64 = 900000061
+	tokyoUserID    int64 = 900000062
+	saoPauloUserID int64 = 900000063
+)
+
+// TestDBScheduledUsers checks digest schedule in user time zones, including weekday of weekly review.
+func TestDBScheduledUsers(t *testing.T) {
+	dbc, _ := test.Setup(t)
+	repo := db.NewEventsRepo(dbc)
+	ctx := context.Background()
+
+	cleanup := func() {
+		if _, err := dbc.Exec(`DELETE FROM "users" WHERE "userTgId" IN (?, ?, ?)`, moscowUserID, tokyoUserID, saoPauloUserID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	for _, u := range []db.User{
+		{UserTgID: moscowUserID, TimeZone: "Europe/Moscow", DigestTime: test.Ptr(540), WeeklyTime: test.Ptr(540)},
+		{UserTgID: tokyoUserID, TimeZone: "Asia/Tokyo", DigestTime: test.Ptr(420), WeeklyTime: test.Ptr(420)},
+		{UserTgID: saoPauloUserID, TimeZone: "America/Sao_Paulo", DigestTime: test.Ptr(540)},
+	} {
+		u.SnoozePresets, u.VacationMode = []int{5}, db.VacationModeSummary
+		if _, err := repo.AddUser(ctx, &u); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	at := func(day, h int) time.Time {
+		return time.Date(2026, 3, day, h, 0, 0, 0, time.UTC) // 2026-03-08 is Sunday
+	}
+
+	tests := []struct {
+		name    string
+		column  string
+		at      time.Time
+		weekday int
+		want    []int64
+	}{
+		{"moscow morning", db.Columns.User.DigestTime, at(8, 6), 0, []int64{moscowUserID}},
+		{"tokyo morning of the next day", db.Columns.User.DigestTime, at(7, 22), 0, []int64{tokyoUserID}},
+		{"negative offset", db.Columns.User.DigestTime, at(8, 12), 0, []int64{saoPauloUserID}},
+		{"nobody", db.Columns.User.DigestTime, at(8, 7), 0, nil},
+		{"weekly on sunday", db.Columns.User.WeeklyTime, at(8, 6), 7, []int64{moscowUserID}},
+		{"weekly on saturday", db.Columns.User.WeeklyTime, at(7, 6), 7, nil},
+		{"sunday in utc is monday in tokyo", db.Columns.User.WeeklyTime, at(8, 22), 7, nil},
+		{"monday in tokyo", db.Columns.User.WeeklyTime, at(8, 22), 1, []int64{tokyoUserID}},
+	}
+
+	for _, tt := range tests {
+		users, err := repo.ScheduledUsers(ctx, tt.column, tt.at, tt.weekday)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// other tests could have users scheduled at the same time
+		var got []int64
+		for _, u := range users {
+			if slices.Contains([]int64{moscowUserID, tokyoUserID, saoPauloUserID}, u.UserTgID) {
+				got = append(got, u.UserTgID)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// UserVacation returns current or planned user vacation or nil.
+func (er EventsRepo) UserVacation(ctx context.Context, userTgID int64) (*Vacation, error) {
+	statusID := StatusEnabled
+	search := &VacationSearch{
+		UserTgID: &userTgID,
+		StatusID: &statusID,
+	}
+	search.With(`t."endsAt" > now()`)
+
+	vacations, err := er.VacationsByFilters(ctx, search, PagerOne, er.DefaultVacationSort())
+	if err != nil || len(vacations) == 0 {
+		return nil, err
+	}
+
+	return &vacations[0], nil
+}
+
+// ActiveVacations returns vacations in progress at given time by user.
+func (er EventsRepo) ActiveVacations(ctx context.Context, at time.Time) (map[int64]Vacation, error) {
+	statusID := StatusEnabled
+	search := &VacationSearch{
+		StatusID: &statusID,
+	}
+	search.With(`t."startsAt" <= ? AND t."endsAt" > ?`, at, at)
+
+	vacations, err := er.VacationsByFilters(ctx, search, PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[int64]Vacation, len(vacations))
+	for _, v := range vacations {
+		res[v.UserTgID] = v
+	}
+
+	return res, nil
+}
+
+// EndedVacations returns enabled vacations with end time in the past.
+// Vacation is disabled after its skipped events are processed.
+func (er EventsRepo) EndedVacations(ctx context.Context) ([]Vacation, error) {
+	statusID := StatusEnabled
+	search := &VacationSearch{
+		StatusID: &statusID,
+	}
+	search.With(`t."endsAt" <= now()`)
+
+	return er.VacationsByFilters(ctx, search, PagerNoLimit, er.DefaultVacationSort())
+}
+
+// SkippedEvents returns enabled one-off user events scheduled within [from, to).
+func (er EventsRepo) SkippedEvents(ctx context.Context, userTgID int64, from, to time.Time) ([]Event, error) {
+	statusID := StatusEnabled
+	search := &EventSearch{
+		UserTgID: &userTgID,
+		StatusID: &statusID,
+	}
+	search.With(`t."periodicity" IS NULL AND t."sendAt" >= ? AND t."sendAt" < ?`, from, to)
+
+	return er.EventsByFilters(ctx, search, PagerNoLimit, WithSort(SortField{Column: Columns.Event.SendAt, Direction: SortAsc}))
+}
+
+// VacationSkippedEvents returns user periodic events moved to the end of vacation.
+func (er EventsRepo) VacationSkippedEvents(ctx context.Context, userTgID int64) ([]Event, error) {
+	search := &EventSearch{
+		UserTgID: &userTgID,
+	}
+	search.With(`t."skippedAt" IS NOT NULL`)
+
+	return er.EventsByFilters(ctx, search, PagerNoLimit)
+}
+
+// ClearSkippedEvents forgets vacation shifts of user periodic events.
+func (er EventsRepo) ClearSkippedEvents(ctx context.Context, userTgID int64) error {
+	_, err := er.db.ModelContext(ctx, (*Event)(nil)).
+		Set(`"skippedAt" = NULL`).
+		Where(`t."userTgId" = ?`, userTgID).
+		Where(`t."skippedAt" IS NOT NULL`).
+		Update()
+
+	return err
+}
@@ -0,0 +1,55 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+)
+
+var (
+	ErrNotFound     = errors.New("event not found")
+	ErrAccessDenied = errors.New("access denied")
+)
+
+// UserEvent returns active or paused event if it belongs to the user.
+// Event IDs from callback data and user input could be forged, so every handler must load events through this method.
+func (bm *BotManager) UserEvent(ctx context.Context, chatID int64, id int) (*db.Event, error) {
+	event, err := bm.EventsRepo.EventByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if event == nil {
+		return nil, ErrNotFound
+	}
+	if event.UserTgID != chatID {
+		return nil, ErrAccessDenied
+	}
+
+	return event, nil
+}
+
+// accessErrorText returns user message for UserEvent error.
+func (bm *BotManager) accessErrorText(tr *i18n.Localizer, err error) string {
+	switch {
+	case errors.Is(err, ErrNotFound):
+		return tr.T("event.not_found")
+	case errors.Is(err, ErrAccessDenied):
+		return tr.T("event.access_denied")
+	default:
+		bm.Errorf("Ошибка загрузки события: %v", err)
+		return tr.T("event.load_error")
+	}
+}
+
+func (bm *BotManager) sendAccessError(ctx context.Context, b *bot.Bot, chatID int64, err error) {
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   bm.accessErrorText(bm.Tr(ctx, chatID), err),
+	})
+	bm.OnError(err)
+}
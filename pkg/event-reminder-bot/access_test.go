@@ -0,0 +1,261 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+
+	"github.com/go-telegram/bot"
+)
+
+const (
+	ownerID    int64 = 900000001
+	attackerID int64 = 900000002
+)
+
+// newTestManager returns BotManager with Telegram API stub and clean events of test users.
+func newTestManager(t *testing.T) *BotManager {
+	t.Helper()
+
+	dbc, logger := test.Setup(t)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprint(w, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	b, err := bot.New("test", bot.WithSkipGetMe(), bot.WithServerURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cleanup := func() {
+		for _, q := range []string{
+			`DELETE FROM "snoozes" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "events" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "tags" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "conversations" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "vacations" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "users" WHERE "userTgId" IN (?, ?)`,
+		} {
+			if _, err := dbc.Exec(q, ownerID, attackerID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	return NewBotManager(b, db.NewEventsRepo(dbc), "test", logger)
+}
+
+func addTestEvent(t *testing.T, bm *BotManager, userTgID int64) *db.Event {
+	t.Helper()
+
+	event, err := bm.EventsRepo.AddEvent(context.Background(), &db.Event{
+		UserTgID:    userTgID,
+		Message:     "secret",
+		SendAt:      time.Now().Add(48 * time.Hour).Truncate(time.Second),
+		StatusID:    db.StatusEnabled,
+		Weekdays:    []int{1, 3},
+		Periodicity: test.Ptr(db.PeriodicityWeekdays),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return event
+}
+
+// assertUnchanged checks that event is still enabled, was not modified and has no snoozed occurrences.
+func assertUnchanged(t *testing.T, bm *BotManager, want *db.Event) {
+	t.Helper()
+
+	got, err := bm.EventsRepo.EventByID(context.Background(), want.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got == nil {
+		t.Fatal("event was removed")
+	}
+
+	snoozes, err := bm.EventsRepo.CountSnoozes(context.Background(), &db.SnoozeSearch{EventID: &want.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snoozes > 0 {
+		t.Errorf("occurrence snoozed %d times", snoozes)
+	}
+
+	switch {
+	case got.UserTgID != want.UserTgID:
+		t.Errorf("owner changed: got %d, want %d", got.UserTgID, want.UserTgID)
+	case got.StatusID != want.StatusID:
+		t.Errorf("status changed: got %d, want %d", got.StatusID, want.StatusID)
+	case !got.SendAt.Equal(want.SendAt):
+		t.Errorf("sendAt changed: got %v, want %v", got.SendAt, want.SendAt)
+	case got.Message != want.Message:
+		t.Errorf("message changed: got %q, want %q", got.Message, want.Message)
+	case !slices.Equal(got.Weekdays, want.Weekdays):
+		t.Errorf("weekdays changed: got %v, want %v", got.Weekdays, want.Weekdays)
+	case got.Periodicity == nil || *got.Periodicity != *want.Periodicity:
+		t.Errorf("periodicity changed: got %v, want %v", got.Periodicity, *want.Periodicity)
+	}
+}
+
+func TestDBUserEvent(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+	event := addTestEvent(t, bm, ownerID)
+
+	got, err := bm.UserEvent(ctx, ownerID, event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != event.ID {
+		t.Errorf("got event %d, want %d", got.ID, event.ID)
+	}
+
+	if _, err = bm.UserEvent(ctx, attackerID, event.ID); !errors.Is(err, ErrAccessDenied) {
+		t.Errorf("got %v, want ErrAccessDenied", err)
+	}
+
+	if _, err = bm.UserEvent(ctx, ownerID, 0); !errors.Is(err, ErrNotFound) {
+		t.Errorf("got %v, want ErrNotFound", err)
+	}
+
+	if err = bm.DeleteEventByID(ctx, ownerID, event.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = bm.UserEvent(ctx, ownerID, event.ID); !errors.Is(err, ErrNotFound) {
+		t.Errorf("deleted event: got %v, want ErrNotFound", err)
+	}
+}
+
+func TestDBForeignEventMethods(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+	event := addTestEvent(t, bm, ownerID)
+
+	tests := []struct {
+		name string
+		fn   func() error
+	}{
+		{"DeleteEventByID", func() error { return bm.DeleteEventByID(ctx, attackerID, event.ID) }},
+		{"GetEventByID", func() error { _, err := bm.GetEventByID(ctx, attackerID, event.ID); return err }},
+		{"SnoozeEvent", func() error { return bm.SnoozeEvent(ctx, event.ID, attackerID, time.Now().Add(time.Hour)) }},
+		{"PauseEvent", func() error { _, err := bm.PauseEvent(ctx, attackerID, event.ID, nil); return err }},
+		{"ResumeEvent", func() error { _, err := bm.ResumeEvent(ctx, attackerID, event.ID); return err }},
+		{"RestoreEvent", func() error { _, err := bm.RestoreEvent(ctx, attackerID, event.ID, 0); return err }},
+		{"CompleteOccurrence", func() error { return bm.CompleteOccurrence(ctx, attackerID, event.ID) }},
+		{"SnoozeOccurrence", func() error { return bm.SnoozeOccurrence(ctx, attackerID, event.ID, time.Now().Add(time.Hour)) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.fn()
+			if !errors.Is(err, ErrAccessDenied) && !errors.Is(err, ErrNotFound) {
+				t.Errorf("got %v, want ErrAccessDenied or ErrNotFound", err)
+			}
+			assertUnchanged(t, bm, event)
+		})
+	}
+}
+
+// TestDBForgedCallbacks sends callbacks with foreign event ID as the first argument.
+// Reminder buttons handled by bot service are checked in botService package.
+func TestDBForgedCallbacks(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+	event := addTestEvent(t, bm, ownerID)
+	id := event.ID
+
+	tag, err := bm.EventsRepo.AddTag(ctx, &db.Tag{UserTgID: attackerID, Title: "mine"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// selected applies bulk action to selection with foreign event
+	selected := func(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+		bm.setSelection(chatID, &selection{IDs: []int{id}})
+		bm.HandleSelect(ctx, b, data, chatID, messageID)
+	}
+
+	loc := bm.UserLocation(ctx, attackerID)
+	day := civilDay(time.Now().In(loc).AddDate(0, 0, 1))
+
+	tests := []struct {
+		name    string
+		data    callback.Data
+		handler func(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int)
+	}{
+		{"detail", callback.New(ActionEventDetail, id), bm.HandleEventDetail},
+		{"edit", callback.New(ActionEventEdit, id), bm.HandleEventEdit},
+		{"delete", callback.New(ActionEventDelete, id), bm.HandleEventDelete},
+		{"period", callback.New(ActionPeriod, id, 1), bm.HandlePeriodicity},
+		{"period none", callback.New(ActionPeriod, id, 4), bm.HandlePeriodicity},
+		{"weekday", callback.New(ActionWeekday, id, 5), bm.HandleWeekday},
+		{"weekdays done", callback.New(ActionWeekdaysDone, id), bm.HandleWeekdaysDone},
+		{"edit date", callback.New(ActionEditDate, id), bm.HandleEditDate},
+		{"edit description", callback.New(ActionEditDescription, id), bm.HandleEditDescription},
+		{"edit notes", callback.New(ActionEditNotes, id), bm.HandleEditNotes},
+		{"edit periodicity", callback.New(ActionEditPeriodicity, id), bm.HandleEditPeriodicity},
+		{"edit period", callback.New(ActionEditPeriod, id, 0), bm.HandleEditPeriod},
+		{"edit weekday", callback.New(ActionEditWeekday, id, 5), bm.HandleEditWeekday},
+		{"edit weekdays done", callback.New(ActionEditWeekdaysDone, id), bm.HandleEditWeekdaysDone},
+		{"postpone hour", callback.New(ActionPostpone, id, 1), bm.HandlePostpone},
+		{"postpone week", callback.New(ActionPostpone, id, 7*24), bm.HandlePostpone},
+		{"postpone custom", callback.New(ActionPostponeCustom, id), bm.HandlePostponeCustom},
+		{"pause", callback.New(ActionPause, id, 7), bm.HandlePause},
+		{"pause date", callback.New(ActionPauseDate, id), bm.HandlePause},
+		{"resume", callback.New(ActionResume, id), bm.HandleResume},
+		{"restore", callback.New(ActionRestore, id), bm.HandleRestore},
+		{"undo", callback.New(ActionUndo, id), bm.HandleUndo},
+		{"pause menu", callback.New(ActionPauseMenu, id), bm.HandlePause},
+		{"postpone preset", callback.New(ActionPostponePreset, id, 60), bm.HandlePostponePreset},
+		{"picker date month", callback.New(ActionPickerMonth, int(PickerEventDate), id, monthIndex(time.Now().In(loc))), bm.HandlePicker},
+		{"picker date day", callback.New(ActionPickerDay, int(PickerEventDate), id, day), bm.HandlePicker},
+		{"picker date hour", callback.New(ActionPickerHour, int(PickerEventDate), id, day, 10), bm.HandlePicker},
+		{"picker date time", callback.New(ActionPickerTime, int(PickerEventDate), id, day, 10*60), bm.HandlePicker},
+		{"picker snooze time", callback.New(ActionPickerTime, int(PickerSnooze), id, day, 10*60), bm.HandlePicker},
+		{"select toggle", callback.New(ActionSelectToggle, id), bm.HandleSelect},
+		{"select delete", callback.New(ActionSelectDelete), selected},
+		{"select pause", callback.New(ActionSelectPause), selected},
+		{"select shift", callback.New(ActionSelectShift, 0), selected},
+		{"select tag", callback.New(ActionSelectTag, tag.ID), selected},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.handler(ctx, bm.b, tt.data, attackerID, 1)
+			assertUnchanged(t, bm, event)
+
+			c, err := bm.TakeConversation(ctx, attackerID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c != nil {
+				t.Errorf("conversation %q started for foreign event", c.State)
+			}
+		})
+	}
+}
+
+func TestDBForgedConversationParams(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+	event := addTestEvent(t, bm, ownerID)
+
+	bm.PauseUntil(ctx, bm.b, attackerID, time.Now().Add(24*time.Hour).Format("2006-01-02 15:04"), db.ConversationParams{EventID: event.ID})
+	assertUnchanged(t, bm, event)
+}
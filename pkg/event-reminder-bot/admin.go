@@ -0,0 +1,341 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// statsDays is how many days of delivery stats are shown.
+	statsDays = 7
+	// activeUserPeriod is how recently user should be seen to be counted as active.
+	activeUserPeriod = 7 * 24 * time.Hour
+
+	// broadcastInterval keeps broadcast under Telegram limit of 30 messages per second.
+	broadcastInterval = 50 * time.Millisecond
+	// broadcastTTL is how long broadcast preview could be confirmed.
+	broadcastTTL = 10 * time.Minute
+	// maxBroadcastLength leaves room for preview header within Telegram message limit.
+	maxBroadcastLength = 3500
+)
+
+var ErrBroadcastRunning = errors.New("broadcast is running")
+
+// AdminStats is service overview for operators.
+type AdminStats struct {
+	db.Stats
+	// Sends are reminder delivery counters of the last days, newest first.
+	Sends []db.SendStat
+}
+
+// AdminUser is user profile and event counters for operators.
+type AdminUser struct {
+	User db.User
+	db.UserStats
+}
+
+// broadcast is a message waiting for admin confirmation.
+type broadcast struct {
+	Text      string
+	CreatedAt time.Time
+}
+
+// IsAdmin reports whether the user may use admin commands.
+func (bm *BotManager) IsAdmin(chatID int64) bool {
+	return slices.Contains(bm.AdminIDs, chatID)
+}
+
+// AdminStats returns counters of users, events and reminder deliveries.
+func (bm *BotManager) AdminStats(ctx context.Context) (*AdminStats, error) {
+	stats, err := bm.EventsRepo.Stats(ctx, time.Now().Add(-activeUserPeriod))
+	if err != nil {
+		return nil, err
+	}
+
+	sends, err := bm.EventsRepo.SendStats(ctx, statsDays)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminStats{Stats: *stats, Sends: sends}, nil
+}
+
+// AdminUser returns user profile with event counters. ErrNotFound is returned for unknown users.
+func (bm *BotManager) AdminUser(ctx context.Context, userID int64) (*AdminUser, error) {
+	u, err := bm.EventsRepo.UserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	} else if u == nil {
+		return nil, ErrNotFound
+	}
+
+	stats, err := bm.EventsRepo.UserStats(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AdminUser{User: *u, UserStats: *stats}, nil
+}
+
+// Broadcast sends text to all users in background at most one message per broadcastInterval and returns recipients count.
+// Only one broadcast runs at a time, ErrBroadcastRunning is returned otherwise. Done is called with results when it ends.
+func (bm *BotManager) Broadcast(ctx context.Context, text string, done func(sent, failed int)) (int, error) {
+	if !bm.broadcasting.CompareAndSwap(false, true) {
+		return 0, ErrBroadcastRunning
+	}
+
+	ids, err := bm.EventsRepo.UserIDs(ctx)
+	if err != nil {
+		bm.broadcasting.Store(false)
+		return 0, err
+	}
+
+	// broadcast outlives request or update that started it
+	ctx = context.WithoutCancel(ctx)
+	go func() {
+		defer bm.broadcasting.Store(false)
+
+		ticker := time.NewTicker(broadcastInterval)
+		defer ticker.Stop()
+
+		var sent, failed int
+		for _, id := range ids {
+			<-ticker.C
+			if _, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{ChatID: id, Text: text}); err != nil {
+				failed++
+				continue
+			}
+			sent++
+		}
+
+		bm.Print(ctx, "broadcast finished", "sent", sent, "failed", failed)
+		if done != nil {
+			done(sent, failed)
+		}
+	}()
+
+	return len(ids), nil
+}
+
+// recordSend logs reminder delivery error and counts delivery in daily stats.
+func (bm *BotManager) recordSend(ctx context.Context, err error) {
+	bm.OnError(err)
+
+	if statErr := bm.EventsRepo.AddSendResult(ctx, err != nil); statErr != nil {
+		bm.Errorf("Ошибка сохранения статистики отправки: %v", statErr)
+	}
+}
+
+// StatsHandler shows service stats to admins: /stats.
+func (bm *BotManager) StatsHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	if !bm.IsAdmin(chatID) {
+		bm.DefaultHandler(ctx, b, update)
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	text := tr.T("admin.error")
+	stats, err := bm.AdminStats(ctx)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки статистики: %v", err)
+	} else {
+		var sb strings.Builder
+		sb.WriteString(tr.T("admin.stats.title") + "\n\n")
+		sb.WriteString(tr.T("admin.stats.users", stats.Users, stats.ActiveUsers) + "\n")
+		sb.WriteString(tr.T("admin.stats.events", stats.ActiveEvents, stats.PausedEvents) + "\n")
+		sb.WriteString(tr.T("admin.stats.periodic") + "\n")
+		for _, c := range periodicityChoices {
+			if c.Periodicity != periodNone {
+				sb.WriteString("  " + tr.T(c.Key) + ": " + strconv.Itoa(stats.Periodic[c.Periodicity]) + "\n")
+			}
+		}
+
+		sb.WriteString("\n" + tr.T("admin.stats.sends", statsDays) + "\n")
+		if len(stats.Sends) == 0 {
+			sb.WriteString(tr.T("admin.stats.no_sends") + "\n")
+		}
+		for _, s := range stats.Sends {
+			sb.WriteString(tr.T("admin.stats.day", s.Day.Format(time.DateOnly), s.Sent, s.Failed) + "\n")
+		}
+		text = sb.String()
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	bm.OnError(err)
+}
+
+// AdminUserHandler shows user profile to admins: /user <id>.
+func (bm *BotManager) AdminUserHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	if !bm.IsAdmin(chatID) {
+		bm.DefaultHandler(ctx, b, update)
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	var text string
+	userID, err := strconv.ParseInt(strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/user")), 10, 64)
+	if err != nil {
+		text = tr.T("admin.user.usage")
+	} else {
+		info, err := bm.AdminUser(ctx, userID)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			text = tr.T("admin.user.not_found", userID)
+		case err != nil:
+			bm.Errorf("Ошибка загрузки пользователя %d: %v", userID, err)
+			text = tr.T("admin.error")
+		default:
+			text = adminUserText(tr, info)
+		}
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	bm.OnError(err)
+}
+
+// BroadcastHandler shows broadcast preview to admins with confirmation buttons: /broadcast <text>.
+func (bm *BotManager) BroadcastHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	if !bm.IsAdmin(chatID) {
+		bm.DefaultHandler(ctx, b, update)
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	params := &bot.SendMessageParams{ChatID: chatID}
+	text := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/broadcast"))
+	switch {
+	case text == "":
+		params.Text = tr.T("admin.broadcast.usage")
+	case utf8.RuneCountInString(text) > maxBroadcastLength:
+		params.Text = tr.T("admin.broadcast.too_long")
+	default:
+		count, err := bm.EventsRepo.CountUsers(ctx, nil)
+		if err != nil {
+			bm.Errorf("Ошибка подсчёта пользователей: %v", err)
+			params.Text = tr.T("admin.error")
+			break
+		}
+
+		bm.setBroadcast(chatID, &broadcast{Text: text, CreatedAt: time.Now()})
+		params.Text = tr.T("admin.broadcast.confirm", count) + "\n\n" + text
+		params.ReplyMarkup = &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{{
+				bm.button(tr.T("admin.broadcast.send_button"), ActionBroadcastSend),
+				bm.button(tr.T("import.cancel"), ActionBroadcastCancel),
+			}},
+		}
+	}
+
+	_, err := b.SendMessage(ctx, params)
+	bm.OnError(err)
+}
+
+// HandleBroadcast starts or cancels broadcast from preview buttons. Admin gets a report when it ends.
+func (bm *BotManager) HandleBroadcast(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	if !bm.IsAdmin(chatID) {
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	pending := bm.takeBroadcast(chatID)
+
+	var text string
+	switch {
+	case data.Action == ActionBroadcastCancel:
+		text = tr.T("admin.broadcast.cancelled")
+	case pending == nil || time.Since(pending.CreatedAt) > broadcastTTL:
+		text = tr.T("admin.broadcast.expired")
+	default:
+		count, err := bm.Broadcast(ctx, pending.Text, func(sent, failed int) {
+			_, err := b.SendMessage(context.WithoutCancel(ctx), &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   tr.T("admin.broadcast.done", sent, failed),
+			})
+			bm.OnError(err)
+		})
+		switch {
+		case errors.Is(err, ErrBroadcastRunning):
+			text = tr.T("admin.broadcast.running")
+		case err != nil:
+			bm.Errorf("Ошибка запуска рассылки: %v", err)
+			text = tr.T("admin.error")
+		default:
+			text = tr.T("admin.broadcast.started", count)
+		}
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	})
+	bm.OnError(err)
+}
+
+// adminUserText returns user profile for /user command.
+func adminUserText(tr *i18n.Localizer, info *AdminUser) string {
+	u := info.User
+	onOff := func(on bool) string {
+		if on {
+			return tr.T("admin.on")
+		}
+		return tr.T("admin.off")
+	}
+	orDash := func(s *string) string {
+		if s == nil || *s == "" {
+			return "—"
+		}
+		return *s
+	}
+
+	lines := []string{
+		tr.T("admin.user.title", u.UserTgID),
+		tr.T("admin.user.name", orDash(u.Name)),
+		tr.T("admin.user.settings", orDash(u.Language), orDash(u.LanguageCode), u.TimeZone),
+		tr.T("admin.user.dates", u.CreatedAt.UTC().Format(time.DateTime), u.LastSeenAt.UTC().Format(time.DateTime)),
+		tr.T("admin.user.events", info.ActiveEvents, info.PausedEvents, info.TrashedEvents, info.PeriodicEvents),
+		tr.T("admin.user.access", onOff(u.IcalToken != nil), onOff(u.APITokenHash != nil)),
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// setBroadcast keeps broadcast preview of the admin until confirmation.
+func (bm *BotManager) setBroadcast(chatID int64, b *broadcast) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	bm.broadcasts[chatID] = b
+}
+
+// takeBroadcast returns and forgets pending broadcast of the admin.
+func (bm *BotManager) takeBroadcast(chatID int64) *broadcast {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	b := bm.broadcasts[chatID]
+	delete(bm.broadcasts, chatID)
+
+	return b
+}
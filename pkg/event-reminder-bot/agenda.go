@@ -0,0 +1,309 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// maxAgendaItems limits agenda size to fit into one Telegram message.
+const maxAgendaItems = 50
+
+// digests are scheduled agenda messages, each is sent at the time of day from user settings column.
+var digests = []struct {
+	column  string
+	weekday int // Monday=1..Sunday=7, 0 is every day
+	message func(bm *BotManager, ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup, error)
+}{
+	{db.Columns.User.DigestTime, 0, func(bm *BotManager, ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup, error) {
+		return bm.digestMessage(ctx, chatID, 0)
+	}},
+	{db.Columns.User.PreviewTime, 0, func(bm *BotManager, ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup, error) {
+		return bm.scheduledAgenda(ctx, chatID, 1, 1, "agenda.preview")
+	}},
+	{db.Columns.User.WeeklyTime, 7, func(bm *BotManager, ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup, error) {
+		return bm.scheduledAgenda(ctx, chatID, 1, 7, "agenda.weekly")
+	}},
+}
+
+type agendaItem struct {
+	Event model.Event
+	At    time.Time
+}
+
+func (bm *BotManager) TodayHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	bm.sendAgenda(ctx, b, update.Message, "/today", 0, 1, "agenda.today")
+}
+
+func (bm *BotManager) TomorrowHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	bm.sendAgenda(ctx, b, update.Message, "/tomorrow", 1, 1, "agenda.tomorrow")
+}
+
+func (bm *BotManager) WeekHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	bm.sendAgenda(ctx, b, update.Message, "/week", 0, 7, "agenda.week")
+}
+
+// HandleDigestTag filters daily digest message by tag, tag ID 0 shows all events.
+func (bm *BotManager) HandleDigestTag(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	text, keyboard, err := bm.digestMessage(ctx, chatID, data.Arg(0))
+	if err != nil {
+		bm.Errorf("Ошибка загрузки событий: %v", err)
+		return
+	}
+
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.EditMessageText(ctx, params)
+	bm.OnError(err)
+}
+
+// SendDigests sends daily digest, evening preview and weekly review to users whose time has come in their time zone.
+// Events are loaded per user, digests without events are not sent.
+func (bm *BotManager) SendDigests(ctx context.Context) error {
+	return bm.sendDigests(ctx, time.Now().Truncate(time.Minute))
+}
+
+// sendDigests sends digests scheduled at now.
+func (bm *BotManager) sendDigests(ctx context.Context, now time.Time) error {
+	vacations, err := bm.EventsRepo.ActiveVacations(ctx, now)
+	if err != nil {
+		bm.Errorf("Ошибка получения отпусков: %v", err)
+		return err
+	}
+
+	for _, d := range digests {
+		users, err := bm.EventsRepo.ScheduledUsers(ctx, d.column, now, d.weekday)
+		if err != nil {
+			bm.Errorf("Ошибка получения пользователей для рассылки %s: %v", d.column, err)
+			continue
+		}
+
+		for _, u := range users {
+			if _, ok := vacations[u.UserTgID]; ok {
+				continue
+			}
+			bm.cacheUser(model.NewUser(&u))
+
+			text, keyboard, err := d.message(bm, ctx, u.UserTgID)
+			if err != nil {
+				bm.Errorf("Ошибка загрузки событий пользователя %d: %v", u.UserTgID, err)
+				continue
+			}
+
+			if keyboard == nil {
+				continue
+			}
+
+			_, err = bm.b.SendMessage(ctx, &bot.SendMessageParams{
+				ChatID:      u.UserTgID,
+				Text:        text,
+				ReplyMarkup: keyboard,
+			})
+			bm.OnError(err)
+		}
+	}
+
+	return nil
+}
+
+// digestMessage returns today's digest filtered by tag. Keyboard is nil if the user has no events today.
+func (bm *BotManager) digestMessage(ctx context.Context, chatID int64, tagID int) (string, *models.InlineKeyboardMarkup, error) {
+	loc := bm.UserLocation(ctx, chatID)
+	from, to := dayRange(time.Now(), loc, 0, 1)
+
+	items, err := bm.Agenda(ctx, chatID, from, to, loc, tagID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tags, err := bm.EventsRepo.UserTags(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки тегов: %v", err)
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	title := tr.T("agenda.today")
+	if len(items) == 0 {
+		if tagID == 0 {
+			return title + ":\n\n" + tr.T("events.empty"), nil, nil
+		}
+		return title + ":\n\n" + tr.T("events.empty_tag"), &models.InlineKeyboardMarkup{InlineKeyboard: bm.tagsKeyboard(tr, tags, tagID, ActionDigestTag)}, nil
+	}
+
+	text, keyboard := bm.formatAgenda(tr, title, items)
+	keyboard.InlineKeyboard = append(keyboard.InlineKeyboard, bm.tagsKeyboard(tr, tags, tagID, ActionDigestTag)...)
+
+	return text, keyboard, nil
+}
+
+// scheduledAgenda returns agenda for days starting from today+offset. Keyboard is nil if the user has no events.
+func (bm *BotManager) scheduledAgenda(ctx context.Context, chatID int64, offset, days int, titleKey string) (string, *models.InlineKeyboardMarkup, error) {
+	loc := bm.UserLocation(ctx, chatID)
+	from, to := dayRange(time.Now(), loc, offset, days)
+
+	items, err := bm.Agenda(ctx, chatID, from, to, loc, 0)
+	if err != nil || len(items) == 0 {
+		return "", nil, err
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	text, keyboard := bm.formatAgenda(tr, tr.T(titleKey), items)
+
+	return text, keyboard, nil
+}
+
+// dayRange returns [from, to) for days starting from today+offset in loc.
+func dayRange(now time.Time, loc *time.Location, offset, days int) (from, to time.Time) {
+	now = now.In(loc)
+	from = time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, loc)
+	return from, from.AddDate(0, 0, days)
+}
+
+// Agenda returns user events within [from, to) with periodic events expanded into occurrences.
+// Hourly events are shown once per day.
+func (bm *BotManager) Agenda(ctx context.Context, chatID int64, from, to time.Time, loc *time.Location, tagID int) ([]agendaItem, error) {
+	statusID := db.StatusEnabled
+	search := &db.EventSearch{
+		UserTgID:     &chatID,
+		StatusID:     &statusID,
+		SendAtBefore: &to,
+	}
+	if tagID != 0 {
+		search.WithTagID(tagID)
+	}
+
+	dbEvents, err := bm.EventsRepo.EventsByFilters(ctx, search, db.PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []agendaItem
+	for _, e := range model.NewEvents(dbEvents) {
+		seenDays := map[string]bool{}
+		for _, at := range model.Occurrences(model.ToDB(&e), from, to, loc) {
+			if e.Periodicity != nil && *e.Periodicity == db.PeriodicityHour {
+				d := at.Format(time.DateOnly)
+				if seenDays[d] {
+					continue
+				}
+				seenDays[d] = true
+			}
+			items = append(items, agendaItem{Event: e, At: at})
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].At.Before(items[j].At)
+	})
+
+	return items, nil
+}
+
+// sendAgenda sends events for days starting from today+offset, titleKey is a message key of agenda title.
+func (bm *BotManager) sendAgenda(ctx context.Context, b *bot.Bot, message *models.Message, cmd string, offset, days int, titleKey string) {
+	chatID := message.Chat.ID
+	tr := bm.Tr(ctx, chatID)
+	title := tr.T(titleKey)
+
+	tagID, _, err := bm.tagFromArgs(ctx, chatID, strings.TrimPrefix(message.Text, cmd))
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tagErrorText(tr, err),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	loc := bm.UserLocation(ctx, chatID)
+	from, to := dayRange(time.Now(), loc, offset, days)
+
+	items, err := bm.Agenda(ctx, chatID, from, to, loc, tagID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки событий: %v", err)
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("events.load_error"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	if len(items) == 0 {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   title + ":\n\n" + tr.T("events.empty"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	text, keyboard := bm.formatAgenda(tr, title, items)
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+	bm.OnError(err)
+}
+
+// formatAgenda returns agenda grouped by day and keyboard with detail buttons for each item.
+func (bm *BotManager) formatAgenda(tr *i18n.Localizer, title string, items []agendaItem) (string, *models.InlineKeyboardMarkup) {
+	var msg strings.Builder
+	msg.WriteString(title + ":\n")
+
+	var more int
+	if len(items) > maxAgendaItems {
+		more = len(items) - maxAgendaItems
+		items = items[:maxAgendaItems]
+	}
+
+	var (
+		day     string
+		buttons [][]models.InlineKeyboardButton
+		row     []models.InlineKeyboardButton
+	)
+	for i, it := range items {
+		if d := tr.DayMonth(it.At); d != day {
+			day = d
+			msg.WriteString(fmt.Sprintf("\n🗓 %s, %s\n", tr.Weekday(model.Weekday(it.At)), d))
+		}
+
+		msg.WriteString(fmt.Sprintf("%d. %s — %s", i+1, tr.Time(it.At), it.Event.Text))
+		if it.Event.Periodicity != nil {
+			msg.WriteString(" 🔄")
+		}
+		msg.WriteString("\n")
+
+		row = append(row, bm.button(fmt.Sprintf("%d", i+1), ActionEventDetail, it.Event.ID))
+		if len(row) == 5 {
+			buttons = append(buttons, row)
+			row = []models.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+	if more > 0 {
+		msg.WriteString(tr.T("agenda.more", more) + "\n")
+	}
+
+	return msg.String(), &models.InlineKeyboardMarkup{InlineKeyboard: buttons}
+}
@@ -0,0 +1,138 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+)
+
+func TestFormatAgenda(t *testing.T) {
+	bm := &BotManager{callbacks: NewCallbackCodec("secret")}
+	tr := i18n.For(i18n.English)
+	at := time.Date(2026, time.March, 2, 0, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		count   int
+		buttons int
+		more    string
+	}{
+		{3, 3, ""},
+		{maxAgendaItems, maxAgendaItems, ""},
+		{maxAgendaItems + 7, maxAgendaItems, "…and 7 more"},
+	}
+
+	for _, tt := range tests {
+		items := make([]agendaItem, tt.count)
+		for i := range items {
+			items[i] = agendaItem{Event: model.Event{ID: i + 1, Text: "Water plants"}, At: at.Add(time.Duration(i) * time.Hour)}
+		}
+
+		text, keyboard := bm.formatAgenda(tr, "Agenda", items)
+
+		var buttons int
+		for _, row := range keyboard.InlineKeyboard {
+			buttons += len(row)
+		}
+		if buttons != tt.buttons {
+			t.Errorf("%d items: got %d buttons, want %d", tt.count, buttons, tt.buttons)
+		}
+		if strings.Contains(text, "more") != (tt.more != "") || !strings.Contains(text, tt.more) {
+			t.Errorf("%d items: got text %q, want %q line", tt.count, text, tt.more)
+		}
+	}
+}
+
+// TestDBSendDigests checks that digest is sent at user time only if the user has events and is not on vacation.
+func TestDBSendDigests(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+
+	var (
+		mu   sync.Mutex
+		sent = map[int64]int{}
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/sendMessage") {
+			chatID, _ := strconv.ParseInt(r.FormValue("chat_id"), 10, 64)
+			mu.Lock()
+			sent[chatID]++
+			mu.Unlock()
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	b, err := bot.New("test", bot.WithSkipGetMe(), bot.WithServerURL(srv.URL))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bm.b = b
+
+	now := time.Now().UTC().Truncate(time.Minute)
+	minutes := now.Hour()*60 + now.Minute()
+	for _, chatID := range []int64{ownerID, attackerID} {
+		_, err = bm.EventsRepo.AddUser(ctx, &db.User{
+			UserTgID:      chatID,
+			TimeZone:      "UTC",
+			DigestTime:    &minutes,
+			PreviewTime:   &minutes,
+			SnoozePresets: []int{5},
+			VacationMode:  db.VacationModeSummary,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = bm.EventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID: chatID,
+			Message:  "Gym",
+			SendAt:   now,
+			StatusID: db.StatusEnabled,
+			Weekdays: []int{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = bm.EventsRepo.AddVacation(ctx, &db.Vacation{
+		UserTgID: attackerID,
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(time.Hour),
+		StatusID: db.StatusEnabled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = bm.sendDigests(ctx, now); err != nil {
+		t.Fatal(err)
+	}
+	// a minute later nobody is scheduled
+	if err = bm.sendDigests(ctx, now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	// tomorrow preview has no events, so only today's digest is sent
+	if sent[ownerID] != 1 {
+		t.Errorf("got %d messages, want today's digest", sent[ownerID])
+	}
+	if sent[attackerID] != 0 {
+		t.Errorf("got %d messages during vacation", sent[attackerID])
+	}
+}
@@ -0,0 +1,519 @@
+package event_reminder_bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/backup"
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// ExportJSON and ExportCSV are /export formats of backup files with all events and settings.
+	ExportJSON = "json"
+	ExportCSV  = "csv"
+)
+
+// restorePlan is a checked backup waiting for merge or replace confirmation.
+type restorePlan struct {
+	ExportedAt time.Time
+	// Settings are nil if backup settings are invalid, they are kept on replace then.
+	Settings *db.User
+	Events   []restoreEvent
+	// Statuses are backup events count by status.
+	Statuses map[int]int
+	Past     int
+	Invalid  []string
+	// Current are IDs of active and paused events moved to trash on replace.
+	Current []int
+	// Periodic is count of current periodic events.
+	Periodic  int
+	CreatedAt time.Time
+}
+
+// restoreEvent is a valid backup event. Duplicate events are already added and skipped on merge.
+type restoreEvent struct {
+	Event     db.Event
+	Tags      []string
+	Duplicate bool
+}
+
+// BackupFormat returns ExportJSON or ExportCSV if document looks like backup file or empty string.
+func BackupFormat(d *models.Document) string {
+	if d == nil {
+		return ""
+	}
+
+	name := strings.ToLower(d.FileName)
+	switch {
+	case strings.HasSuffix(name, "."+ExportJSON) || strings.HasPrefix(d.MimeType, backup.JSONContentType):
+		return ExportJSON
+	case strings.HasSuffix(name, "."+ExportCSV) || strings.HasPrefix(d.MimeType, backup.CSVContentType):
+		return ExportCSV
+	}
+
+	return ""
+}
+
+// ExportBackup returns all user events and settings as JSON or CSV backup file.
+func (bm *BotManager) ExportBackup(ctx context.Context, chatID int64, format string) ([]byte, error) {
+	u, err := bm.EventsRepo.UserByID(ctx, chatID)
+	if err != nil {
+		return nil, err
+	} else if u == nil {
+		u = defaultUserRow(chatID)
+	}
+
+	sort := db.WithSort(db.SortField{Column: db.Columns.Event.SendAt, Direction: db.SortAsc})
+	events, err := bm.EventsRepo.EventsByFilters(ctx, &db.EventSearch{UserTgID: &chatID}, db.PagerNoLimit, sort)
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := bm.EventsRepo.TrashedEvents(ctx, chatID, time.Time{}, db.PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := bm.EventsRepo.UserEventTags(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := backup.New(*u, append(events, trashed...), tags, time.Now())
+
+	var buf bytes.Buffer
+	if format == ExportCSV {
+		err = backup.EncodeCSV(&buf, b)
+	} else {
+		err = backup.EncodeJSON(&buf, b)
+	}
+
+	return buf.Bytes(), err
+}
+
+// restoreBackup parses uploaded backup file and shows restore preview.
+func (bm *BotManager) restoreBackup(ctx context.Context, b *bot.Bot, chatID int64, format string, data []byte) {
+	tr := bm.Tr(ctx, chatID)
+
+	var (
+		bk  *backup.Backup
+		err error
+	)
+	if format == ExportCSV {
+		bk, err = backup.DecodeCSV(bytes.NewReader(data))
+	} else {
+		bk, err = backup.DecodeJSON(bytes.NewReader(data))
+	}
+
+	var plan *restorePlan
+	if err == nil {
+		plan, err = bm.PlanRestore(ctx, chatID, bk, time.Now())
+	}
+
+	var text string
+	var keyboard models.ReplyMarkup
+	switch {
+	case errors.Is(err, backup.ErrInvalidBackup):
+		bm.Printf("Некорректная резервная копия пользователя %d: %v", chatID, err)
+		text = tr.T("backup.invalid_file")
+	case err != nil:
+		bm.Errorf("Ошибка разбора резервной копии пользователя %d: %v", chatID, err)
+		text = tr.T("import.error")
+	default:
+		bm.setRestore(chatID, plan)
+		text, keyboard = bm.restorePreview(tr, plan)
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+	bm.OnError(err)
+}
+
+// HandleBackup merges or replaces events with previewed backup, or cancels restore.
+func (bm *BotManager) HandleBackup(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	tr := bm.Tr(ctx, chatID)
+	plan := bm.takeRestore(chatID)
+
+	var text string
+	switch {
+	case data.Action == ActionBackupCancel:
+		text = tr.T("backup.cancelled")
+	case plan == nil || time.Since(plan.CreatedAt) > importTTL:
+		text = tr.T("backup.expired")
+	default:
+		replace := data.Action == ActionBackupReplace
+		created, err := bm.ApplyRestore(ctx, chatID, plan, replace)
+		switch {
+		case err != nil:
+			bm.Errorf("Ошибка восстановления резервной копии пользователя %d: %v", chatID, err)
+			text = tr.T("backup.failed")
+		case replace:
+			text = tr.T("backup.replaced", created)
+		default:
+			text = tr.N("import.done", created)
+		}
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	})
+	bm.OnError(err)
+}
+
+// PlanRestore checks backup events and settings. Invalid events and past one-off reminders are skipped,
+// periodic events in the past are moved to their next occurrence.
+func (bm *BotManager) PlanRestore(ctx context.Context, chatID int64, bk *backup.Backup, now time.Time) (*restorePlan, error) {
+	tr := bm.Tr(ctx, chatID)
+
+	// active and paused events, deleted ones are filtered out by default
+	current, err := bm.EventsRepo.EventsByFilters(ctx, &db.EventSearch{UserTgID: &chatID}, db.PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+	trashed, err := bm.EventsRepo.TrashedEvents(ctx, chatID, time.Time{}, db.PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+	periodic, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &restorePlan{
+		ExportedAt: bk.ExportedAt,
+		Statuses:   make(map[int]int),
+		Periodic:   periodic,
+		CreatedAt:  now,
+	}
+	for _, e := range current {
+		plan.Current = append(plan.Current, e.ID)
+	}
+
+	loc := bm.UserLocation(ctx, chatID)
+	if u, reason := checkBackupSettings(bk.Settings, chatID); reason != "" {
+		plan.Invalid = append(plan.Invalid, tr.T("backup.settings_invalid", tr.T(reason)))
+	} else {
+		plan.Settings = u
+		loc = model.LoadLocation(u.TimeZone)
+	}
+
+	existing := make(map[string]bool, len(current)+len(trashed))
+	for _, e := range append(current, trashed...) {
+		existing[eventKey(e)] = true
+	}
+
+	for i, e := range bk.Events {
+		event, reason := checkBackupEvent(e, chatID, loc, now)
+		switch {
+		case reason == "past":
+			plan.Past++
+			continue
+		case reason != "":
+			name := "#" + strconv.Itoa(i+1)
+			if e.Title != "" {
+				name += " " + truncate(e.Title, 40)
+			}
+			plan.Invalid = append(plan.Invalid, name+": "+tr.T(reason))
+			continue
+		}
+
+		key := eventKey(*event)
+		plan.Statuses[event.StatusID]++
+		plan.Events = append(plan.Events, restoreEvent{Event: *event, Tags: e.Tags, Duplicate: existing[key]})
+		existing[key] = true
+	}
+
+	return plan, nil
+}
+
+// ApplyRestore adds backup events with their tags. Merge skips duplicate events and keeps settings,
+// replace moves current events to trash and restores settings. Restore is applied in one transaction,
+// so nothing is changed on error. It returns the number of created events.
+func (bm *BotManager) ApplyRestore(ctx context.Context, chatID int64, plan *restorePlan, replace bool) (int, error) {
+	var created int
+	err := bm.EventsRepo.RunInTx(ctx, func(r db.EventsRepo) error {
+		periodic := 0
+		if replace {
+			// events could be added after preview, so current ones are reloaded
+			current, err := r.EventsByFilters(ctx, &db.EventSearch{UserTgID: &chatID}, db.PagerNoLimit, db.WithColumns(db.Columns.Event.ID))
+			if err != nil {
+				return err
+			}
+			ids := make([]int, 0, len(current))
+			for _, e := range current {
+				ids = append(ids, e.ID)
+			}
+			_, err = r.BulkUpdateEvents(ctx, chatID, ids, func(r db.EventsRepo, e *db.Event) error {
+				_, err := r.TrashEvent(ctx, e.ID)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+
+			if plan.Settings != nil {
+				c := db.Columns.User
+				_, err = r.UpdateUser(ctx, plan.Settings, db.WithColumns(c.Language, c.TimeZone, c.DigestTime, c.PreviewTime, c.WeeklyTime, c.QuietFrom, c.QuietTo, c.SnoozePresets, c.VacationMode))
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			var err error
+			if periodic, err = r.CountUserPeriodicEvents(ctx, chatID); err != nil {
+				return err
+			}
+		}
+
+		events, _ := plan.restoredEvents(replace, periodic)
+		for _, re := range events {
+			event := re.Event
+			added, err := r.AddEvent(ctx, &event)
+			if err != nil {
+				return err
+			}
+			if err = r.SetEventTags(ctx, added, re.Tags); err != nil {
+				return err
+			}
+		}
+		created = len(events)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	if replace && plan.Settings != nil {
+		bm.forgetUser(chatID)
+	}
+
+	return created, nil
+}
+
+// restoredEvents returns events added by merge or replace and the count of events over MaxPeriodic limit.
+func (p *restorePlan) restoredEvents(replace bool, periodic int) ([]restoreEvent, int) {
+	var events []restoreEvent
+	var limit int
+	for _, re := range p.Events {
+		if re.Duplicate && !replace {
+			continue
+		}
+		if re.Event.Periodicity != nil && re.Event.StatusID != db.StatusDeleted {
+			if periodic >= MaxPeriodic {
+				limit++
+				continue
+			}
+			periodic++
+		}
+		events = append(events, re)
+	}
+
+	return events, limit
+}
+
+// restorePreview returns dry-run summary of merge and replace with confirmation buttons.
+func (bm *BotManager) restorePreview(tr *i18n.Localizer, plan *restorePlan) (string, *models.InlineKeyboardMarkup) {
+	var msg strings.Builder
+	msg.WriteString(tr.T("backup.title", tr.DateTime(plan.ExportedAt)) + "\n\n")
+	msg.WriteString(tr.T("backup.events", len(plan.Events),
+		plan.Statuses[db.StatusEnabled], plan.Statuses[db.StatusDisabled], plan.Statuses[db.StatusDeleted]) + "\n")
+	if plan.Past > 0 {
+		msg.WriteString(tr.T("backup.past", plan.Past) + "\n")
+	}
+
+	merged, mergeLimit := plan.restoredEvents(false, plan.Periodic)
+	msg.WriteString("\n" + tr.T("backup.merge", len(merged), len(plan.Events)-len(merged)-mergeLimit) + "\n")
+	if mergeLimit > 0 {
+		msg.WriteString(tr.T("import.limit", mergeLimit) + "\n")
+	}
+
+	replaced, replaceLimit := plan.restoredEvents(true, 0)
+	settings := "backup.settings_kept"
+	if plan.Settings != nil {
+		settings = "backup.settings_restored"
+	}
+	msg.WriteString("\n" + tr.T("backup.replace", len(plan.Current), len(replaced), tr.T(settings)) + "\n")
+	if replaceLimit > 0 {
+		msg.WriteString(tr.T("import.limit", replaceLimit) + "\n")
+	}
+	if mergeLimit > 0 || replaceLimit > 0 {
+		msg.WriteString(tr.T("periodicity.limit", MaxPeriodic) + "\n")
+	}
+
+	if len(plan.Invalid) > 0 {
+		msg.WriteString("\n" + tr.T("backup.invalid") + "\n")
+		for i, line := range plan.Invalid {
+			if i == maxImportLines {
+				msg.WriteString(tr.T("import.more", len(plan.Invalid)-maxImportLines) + "\n")
+				break
+			}
+			msg.WriteString("• " + line + "\n")
+		}
+	}
+
+	return msg.String(), &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				bm.button(tr.T("backup.merge_button"), ActionBackupMerge),
+				bm.button(tr.T("backup.replace_button"), ActionBackupReplace),
+			},
+			{bm.button(tr.T("import.cancel"), ActionBackupCancel)},
+		},
+	}
+}
+
+// checkBackupEvent converts backup event to event row. It returns invalid reason message key or "past".
+func checkBackupEvent(e backup.Event, chatID int64, loc *time.Location, now time.Time) (*db.Event, string) {
+	event, err := e.DBEvent(chatID)
+	if err != nil {
+		return nil, "backup.reason.status"
+	}
+	if _, ok := event.Validate(); !ok {
+		return nil, "backup.reason.fields"
+	}
+
+	title := strings.TrimSpace(event.Message)
+	switch {
+	case title == "" || utf8.RuneCountInString(title) > MaxTitleLength:
+		return nil, "backup.reason.title"
+	case event.Notes != nil && utf8.RuneCountInString(*event.Notes) > MaxNotesLength:
+		return nil, "backup.reason.notes"
+	case event.SendAt.IsZero():
+		return nil, "backup.reason.date"
+	}
+	event.Message = title
+
+	for _, day := range event.Weekdays {
+		if day < 1 || day > 7 {
+			return nil, "backup.reason.weekdays"
+		}
+	}
+	if event.Periodicity != nil {
+		switch *event.Periodicity {
+		case db.PeriodicityHour, db.PeriodicityDay, db.PeriodicityWeek:
+		case db.PeriodicityWeekdays:
+			if len(event.Weekdays) == 0 {
+				return nil, "backup.reason.weekdays"
+			}
+		default:
+			return nil, "backup.reason.periodicity"
+		}
+	}
+
+	if event.StatusID != db.StatusEnabled || !event.SendAt.Before(now) {
+		return &event, ""
+	}
+
+	// overdue reminders are not sent again, series continue from the next occurrence
+	if event.Periodicity == nil {
+		return nil, "past"
+	}
+	next := model.NextAfter(model.ReminderEvent{DateTime: event.SendAt.In(loc), Periodicity: event.Periodicity, Weekdays: event.Weekdays}, now)
+	if next == nil {
+		return nil, "past"
+	}
+	event.SendAt = *next
+
+	return &event, ""
+}
+
+// checkBackupSettings returns backup settings as user row or invalid reason message key.
+func checkBackupSettings(s backup.Settings, chatID int64) (*db.User, string) {
+	u := s.User(chatID)
+	if _, ok := u.Validate(); !ok {
+		return nil, "backup.reason.fields"
+	}
+
+	if u.Language != nil {
+		if _, ok := i18n.Parse(*u.Language); !ok {
+			return nil, "backup.reason.language"
+		}
+	}
+
+	tz, err := ParseTimeZone(u.TimeZone)
+	if err != nil {
+		return nil, "backup.reason.timezone"
+	}
+	u.TimeZone = tz
+
+	for _, t := range []*int{u.DigestTime, u.PreviewTime, u.WeeklyTime, u.QuietFrom, u.QuietTo} {
+		if t != nil && (*t < 0 || *t >= 24*60) {
+			return nil, "backup.reason.time"
+		}
+	}
+
+	if len(u.SnoozePresets) > maxSnoozePresets {
+		return nil, "backup.reason.snooze"
+	}
+	for _, p := range u.SnoozePresets {
+		if !slices.Contains(snoozeChoices, p) {
+			return nil, "backup.reason.snooze"
+		}
+	}
+
+	if u.VacationMode == "" {
+		u.VacationMode = db.VacationModeSummary
+	} else if !slices.Contains(vacationModeList, u.VacationMode) {
+		return nil, "backup.reason.vacation"
+	}
+
+	return &u, ""
+}
+
+// eventKey identifies duplicate events: the same title, periodicity and reminder minute.
+func eventKey(e db.Event) string {
+	periodicity := ""
+	if e.Periodicity != nil {
+		periodicity = *e.Periodicity
+	}
+
+	return e.Message + "\x00" + periodicity + "\x00" + strconv.FormatInt(e.SendAt.Truncate(time.Minute).Unix(), 10)
+}
+
+// defaultUserRow returns settings row of the user who has never changed settings.
+func defaultUserRow(chatID int64) *db.User {
+	u := model.NewDefaultUser(chatID)
+
+	return &db.User{
+		UserTgID:      chatID,
+		TimeZone:      u.Location.String(),
+		DigestTime:    u.DigestTime,
+		SnoozePresets: slices.Clone(u.SnoozePresets),
+		VacationMode:  u.VacationMode,
+	}
+}
+
+func (bm *BotManager) setRestore(chatID int64, plan *restorePlan) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	bm.restores[chatID] = plan
+}
+
+// takeRestore returns and forgets pending restore of the user.
+func (bm *BotManager) takeRestore(chatID int64) *restorePlan {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	plan := bm.restores[chatID]
+	delete(bm.restores, chatID)
+
+	return plan
+}
@@ -0,0 +1,75 @@
+package event_reminder_bot
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/backup"
+	"event-reminder-bot/pkg/db"
+)
+
+func TestCheckBackupEvent(t *testing.T) {
+	loc := time.UTC
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, loc)
+	at := func(day, hour int) time.Time {
+		return time.Date(2026, time.October, day, hour, 0, 0, 0, loc)
+	}
+	p := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		event  backup.Event
+		sendAt time.Time
+		reason string
+	}{
+		{"active", backup.Event{Status: backup.StatusActive, Title: "Dentist", SendAt: at(20, 10)}, at(20, 10), ""},
+		{"past one-off", backup.Event{Status: backup.StatusActive, Title: "Dentist", SendAt: at(17, 10)}, time.Time{}, "past"},
+		{"past paused", backup.Event{Status: backup.StatusPaused, Title: "Dentist", SendAt: at(17, 10)}, at(17, 10), ""},
+		{"past series", backup.Event{Status: backup.StatusActive, Title: "Pills", SendAt: at(1, 9), Periodicity: p(db.PeriodicityDay)}, at(19, 9), ""},
+		{"trashed", backup.Event{Status: backup.StatusDeleted, Title: "Old", SendAt: at(1, 9), DeletedAt: &now}, at(1, 9), ""},
+		{"status", backup.Event{Status: "done", Title: "Dentist", SendAt: at(20, 10)}, time.Time{}, "backup.reason.status"},
+		{"empty title", backup.Event{Status: backup.StatusActive, Title: " ", SendAt: at(20, 10)}, time.Time{}, "backup.reason.title"},
+		{"long title", backup.Event{Status: backup.StatusActive, Title: strings.Repeat("a", MaxTitleLength+1), SendAt: at(20, 10)}, time.Time{}, "backup.reason.title"},
+		{"no date", backup.Event{Status: backup.StatusActive, Title: "Dentist"}, time.Time{}, "backup.reason.date"},
+		{"periodicity", backup.Event{Status: backup.StatusActive, Title: "Rent", SendAt: at(20, 10), Periodicity: p("month")}, time.Time{}, "backup.reason.periodicity"},
+		{"long periodicity", backup.Event{Status: backup.StatusActive, Title: "Rent", SendAt: at(20, 10), Periodicity: p(strings.Repeat("a", 17))}, time.Time{}, "backup.reason.fields"},
+		{"no weekdays", backup.Event{Status: backup.StatusActive, Title: "Gym", SendAt: at(20, 10), Periodicity: p(db.PeriodicityWeekdays)}, time.Time{}, "backup.reason.weekdays"},
+		{"bad weekday", backup.Event{Status: backup.StatusActive, Title: "Gym", SendAt: at(20, 10), Weekdays: []int{8}}, time.Time{}, "backup.reason.weekdays"},
+	}
+
+	for _, tt := range tests {
+		event, reason := checkBackupEvent(tt.event, 1, loc, now)
+		if reason != tt.reason {
+			t.Errorf("%s: reason = %q, want %q", tt.name, reason, tt.reason)
+			continue
+		}
+		if reason == "" && !event.SendAt.Equal(tt.sendAt) {
+			t.Errorf("%s: sendAt = %v, want %v", tt.name, event.SendAt, tt.sendAt)
+		}
+	}
+}
+
+func TestCheckBackupSettings(t *testing.T) {
+	p := func(n int) *int { return &n }
+	lang := "de"
+
+	tests := []struct {
+		name     string
+		settings backup.Settings
+		reason   string
+	}{
+		{"valid", backup.Settings{TimeZone: "UTC+3", DigestTime: p(8 * 60), SnoozePresets: []int{5, 10}}, ""},
+		{"language", backup.Settings{Language: &lang, TimeZone: "UTC"}, "backup.reason.language"},
+		{"time zone", backup.Settings{TimeZone: "Mars/Olympus"}, "backup.reason.timezone"},
+		{"time", backup.Settings{TimeZone: "UTC", QuietFrom: p(24 * 60)}, "backup.reason.time"},
+		{"snooze", backup.Settings{TimeZone: "UTC", SnoozePresets: []int{7}}, "backup.reason.snooze"},
+		{"vacation", backup.Settings{TimeZone: "UTC", VacationMode: "skip"}, "backup.reason.vacation"},
+	}
+
+	for _, tt := range tests {
+		if _, reason := checkBackupSettings(tt.settings, 1); reason != tt.reason {
+			t.Errorf("%s: reason = %q, want %q", tt.name, reason, tt.reason)
+		}
+	}
+}
@@ -0,0 +1,314 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// selection is a multi-select state of /list for bulk actions.
+type selection struct {
+	IDs       []int
+	Page      int
+	TagID     int
+	UpdatedAt time.Time
+}
+
+// selectShifts are postpone options for selected events. Index is stored in callback data, so append only.
+var selectShifts = []struct {
+	Data string
+	Key  string
+}{
+	{Data: "1h", Key: "select.shift.hour"},
+	{Data: "3h", Key: "select.shift.3hours"},
+	{Data: "1d", Key: "select.shift.day"},
+	{Data: "1w", Key: "select.shift.week"},
+}
+
+// HandleSelect handles all callbacks of /list selection mode.
+func (bm *BotManager) HandleSelect(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	switch data.Action {
+	case ActionSelectStart:
+		bm.setSelection(chatID, &selection{Page: data.Arg(0), TagID: data.Arg(1)})
+	case ActionSelectPage:
+		sel := bm.getSelection(chatID)
+		sel.Page = data.Arg(0)
+		bm.setSelection(chatID, sel)
+	case ActionSelectToggle:
+		sel := bm.getSelection(chatID)
+		sel.IDs = toggleInSlice(sel.IDs, data.Arg(0))
+		bm.setSelection(chatID, sel)
+	case ActionSelectCancel:
+		sel := bm.getSelection(chatID)
+		bm.setSelection(chatID, nil)
+		bm.editListPage(ctx, b, chatID, messageID, sel.Page, sel.TagID)
+		return
+	case ActionSelectPostpone:
+		tr := bm.Tr(ctx, chatID)
+		bm.editSelectionMenu(ctx, b, chatID, messageID, tr.T("select.postpone_ask"), bm.selectShiftsKeyboard(tr, bm.getSelection(chatID).Page))
+		return
+	case ActionSelectTagMenu:
+		bm.showSelectionTags(ctx, b, chatID, messageID)
+		return
+	default:
+		bm.applySelection(ctx, b, data, chatID, messageID)
+		return
+	}
+
+	bm.editSelectionPage(ctx, b, chatID, messageID)
+}
+
+// applySelection applies bulk action to all selected events in one transaction.
+func (bm *BotManager) applySelection(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	sel := bm.getSelection(chatID)
+	if len(sel.IDs) == 0 {
+		bm.editSelectionPage(ctx, b, chatID, messageID)
+		return
+	}
+
+	// result is a plural message key, event count is its last argument
+	var (
+		fn         func(r db.EventsRepo, e *db.Event) error
+		result     string
+		resultArgs []any
+		skipped    int
+	)
+	switch data.Action {
+	case ActionSelectDelete:
+		fn = func(r db.EventsRepo, e *db.Event) error {
+			_, err := r.TrashEvent(ctx, e.ID)
+			return err
+		}
+		result = "select.trashed"
+	case ActionSelectPause:
+		fn = func(r db.EventsRepo, e *db.Event) error {
+			e.StatusID = db.StatusDisabled
+			_, err := r.UpdateEvent(ctx, e, db.WithColumns(db.Columns.Event.StatusID))
+			return err
+		}
+		result = "select.paused"
+	case ActionSelectShift:
+		i := data.Arg(0)
+		if i < 0 || i >= len(selectShifts) {
+			return
+		}
+		d, err := ParseDuration(selectShifts[i].Data)
+		if err != nil {
+			return
+		}
+		fn = func(r db.EventsRepo, e *db.Event) error {
+			e.SendAt = e.SendAt.Add(d)
+			_, err := r.UpdateEvent(ctx, e, db.WithColumns(db.Columns.Event.SendAt))
+			return err
+		}
+		result = "select.postponed"
+	case ActionSelectTag:
+		tag, err := bm.EventsRepo.TagByID(ctx, data.Arg(0))
+		if err != nil || tag == nil || tag.UserTgID != chatID {
+			return
+		}
+		fn = func(r db.EventsRepo, e *db.Event) error {
+			if !replaceTag(e, tag.Title) {
+				skipped++
+				return nil
+			}
+			if _, err := r.UpdateEvent(ctx, e, db.WithColumns(db.Columns.Event.Message, db.Columns.Event.Notes)); err != nil {
+				return err
+			}
+			return r.SetEventTags(ctx, e, []string{tag.Title})
+		}
+		result, resultArgs = "select.tagged", []any{tag.Title}
+	default:
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	n, err := bm.EventsRepo.BulkUpdateEvents(ctx, chatID, sel.IDs, fn)
+	if err != nil {
+		bm.Errorf("Ошибка массового изменения событий: %v", err)
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("select.error"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	bm.setSelection(chatID, nil)
+
+	n -= skipped
+	text := tr.N(result, n, append(resultArgs, n)...)
+	if skipped > 0 {
+		text += "\n" + tr.T("select.tag_skipped", skipped, MaxTitleLength)
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	bm.OnError(err)
+	bm.editListPage(ctx, b, chatID, messageID, sel.Page, sel.TagID)
+}
+
+func (bm *BotManager) editSelectionPage(ctx context.Context, b *bot.Bot, chatID int64, messageID int) {
+	sel := bm.getSelection(chatID)
+
+	events, total, err := bm.GetUserEventsPaged(ctx, chatID, sel.Page, listPageSize, sel.TagID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки событий: %v", err)
+		return
+	}
+
+	start := (sel.Page - 1) * listPageSize
+	tr := bm.Tr(ctx, chatID)
+
+	var msg strings.Builder
+	msg.WriteString(tr.T("select.title", len(sel.IDs)) + "\n\n")
+
+	var (
+		buttons [][]models.InlineKeyboardButton
+		row     []models.InlineKeyboardButton
+	)
+	for i, e := range events {
+		num := strconv.Itoa(start + i + 1)
+		if slices.Contains(sel.IDs, e.ID) {
+			num = "✅ " + num
+		}
+		msg.WriteString(fmt.Sprintf("%d. %s — %s\n", start+i+1, e.Text, tr.DateTime(e.DateTime)))
+
+		row = append(row, bm.button(num, ActionSelectToggle, e.ID))
+		if len(row) == 5 {
+			buttons = append(buttons, row)
+			row = []models.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	var navRow []models.InlineKeyboardButton
+	if sel.Page > 1 {
+		navRow = append(navRow, bm.button(tr.T("button.prev"), ActionSelectPage, sel.Page-1))
+	}
+	if start+listPageSize < total {
+		navRow = append(navRow, bm.button(tr.T("button.next"), ActionSelectPage, sel.Page+1))
+	}
+	if len(navRow) > 0 {
+		buttons = append(buttons, navRow)
+	}
+
+	buttons = append(buttons,
+		[]models.InlineKeyboardButton{
+			bm.button(tr.T("select.delete"), ActionSelectDelete),
+			bm.button(tr.T("select.postpone"), ActionSelectPostpone),
+		},
+		[]models.InlineKeyboardButton{
+			bm.button(tr.T("select.pause"), ActionSelectPause),
+			bm.button(tr.T("select.tag"), ActionSelectTagMenu),
+		},
+		[]models.InlineKeyboardButton{
+			bm.button(tr.T("select.cancel"), ActionSelectCancel),
+		},
+	)
+
+	bm.editSelectionMenu(ctx, b, chatID, messageID, msg.String(), buttons)
+}
+
+func (bm *BotManager) showSelectionTags(ctx context.Context, b *bot.Bot, chatID int64, messageID int) {
+	tags, err := bm.EventsRepo.UserTags(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки тегов: %v", err)
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+
+	var buttons [][]models.InlineKeyboardButton
+	for _, t := range tags {
+		buttons = append(buttons, []models.InlineKeyboardButton{bm.button("#"+t.Title, ActionSelectTag, t.ID)})
+	}
+	buttons = append(buttons, []models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionSelectPage, bm.getSelection(chatID).Page)})
+
+	text := tr.T("select.tag_ask")
+	if len(tags) == 0 {
+		text = tr.T("select.no_tags")
+	}
+
+	bm.editSelectionMenu(ctx, b, chatID, messageID, text, buttons)
+}
+
+func (bm *BotManager) editSelectionMenu(ctx context.Context, b *bot.Bot, chatID int64, messageID int, text string, buttons [][]models.InlineKeyboardButton) {
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        text,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: buttons},
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) selectShiftsKeyboard(tr *i18n.Localizer, page int) [][]models.InlineKeyboardButton {
+	var buttons [][]models.InlineKeyboardButton
+	for i, s := range selectShifts {
+		buttons = append(buttons, []models.InlineKeyboardButton{bm.button(tr.T(s.Key), ActionSelectShift, i)})
+	}
+	return append(buttons, []models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionSelectPage, page)})
+}
+
+func (bm *BotManager) getSelection(chatID int64) *selection {
+	bm.Mu.RLock()
+	defer bm.Mu.RUnlock()
+
+	if sel, ok := bm.selections[chatID]; ok {
+		return &selection{IDs: slices.Clone(sel.IDs), Page: sel.Page, TagID: sel.TagID}
+	}
+
+	return &selection{Page: 1}
+}
+
+func (bm *BotManager) setSelection(chatID int64, sel *selection) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	if sel == nil {
+		delete(bm.selections, chatID)
+		return
+	}
+	if sel.Page < 1 {
+		sel.Page = 1
+	}
+	sel.UpdatedAt = time.Now()
+	bm.selections[chatID] = sel
+}
+
+// replaceTag removes all hashtags from event title and notes and adds #tag to the title.
+// Event is not changed and false is returned if the title would be longer than MaxTitleLength.
+func replaceTag(e *db.Event, tag string) bool {
+	title := strings.TrimSpace(tagSpaceRe.ReplaceAllString(e.Message, "")) + " #" + tag
+	title = strings.TrimSpace(title)
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return false
+	}
+	e.Message = title
+
+	if e.Notes != nil {
+		notes := strings.TrimSpace(tagSpaceRe.ReplaceAllString(*e.Notes, ""))
+		e.Notes = &notes
+		if notes == "" {
+			e.Notes = nil
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,36 @@
+package event_reminder_bot
+
+import (
+	"strings"
+	"testing"
+
+	"event-reminder-bot/pkg/db"
+)
+
+func TestReplaceTag(t *testing.T) {
+	long := strings.Repeat("a", MaxTitleLength-5)
+
+	tests := []struct {
+		title string
+		want  string
+		ok    bool
+	}{
+		{"Buy milk", "Buy milk #home", true},
+		{"Buy  milk #work today", "Buy  milk today #home", true},
+		{"#work Call  mom", "Call  mom #home", true},
+		{long, long, false},
+		{long + " #work", long + " #work", false},
+		{strings.Repeat("a", MaxTitleLength-6), strings.Repeat("a", MaxTitleLength-6) + " #home", true},
+	}
+
+	for _, tt := range tests {
+		e := &db.Event{Message: tt.title}
+		ok := replaceTag(e, "home")
+		if ok != tt.ok {
+			t.Errorf("%q: got %v, want %v", tt.title, ok, tt.ok)
+		}
+		if e.Message != tt.want {
+			t.Errorf("%q: got title %q", tt.title, e.Message)
+		}
+	}
+}
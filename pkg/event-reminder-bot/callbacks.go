@@ -0,0 +1,214 @@
+package event_reminder_bot
+
+import (
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot/models"
+)
+
+// Callback actions. Values are stored in buttons of chat history, so never change or reuse them.
+const (
+	ActionDone             callback.Action = 1 // eventID
+	ActionSnooze           callback.Action = 2 // eventID, snooze preset
+	ActionSnoozeCustom     callback.Action = 3 // eventID
+	ActionPeriod           callback.Action = 4 // eventID, periodicityChoices index
+	ActionWeekday          callback.Action = 5 // eventID, weekday
+	ActionWeekdaysDone     callback.Action = 6 // eventID
+	ActionEventDetail      callback.Action = 7 // eventID
+	ActionEventEdit        callback.Action = 8 // eventID
+	ActionEventDelete      callback.Action = 9 // eventID
+	ActionBackToList       callback.Action = 10
+	ActionEditDate         callback.Action = 11 // eventID
+	ActionEditDescription  callback.Action = 12 // eventID
+	ActionEditNotes        callback.Action = 13 // eventID
+	ActionEditPeriodicity  callback.Action = 14 // eventID
+	ActionEditPeriod       callback.Action = 15 // eventID, periodicityChoices index
+	ActionEditWeekday      callback.Action = 16 // eventID, weekday
+	ActionEditWeekdaysDone callback.Action = 17 // eventID
+	ActionPostpone         callback.Action = 18 // eventID, hours
+	ActionPostponeCustom   callback.Action = 19 // eventID
+	ActionListPage         callback.Action = 20 // page, tagID
+	ActionSearchPage       callback.Action = 21 // page
+	ActionDigestTag        callback.Action = 22 // tagID
+	ActionSelectStart      callback.Action = 23 // page, tagID
+	ActionSelectPage       callback.Action = 24 // page
+	ActionSelectToggle     callback.Action = 25 // eventID
+	ActionSelectDelete     callback.Action = 26
+	ActionSelectPause      callback.Action = 27
+	ActionSelectPostpone   callback.Action = 28
+	ActionSelectShift      callback.Action = 29 // selectShifts index
+	ActionSelectTagMenu    callback.Action = 30
+	ActionSelectTag        callback.Action = 31 // tagID
+	ActionSelectCancel     callback.Action = 32
+	ActionUndo             callback.Action = 33 // eventID
+	ActionRestore          callback.Action = 34 // eventID
+	ActionPauseMenu        callback.Action = 35 // eventID
+	ActionPause            callback.Action = 36 // eventID, days
+	ActionPauseDate        callback.Action = 37 // eventID
+	ActionResume           callback.Action = 38 // eventID
+	ActionVacationCancel   callback.Action = 39
+	ActionVacationMode     callback.Action = 40 // vacationModeList index
+	ActionLanguage         callback.Action = 41 // languageChoices index
+	ActionSettings         callback.Action = 42
+	ActionSettingsSection  callback.Action = 43 // settings section
+	ActionSettingsSet      callback.Action = 44 // settings section, choice
+	ActionPostponePreset   callback.Action = 45 // eventID, snooze preset
+	ActionOccurrenceDone   callback.Action = 46 // eventID
+	ActionOccurrenceSkip   callback.Action = 47 // eventID
+	ActionOccurrenceSnooze callback.Action = 48 // eventID, snooze preset
+	ActionStopSeries       callback.Action = 49 // eventID
+	ActionPickerNoop       callback.Action = 50
+	ActionPickerMonth      callback.Action = 51 // picker target, ref, month index
+	ActionPickerDay        callback.Action = 52 // picker target, ref, civil day
+	ActionPickerHour       callback.Action = 53 // picker target, ref, civil day, hour
+	ActionPickerTime       callback.Action = 54 // picker target, ref, civil day, minutes
+	ActionImportConfirm    callback.Action = 55
+	ActionImportCancel     callback.Action = 56
+	ActionBackupMerge      callback.Action = 57
+	ActionBackupReplace    callback.Action = 58
+	ActionBackupCancel     callback.Action = 59
+	ActionBroadcastSend    callback.Action = 60
+	ActionBroadcastCancel  callback.Action = 61
+)
+
+// callbackActions is the action registry: name and number of arguments.
+var callbackActions = []struct {
+	Action callback.Action
+	Name   string
+	Args   int
+}{
+	{ActionDone, "done", 1},
+	{ActionSnooze, "snooze", 2},
+	{ActionSnoozeCustom, "snooze_custom", 1},
+	{ActionPeriod, "period", 2},
+	{ActionWeekday, "weekday", 2},
+	{ActionWeekdaysDone, "weekdays_done", 1},
+	{ActionEventDetail, "event_detail", 1},
+	{ActionEventEdit, "event_edit", 1},
+	{ActionEventDelete, "event_delete", 1},
+	{ActionBackToList, "back_to_list", 0},
+	{ActionEditDate, "edit_date", 1},
+	{ActionEditDescription, "edit_desc", 1},
+	{ActionEditNotes, "edit_notes", 1},
+	{ActionEditPeriodicity, "edit_periodicity", 1},
+	{ActionEditPeriod, "edit_period", 2},
+	{ActionEditWeekday, "edit_weekday", 2},
+	{ActionEditWeekdaysDone, "edit_weekdays_done", 1},
+	{ActionPostpone, "postpone", 2},
+	{ActionPostponeCustom, "postpone_custom", 1},
+	{ActionListPage, "page", 2},
+	{ActionSearchPage, "search_page", 1},
+	{ActionDigestTag, "digest", 1},
+	{ActionSelectStart, "sel_start", 2},
+	{ActionSelectPage, "sel_page", 1},
+	{ActionSelectToggle, "sel_toggle", 1},
+	{ActionSelectDelete, "sel_delete", 0},
+	{ActionSelectPause, "sel_pause", 0},
+	{ActionSelectPostpone, "sel_postpone", 0},
+	{ActionSelectShift, "sel_shift", 1},
+	{ActionSelectTagMenu, "sel_tagmenu", 0},
+	{ActionSelectTag, "sel_tag", 1},
+	{ActionSelectCancel, "sel_cancel", 0},
+	{ActionUndo, "undo", 1},
+	{ActionRestore, "restore", 1},
+	{ActionPauseMenu, "pause_menu", 1},
+	{ActionPause, "pause", 2},
+	{ActionPauseDate, "pause_date", 1},
+	{ActionResume, "resume", 1},
+	{ActionVacationCancel, "vacation_cancel", 0},
+	{ActionVacationMode, "vacation_mode", 1},
+	{ActionLanguage, "language", 1},
+	{ActionSettings, "settings", 0},
+	{ActionSettingsSection, "settings_section", 1},
+	{ActionSettingsSet, "settings_set", 2},
+	{ActionPostponePreset, "postpone_preset", 2},
+	{ActionOccurrenceDone, "occurrence_done", 1},
+	{ActionOccurrenceSkip, "occurrence_skip", 1},
+	{ActionOccurrenceSnooze, "occurrence_snooze", 2},
+	{ActionStopSeries, "stop_series", 1},
+	{ActionPickerNoop, "picker_noop", 0},
+	{ActionPickerMonth, "picker_month", 3},
+	{ActionPickerDay, "picker_day", 3},
+	{ActionPickerHour, "picker_hour", 4},
+	{ActionPickerTime, "picker_time", 4},
+	{ActionImportConfirm, "import_confirm", 0},
+	{ActionImportCancel, "import_cancel", 0},
+	{ActionBackupMerge, "backup_merge", 0},
+	{ActionBackupReplace, "backup_replace", 0},
+	{ActionBackupCancel, "backup_cancel", 0},
+	{ActionBroadcastSend, "broadcast_send", 0},
+	{ActionBroadcastCancel, "broadcast_cancel", 0},
+}
+
+// periodNone is a periodicity choice for one-off events.
+const periodNone = "none"
+
+// periodicityChoices are periodicity buttons. Index is stored in callback data, so append only.
+var periodicityChoices = []struct {
+	Periodicity string
+	Key         string
+}{
+	{Periodicity: db.PeriodicityHour, Key: "periodicity.choice.hour"},
+	{Periodicity: db.PeriodicityDay, Key: "periodicity.choice.day"},
+	{Periodicity: db.PeriodicityWeek, Key: "periodicity.choice.week"},
+	{Periodicity: db.PeriodicityWeekdays, Key: "periodicity.choice.weekdays"},
+	{Periodicity: periodNone, Key: "periodicity.choice.none"},
+}
+
+// vacationModeList maps vacation mode to callback argument. Append only.
+var vacationModeList = []string{db.VacationModeSummary, db.VacationModeShift}
+
+// NewCallbackCodec returns codec with all bot actions registered.
+func NewCallbackCodec(secret string) *callback.Codec {
+	c := callback.NewCodec(secret)
+	for _, a := range callbackActions {
+		c.Register(a.Action, a.Name, a.Args)
+	}
+
+	return c
+}
+
+// DecodeCallback verifies callback data of the button.
+func (bm *BotManager) DecodeCallback(data string) (callback.Data, error) {
+	return bm.callbacks.Decode(data)
+}
+
+// callbackData returns signed callback data for action.
+func (bm *BotManager) callbackData(action callback.Action, args ...int) string {
+	return bm.callbacks.Encode(callback.New(action, args...))
+}
+
+// button returns inline button with signed callback data.
+func (bm *BotManager) button(text string, action callback.Action, args ...int) models.InlineKeyboardButton {
+	return models.InlineKeyboardButton{Text: text, CallbackData: bm.callbackData(action, args...)}
+}
+
+// periodicityRows returns periodicity choice buttons, one per row.
+func (bm *BotManager) periodicityRows(tr *i18n.Localizer, action callback.Action, eventID int) [][]models.InlineKeyboardButton {
+	rows := make([][]models.InlineKeyboardButton, 0, len(periodicityChoices))
+	for i, c := range periodicityChoices {
+		rows = append(rows, []models.InlineKeyboardButton{bm.button(tr.T(c.Key), action, eventID, i)})
+	}
+
+	return rows
+}
+
+// periodicityArg returns periodicity from callback argument.
+func periodicityArg(i int) (string, bool) {
+	if i < 0 || i >= len(periodicityChoices) {
+		return "", false
+	}
+
+	return periodicityChoices[i].Periodicity, true
+}
+
+// enumArg returns list item by callback argument.
+func enumArg(list []string, i int) (string, bool) {
+	if i < 0 || i >= len(list) {
+		return "", false
+	}
+
+	return list[i], true
+}
@@ -0,0 +1,33 @@
+package event_reminder_bot
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"event-reminder-bot/pkg/callback"
+)
+
+func TestCallbackActions(t *testing.T) {
+	c := NewCallbackCodec("secret")
+
+	for _, a := range callbackActions {
+		args := make([]int, a.Args)
+		for i := range args {
+			args[i] = math.MaxInt32
+		}
+
+		s := c.Encode(callback.New(a.Action, args...))
+		if len(s) > callback.MaxLength {
+			t.Errorf("%s: payload length %d > %d", a.Name, len(s), callback.MaxLength)
+		}
+
+		d, err := c.Decode(s)
+		if err != nil {
+			t.Fatalf("%s: %v", a.Name, err)
+		}
+		if d.Action != a.Action || !slices.Equal(d.Args, args) {
+			t.Errorf("%s: got %v", a.Name, d)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+var (
+	ErrInvalidRef      = errors.New("invalid event reference")
+	ErrInvalidDuration = errors.New("invalid duration")
+
+	durationPartRe = regexp.MustCompile(`^(\d+)(\p{L}+)`)
+
+	durationUnits = map[string]time.Duration{
+		"m": time.Minute, "min": time.Minute, "м": time.Minute, "мин": time.Minute,
+		"h": time.Hour, "ч": time.Hour,
+		"d": 24 * time.Hour, "д": 24 * time.Hour, "дн": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour, "н": 7 * 24 * time.Hour, "нед": 7 * 24 * time.Hour,
+	}
+)
+
+// EventByRef returns user event by its number in /list ("3") or by its ID ("id123").
+func (bm *BotManager) EventByRef(ctx context.Context, chatID int64, ref string) (*db.Event, error) {
+	ref = strings.ToLower(strings.TrimSpace(ref))
+
+	if idStr, ok := strings.CutPrefix(ref, "id"); ok {
+		eventID, err := strconv.Atoi(strings.TrimPrefix(idStr, ":"))
+		if err != nil || eventID < 1 {
+			return nil, ErrInvalidRef
+		}
+
+		return bm.UserEvent(ctx, chatID, eventID)
+	}
+
+	index, err := strconv.Atoi(ref)
+	if err != nil || index < 1 {
+		return nil, ErrInvalidRef
+	}
+
+	// the same search and sort as in /list, one event per page
+	search := &db.EventSearch{
+		UserTgID: &chatID,
+	}
+	events, err := bm.EventsRepo.EventsByFilters(ctx, search, db.NewPager(index, 1), bm.EventsRepo.DefaultEventSort())
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return &events[0], nil
+}
+
+// ParseDuration parses natural durations like "1h", "30m", "1h30m", "2d", "1w" or "1ч 30мин".
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.Join(strings.Fields(s), ""))
+	if s == "" {
+		return 0, ErrInvalidDuration
+	}
+
+	var total time.Duration
+	for s != "" {
+		m := durationPartRe.FindStringSubmatch(s)
+		if m == nil {
+			return 0, ErrInvalidDuration
+		}
+
+		n, err := strconv.Atoi(m[1])
+		if err != nil {
+			return 0, ErrInvalidDuration
+		}
+
+		unit, ok := durationUnits[m[2]]
+		if !ok {
+			return 0, ErrInvalidDuration
+		}
+
+		total += time.Duration(n) * unit
+		s = s[len(m[0]):]
+	}
+
+	if total <= 0 {
+		return 0, ErrInvalidDuration
+	}
+
+	return total, nil
+}
+
+// FormatDuration returns duration in the largest whole unit: days, hours or minutes.
+func FormatDuration(tr *i18n.Localizer, d time.Duration) string {
+	m := int(d / time.Minute)
+	switch {
+	case m >= 24*60 && m%(24*60) == 0:
+		return tr.N("duration.days", m/(24*60))
+	case m >= 60 && m%60 == 0:
+		return tr.N("duration.hours", m/60)
+	default:
+		return tr.N("duration.minutes", m)
+	}
+}
+
+// parseSnoozeTime returns new event time from duration ("1h") or from date ("YYYY-MM-DD HH:MM").
+func (bm *BotManager) parseSnoozeTime(ctx context.Context, chatID int64, s string) (time.Time, error) {
+	if d, err := ParseDuration(s); err == nil {
+		return time.Now().Add(d), nil
+	}
+
+	t, err := time.ParseInLocation("2006-01-02 15:04", strings.TrimSpace(s), bm.UserLocation(ctx, chatID))
+	if err != nil {
+		return time.Time{}, ErrInvalidDuration
+	}
+
+	return t, nil
+}
+
+func (bm *BotManager) SnoozeHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	tr := bm.Tr(ctx, chatID)
+	args := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/snooze"))
+
+	ref, when, ok := strings.Cut(args, " ")
+	if !ok || strings.TrimSpace(when) == "" {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("snooze.usage"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	event, err := bm.EventByRef(ctx, chatID, ref)
+	if err != nil {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   refErrorText(tr, err, ref),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	newTime, err := bm.parseSnoozeTime(ctx, chatID, when)
+	if err != nil {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("snooze.invalid_time"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	err = bm.SnoozeEvent(ctx, event.ID, chatID, newTime)
+	if err != nil {
+		text := tr.T("common.error", err)
+		if errors.Is(err, ErrPastDate) {
+			text = tr.T("snooze.past_date")
+		}
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		bm.OnError(err)
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr.T("snooze.done", event.Message, tr.DateTime(newTime)),
+	})
+	bm.OnError(err)
+}
+
+// refErrorText returns user message for EventByRef error.
+func refErrorText(tr *i18n.Localizer, err error, ref string) string {
+	switch {
+	case errors.Is(err, ErrInvalidRef):
+		return tr.T("ref.invalid")
+	case errors.Is(err, ErrNotFound):
+		return tr.T("ref.not_found", ref)
+	case errors.Is(err, ErrAccessDenied):
+		return tr.T("event.access_denied")
+	default:
+		return tr.T("events.load_error")
+	}
+}
@@ -0,0 +1,178 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// ConversationState is a step of multi-step dialog waiting for text input from user.
+type ConversationState string
+
+const (
+	StateSnoozeDate ConversationState = "snooze_date"
+	StateEventDate  ConversationState = "event_date"
+	StateEventTitle ConversationState = "event_title"
+	StateEventNotes ConversationState = "event_notes"
+	StateResumeDate ConversationState = "resume_date"
+	StateTimeZone   ConversationState = "time_zone"
+	StateAddTitle   ConversationState = "add_title"
+
+	// conversationTTL is how long bot waits for user input.
+	conversationTTL = 10 * time.Minute
+	// sessionTTL is how long /list selection and /search query are kept after last use.
+	sessionTTL = time.Hour
+)
+
+// Conversation is an active dialog step of the user.
+type Conversation struct {
+	State     ConversationState
+	Params    db.ConversationParams
+	ExpiresAt time.Time
+}
+
+// Expired returns true if input was not received in time.
+func (c Conversation) Expired() bool {
+	return !c.ExpiresAt.After(time.Now())
+}
+
+// StartConversation sets user dialog state replacing any previous one.
+func (bm *BotManager) StartConversation(ctx context.Context, chatID int64, state ConversationState, params db.ConversationParams) error {
+	err := bm.EventsRepo.SetConversation(ctx, &db.Conversation{
+		UserTgID:  chatID,
+		State:     string(state),
+		Params:    &params,
+		ExpiresAt: time.Now().Add(conversationTTL),
+	})
+	if err != nil {
+		return fmt.Errorf("ошибка сохранения состояния диалога: %w", err)
+	}
+
+	return nil
+}
+
+// TakeConversation removes user dialog state and returns it or nil if user is not in a dialog.
+func (bm *BotManager) TakeConversation(ctx context.Context, chatID int64) (*Conversation, error) {
+	c, err := bm.EventsRepo.PopConversation(ctx, chatID)
+	if err != nil || c == nil {
+		return nil, err
+	}
+
+	return newConversation(c), nil
+}
+
+// ExpireConversations removes expired dialog states and notifies users.
+func (bm *BotManager) ExpireConversations(ctx context.Context) error {
+	bm.expirePending(time.Now())
+
+	conversations, err := bm.EventsRepo.PopExpiredConversations(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка удаления устаревших диалогов: %w", err)
+	}
+
+	for _, c := range conversations {
+		bm.SendConversationTimeout(ctx, c.UserTgID)
+	}
+
+	return nil
+}
+
+// expirePending forgets in-memory state abandoned by users.
+func (bm *BotManager) expirePending(now time.Time) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	for chatID, sel := range bm.selections {
+		if now.Sub(sel.UpdatedAt) > sessionTTL {
+			delete(bm.selections, chatID)
+		}
+	}
+	for chatID, q := range bm.searchQueries {
+		if now.Sub(q.UpdatedAt) > sessionTTL {
+			delete(bm.searchQueries, chatID)
+		}
+	}
+	for chatID, plan := range bm.imports {
+		if now.Sub(plan.CreatedAt) > importTTL {
+			delete(bm.imports, chatID)
+		}
+	}
+	for chatID, plan := range bm.restores {
+		if now.Sub(plan.CreatedAt) > importTTL {
+			delete(bm.restores, chatID)
+		}
+	}
+	for chatID, b := range bm.broadcasts {
+		if now.Sub(b.CreatedAt) > broadcastTTL {
+			delete(bm.broadcasts, chatID)
+		}
+	}
+}
+
+// SendConversationTimeout notifies user that bot is not waiting for input anymore.
+func (bm *BotManager) SendConversationTimeout(ctx context.Context, chatID int64) {
+	_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   bm.Tr(ctx, chatID).T("conversation.timeout"),
+	})
+	bm.OnError(err)
+}
+
+// startConversation starts dialog and asks user for input.
+func (bm *BotManager) startConversation(ctx context.Context, b *bot.Bot, chatID int64, messageID int, state ConversationState, params db.ConversationParams, prompt string) {
+	tr := bm.Tr(ctx, chatID)
+	if err := bm.StartConversation(ctx, chatID, state, params); err != nil {
+		bm.Errorf("%v", err)
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("common.retry_error"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      prompt + "\n\n" + tr.T("conversation.cancel_hint"),
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) CancelHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+
+	c, err := bm.TakeConversation(ctx, chatID)
+	if err != nil {
+		bm.Errorf("%v", err)
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	text := tr.T("conversation.cancelled")
+	if c == nil {
+		text = tr.T("conversation.nothing")
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	bm.OnError(err)
+}
+
+func newConversation(c *db.Conversation) *Conversation {
+	res := &Conversation{
+		State:     ConversationState(c.State),
+		ExpiresAt: c.ExpiresAt,
+	}
+	if c.Params != nil {
+		res.Params = *c.Params
+	}
+
+	return res
+}
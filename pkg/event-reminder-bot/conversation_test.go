@@ -0,0 +1,66 @@
+package event_reminder_bot
+
+import (
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+
+	"github.com/vmkteam/embedlog"
+)
+
+func TestExpirePending(t *testing.T) {
+	bm := NewBotManager(nil, db.EventsRepo{}, "test", embedlog.Logger{})
+	now := time.Now()
+
+	tests := []struct {
+		chatID int64
+		age    time.Duration
+		kept   bool
+	}{
+		{1, time.Minute, true},
+		{2, sessionTTL - time.Second, true},
+		{3, sessionTTL + time.Second, false},
+	}
+
+	for _, tt := range tests {
+		bm.selections[tt.chatID] = &selection{Page: 1, UpdatedAt: now.Add(-tt.age)}
+		bm.searchQueries[tt.chatID] = &searchQuery{Query: "gym", UpdatedAt: now.Add(-tt.age)}
+	}
+	bm.imports[1] = &importPlan{CreatedAt: now.Add(-importTTL + time.Second)}
+	bm.imports[2] = &importPlan{CreatedAt: now.Add(-importTTL - time.Second)}
+	bm.restores[1] = &restorePlan{CreatedAt: now.Add(-importTTL + time.Second)}
+	bm.restores[2] = &restorePlan{CreatedAt: now.Add(-importTTL - time.Second)}
+	bm.broadcasts[1] = &broadcast{CreatedAt: now.Add(-broadcastTTL + time.Second)}
+	bm.broadcasts[2] = &broadcast{CreatedAt: now.Add(-broadcastTTL - time.Second)}
+
+	bm.expirePending(now)
+
+	for _, tt := range tests {
+		if _, ok := bm.selections[tt.chatID]; ok != tt.kept {
+			t.Errorf("selection %d: kept %v, want %v", tt.chatID, ok, tt.kept)
+		}
+		if _, ok := bm.searchQueries[tt.chatID]; ok != tt.kept {
+			t.Errorf("search query %d: kept %v, want %v", tt.chatID, ok, tt.kept)
+		}
+	}
+
+	if _, ok := bm.imports[1]; !ok {
+		t.Error("pending import removed")
+	}
+	if _, ok := bm.imports[2]; ok {
+		t.Error("expired import kept")
+	}
+	if _, ok := bm.restores[1]; !ok {
+		t.Error("pending restore removed")
+	}
+	if _, ok := bm.restores[2]; ok {
+		t.Error("expired restore kept")
+	}
+	if _, ok := bm.broadcasts[1]; !ok {
+		t.Error("pending broadcast removed")
+	}
+	if _, ok := bm.broadcasts[2]; ok {
+		t.Error("expired broadcast kept")
+	}
+}
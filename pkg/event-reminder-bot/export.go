@@ -0,0 +1,132 @@
+package event_reminder_bot
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/ical"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// ExportICS is /export format of iCalendar file.
+	ExportICS = "ics"
+
+	// ICalPath is URL path of calendar subscription feeds on HTTP server, feed URL is ICalPath/<token>.ics.
+	ICalPath = "/ical"
+
+	// icalTokenBytes is random bytes count of feed token.
+	icalTokenBytes = 24
+)
+
+func (bm *BotManager) ExportHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	format := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/export")))
+	tr := bm.Tr(ctx, chatID)
+
+	var (
+		data              []byte
+		err               error
+		filename, caption string
+	)
+	switch format {
+	case "", ExportICS:
+		data, err = bm.ExportICS(ctx, chatID)
+		filename, caption = "events.ics", tr.T("export.ics_caption")
+	case ExportJSON, ExportCSV:
+		data, err = bm.ExportBackup(ctx, chatID, format)
+		filename, caption = "events-backup."+format, tr.T("export.backup_caption")
+	default:
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("export.usage"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	if err != nil {
+		bm.Errorf("Ошибка экспорта событий пользователя %d: %v", chatID, err)
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("export.error"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	_, err = b.SendDocument(ctx, &bot.SendDocumentParams{
+		ChatID:   chatID,
+		Document: &models.InputFileUpload{Filename: filename, Data: bytes.NewReader(data)},
+		Caption:  caption,
+	})
+	bm.OnError(err)
+}
+
+// ExportICS returns enabled user events as iCalendar file.
+func (bm *BotManager) ExportICS(ctx context.Context, chatID int64) ([]byte, error) {
+	statusID := db.StatusEnabled
+	events, err := bm.EventsRepo.EventsByFilters(ctx, &db.EventSearch{UserTgID: &chatID, StatusID: &statusID}, db.PagerNoLimit,
+		db.WithSort(db.SortField{Column: db.Columns.Event.SendAt, Direction: db.SortAsc}))
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	err = ical.Encode(&buf, ical.Calendar{
+		Name:     bm.Tr(ctx, chatID).T("export.calendar_name"),
+		Location: bm.UserLocation(ctx, chatID),
+		Events:   model.NewEvents(events),
+	}, time.Now())
+
+	return buf.Bytes(), err
+}
+
+// ICalFeed returns iCalendar file of the user with feed token. ErrNotFound is returned for unknown or rotated tokens.
+func (bm *BotManager) ICalFeed(ctx context.Context, token string) ([]byte, error) {
+	if token == "" {
+		return nil, ErrNotFound
+	}
+
+	user, err := bm.EventsRepo.OneUser(ctx, &db.UserSearch{IcalToken: &token})
+	if err != nil {
+		return nil, err
+	} else if user == nil {
+		return nil, ErrNotFound
+	}
+
+	return bm.ExportICS(ctx, user.UserTgID)
+}
+
+// RotateICalToken sets new feed token, so the previous feed URL stops working.
+func (bm *BotManager) RotateICalToken(ctx context.Context, chatID int64) error {
+	b := make([]byte, icalTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return bm.UpdateUser(ctx, &db.User{UserTgID: chatID, IcalToken: &token}, db.Columns.User.IcalToken)
+}
+
+// DisableICalFeed removes feed token.
+func (bm *BotManager) DisableICalFeed(ctx context.Context, chatID int64) error {
+	return bm.UpdateUser(ctx, &db.User{UserTgID: chatID}, db.Columns.User.IcalToken)
+}
+
+// ICalURL returns feed URL of the user or empty string if the feed is off or public URL is not configured.
+func (bm *BotManager) ICalURL(u *model.User) string {
+	if u.IcalToken == "" || bm.PublicURL == "" {
+		return ""
+	}
+
+	return strings.TrimSuffix(bm.PublicURL, "/") + ICalPath + "/" + u.IcalToken + ".ics"
+}
@@ -9,9 +9,13 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
+	"event-reminder-bot/pkg/callback"
 	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
 	"event-reminder-bot/pkg/model"
 
 	"github.com/go-telegram/bot"
@@ -21,82 +25,25 @@ import (
 
 const MaxPeriodic = 100
 
+const listPageSize = 10
+
 const (
-	Monday            = "1"
-	Tuesday           = "2"
-	Wednesday         = "3"
-	Thursday          = "4"
-	Friday            = "5"
-	Saturday          = "6"
-	Sunday            = "7"
-	EventDetailPrefix = "event_detail_"
-	eventEditPrefix   = "event_edit_"
-	eventDeletePrefix = "event_delete_"
-	eventBackToList   = "back_to_list"
-
-	editDatePrefix        = "edit_date_"
-	editDescPrefix        = "edit_desc_"
-	editPeriodicityPrefix = "edit_periodicity_"
-
-	postponeHour   = "postpone_hour_"
-	postponeDay    = "postpone_day_"
-	postponeWeek   = "postpone_week_"
-	postponeCustom = "postpone_custom_"
+	// MaxTitleLength is the maximum event title length in runes.
+	MaxTitleLength = 200
+	// MaxNotesLength is the maximum event notes length in runes.
+	MaxNotesLength = 3000
 )
 
-type WeekDay struct {
-	ID   string
-	Name string
-}
-
-var weekDays = []WeekDay{
-	{ID: Monday, Name: "Понедельник"},
-	{ID: Tuesday, Name: "Вторник"},
-	{ID: Wednesday, Name: "Среда"},
-	{ID: Thursday, Name: "Четверг"},
-	{ID: Friday, Name: "Пятница"},
-	{ID: Saturday, Name: "Суббота"},
-	{ID: Sunday, Name: "Воскресенье"},
-}
+// weekdays are day numbers in Monday=1..Sunday=7 notation used by Event.Weekdays.
+var weekdays = []int{1, 2, 3, 4, 5, 6, 7}
 
-func DefaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+func (bm *BotManager) DefaultHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 	if update.Message == nil {
 		return
 	}
 	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: update.Message.Chat.ID,
-		Text:   "Нет такой команды, используйте /help чтобы посмотреть доступные команды команд",
-	})
-	if err != nil {
-		return
-	}
-}
-
-func StartHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
-	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: update.Message.Chat.ID,
-		Text: "Добрый день, данный бот предназначен для простого планирования.\n" +
-			"Список умений:\n" +
-			"Добавить событие: /add <YYYY-MM-DD HH:MM>\n <Текст>\n" +
-			"Список событий: /list \n" +
-			"Удалить событие: /delete id\n" +
-			"Перенести событие: /snooze <id> <YYYY-MM-DD HH:MM>\n" +
-			"Список команд: /help",
-	})
-	if err != nil {
-		return
-	}
-}
-
-func HelpHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
-	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: update.Message.Chat.ID,
-		Text: "Список умений:\n" +
-			"Добавить событие: /add <YYYY-MM-DD HH:MM>\n <Текст>\n" +
-			"Список событий: /list\n" +
-			"Удалить событие: /delete id\n" +
-			"Перенести событие: /snooze <id> <YYYY-MM-DD HH:MM>\n" +
-			"Список команд: /help",
+		Text:   bm.Tr(ctx, update.Message.Chat.ID).T("command.unknown"),
 	})
 	if err != nil {
 		return
@@ -104,80 +51,54 @@ func HelpHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
 }
 
 func (bm *BotManager) DeleteHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	tr := bm.Tr(ctx, chatID)
 	args := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/delete"))
 	if args == "" {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "❗ Укажите ID события, например: /delete 123",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	index, err := strconv.Atoi(args)
-	if err != nil || index < 1 {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "❗ Номер события должен быть положительным числом",
+			ChatID: chatID,
+			Text:   tr.T("delete.usage"),
 		})
 		bm.OnError(err)
 		return
 	}
 
-	events, err := bm.GetUserEvents(ctx, update.Message.Chat.ID)
+	event, err := bm.EventByRef(ctx, chatID, args)
 	if err != nil {
-		bm.Errorf("Ошибка загрузки событий: %v", err)
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "❌ Ошибка при загрузке событий",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if len(events) == 0 {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "🔍 У вас нет событий для удаления",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if index > len(events) {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   fmt.Sprintf("❗ Нет события с номером %d", index),
+			ChatID: chatID,
+			Text:   refErrorText(tr, err, args),
 		})
 		bm.OnError(err)
 		return
 	}
 
-	eventToDelete := events[index-1]
-
-	err = bm.DeleteEventByID(ctx, eventToDelete.ID)
+	err = bm.trashEvent(ctx, event.ID)
 	if err != nil {
 		bm.Errorf("Ошибка удаления события: %v", err)
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "❌ Ошибка при удалении события",
+			ChatID: chatID,
+			Text:   tr.T("event.delete_error"),
 		})
 		bm.OnError(err)
 		return
 	}
 
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: update.Message.Chat.ID,
-		Text:   "✅ Событие удалено!",
+		ChatID:      chatID,
+		Text:        tr.T("event.trashed", event.Message),
+		ReplyMarkup: bm.UndoKeyboard(tr, event.ID),
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) GetUserEventsPaged(ctx context.Context, chatID int64, page int, pageSize int) ([]model.Event, int, error) {
-	statusId := db.StatusEnabled
+// GetUserEventsPaged returns active and paused user events.
+func (bm *BotManager) GetUserEventsPaged(ctx context.Context, chatID int64, page int, pageSize int, tagID int) ([]model.Event, int, error) {
 	search := &db.EventSearch{
 		UserTgID: &chatID,
-		StatusID: &statusId,
+	}
+	if tagID != 0 {
+		search.WithTagID(tagID)
 	}
 
 	// Получаем общее количество событий
@@ -197,69 +118,116 @@ func (bm *BotManager) GetUserEventsPaged(ctx context.Context, chatID int64, page
 }
 
 func (bm *BotManager) ListHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	tr := bm.Tr(ctx, chatID)
 	page := 1
-	pageSize := 10
 
-	parts := strings.Fields(update.Message.Text)
-	if len(parts) > 1 {
-		if p, err := strconv.Atoi(parts[1]); err == nil && p > 0 {
-			page = p
-		}
+	tagID, rest, err := bm.tagFromArgs(ctx, chatID, strings.TrimPrefix(update.Message.Text, "/list"))
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tagErrorText(tr, err),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	if p, err := strconv.Atoi(rest); err == nil && p > 0 {
+		page = p
+	}
+
+	text, keyboard, err := bm.listPage(ctx, chatID, page, tagID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки событий: %v", err)
+		text, keyboard = tr.T("events.load_error"), nil
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.SendMessage(ctx, params)
+	bm.OnError(err)
+}
+
+// HandleListPage shows list page filtered by tag.
+func (bm *BotManager) HandleListPage(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	page, tagID := data.Arg(0), data.Arg(1)
+	if page < 1 {
+		return
 	}
 
-	events, total, err := bm.GetUserEventsPaged(ctx, update.Message.Chat.ID, page, pageSize)
+	bm.editListPage(ctx, b, chatID, messageID, page, tagID)
+}
+
+func (bm *BotManager) editListPage(ctx context.Context, b *bot.Bot, chatID int64, messageID, page, tagID int) {
+	text, keyboard, err := bm.listPage(ctx, chatID, page, tagID)
 	if err != nil {
 		bm.Errorf("Ошибка загрузки событий: %v", err)
 		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "❌ Ошибка при загрузке событий",
+			ChatID: chatID,
+			Text:   bm.Tr(ctx, chatID).T("events.load_error"),
 		})
 		bm.OnError(err)
 		return
 	}
 
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.EditMessageText(ctx, params)
+	bm.OnError(err)
+}
+
+// listPage returns text and keyboard for one /list page, optionally filtered by tag.
+func (bm *BotManager) listPage(ctx context.Context, chatID int64, page, tagID int) (string, *models.InlineKeyboardMarkup, error) {
+	events, total, err := bm.GetUserEventsPaged(ctx, chatID, page, listPageSize, tagID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	tags, err := bm.EventsRepo.UserTags(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки тегов: %v", err)
+	}
+
 	if len(events) == 0 {
-		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: update.Message.Chat.ID,
-			Text:   "🔍 Нет событий",
-		})
-		return
+		if tagID == 0 {
+			return tr.T("events.empty"), nil, nil
+		}
+		return tr.T("events.empty_tag"), &models.InlineKeyboardMarkup{InlineKeyboard: bm.tagsKeyboard(tr, tags, tagID, ActionListPage, 1)}, nil
 	}
 
-	start := (page - 1) * pageSize
+	start := (page - 1) * listPageSize
 
-	periodicCount, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, update.Message.Chat.ID)
+	periodicCount, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
 	if err != nil {
 		bm.Errorf("Ошибка подсчета периодических событий: %v", err)
 		periodicCount = 0
 	}
 
 	var msg strings.Builder
-	msg.WriteString("📅 Список событий:\n\n")
-	msg.WriteString(fmt.Sprintf("📊 Периодических уведомлений: %d/%d\n\n", periodicCount, MaxPeriodic))
+	msg.WriteString(tr.T("list.title") + "\n\n")
+	msg.WriteString(tr.T("list.periodic", periodicCount, MaxPeriodic) + "\n\n")
 
 	for i, e := range events {
-		msg.WriteString(fmt.Sprintf("%d. %s — ", start+i+1, e.Text))
-		msg.WriteString(fmt.Sprintf("%s\n", e.DateTime.Format("2006-01-02 15:04")))
-
-		if e.Periodicity != nil {
-			switch *e.Periodicity {
-			case "hour":
-				msg.WriteString("🔄 Каждый час\n")
-			case "day":
-				msg.WriteString("🔄 Ежедневно\n")
-			case "week":
-				msg.WriteString("🔄 Еженедельно\n")
-			case "weekdays":
-				var days []string
-				for _, day := range e.Weekdays {
-					days = append(days, DayName(day))
-				}
-				msg.WriteString(fmt.Sprintf("🔄 По дням: %s\n", strings.Join(days, ", ")))
-			}
-		} else {
-			msg.WriteString("⏹️ Без повтора\n")
+		if e.Paused {
+			msg.WriteString("⏸ ")
 		}
+		msg.WriteString(fmt.Sprintf("%d. %s — ", start+i+1, e.Text))
+		msg.WriteString(fmt.Sprintf("%s\n", tr.DateTime(e.DateTime)))
+		msg.WriteString(periodicityLine(tr, e.Periodicity, e.Weekdays))
 	}
 
 	var buttons [][]models.InlineKeyboardButton
@@ -267,10 +235,7 @@ func (bm *BotManager) ListHandler(ctx context.Context, b *bot.Bot, update *model
 	var row []models.InlineKeyboardButton
 	for i := range events {
 		eventNum := start + i + 1
-		row = append(row, models.InlineKeyboardButton{
-			Text:         fmt.Sprintf("%d", eventNum),
-			CallbackData: fmt.Sprintf("%s%d", EventDetailPrefix, events[i].ID),
-		})
+		row = append(row, bm.button(fmt.Sprintf("%d", eventNum), ActionEventDetail, events[i].ID))
 
 		if len(row) == 5 {
 			buttons = append(buttons, row)
@@ -283,133 +248,100 @@ func (bm *BotManager) ListHandler(ctx context.Context, b *bot.Bot, update *model
 
 	var navRow []models.InlineKeyboardButton
 	if page > 1 {
-		navRow = append(navRow, models.InlineKeyboardButton{
-			Text:         "⬅️ Назад",
-			CallbackData: fmt.Sprintf("page_%d", page-1),
-		})
+		navRow = append(navRow, bm.button(tr.T("button.prev"), ActionListPage, page-1, tagID))
 	}
-	if start+pageSize < total {
-		navRow = append(navRow, models.InlineKeyboardButton{
-			Text:         "➡️ Далее",
-			CallbackData: fmt.Sprintf("page_%d", page+1),
-		})
+	if start+listPageSize < total {
+		navRow = append(navRow, bm.button(tr.T("button.next"), ActionListPage, page+1, tagID))
 	}
 	if len(navRow) > 0 {
 		buttons = append(buttons, navRow)
 	}
 
-	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: update.Message.Chat.ID,
-		Text:   msg.String(),
-		ReplyMarkup: &models.InlineKeyboardMarkup{
-			InlineKeyboard: buttons,
-		},
+	buttons = append(buttons, []models.InlineKeyboardButton{
+		bm.button(tr.T("list.select"), ActionSelectStart, page, tagID),
 	})
-	bm.OnError(err)
-}
+	buttons = append(buttons, bm.tagsKeyboard(tr, tags, tagID, ActionListPage, 1)...)
 
-func DayName(day int) string {
-	switch day {
-	case 1:
-		return "Пн"
-	case 2:
-		return "Вт"
-	case 3:
-		return "Ср"
-	case 4:
-		return "Чт"
-	case 5:
-		return "Пт"
-	case 6:
-		return "Сб"
-	case 7:
-		return "Вс"
-	default:
-		return strconv.Itoa(day)
-	}
+	return msg.String(), &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
 }
 
-func (bm *BotManager) SendDailyEvents(ctx context.Context) {
-	users, err := bm.EventsRepo.AllUsersWithEventsToday(ctx)
-	if err != nil {
-		bm.Errorf("Ошибка получения пользователей: %v", err)
-		return
+// periodicityLine returns human-readable periodicity line for event lists.
+func periodicityLine(tr *i18n.Localizer, periodicity *string, weekdays []int) string {
+	if text := periodicityText(tr, periodicity, weekdays); text != "" {
+		return text + "\n"
 	}
 
-	for _, userID := range users {
-		events, err := bm.GetUserEvents(ctx, userID)
-		if err != nil {
-			bm.Errorf("Ошибка загрузки событий пользователя %d: %v", userID, err)
-			continue
-		}
-
-		var todayEvents []model.Event
-		today := time.Now().In(time.FixedZone("MSK", 3*3600)).Format("2006-01-02")
+	return ""
+}
 
-		for _, e := range events {
-			if e.DateTime.Format("2006-01-02") == today {
-				todayEvents = append(todayEvents, e)
-			}
-		}
+// periodicityText returns human-readable periodicity.
+func periodicityText(tr *i18n.Localizer, periodicity *string, weekdays []int) string {
+	if periodicity == nil {
+		return tr.T("periodicity.none")
+	}
 
-		if len(todayEvents) == 0 {
-			continue
-		}
+	switch *periodicity {
+	case db.PeriodicityHour:
+		return tr.T("periodicity.hour")
+	case db.PeriodicityDay:
+		return tr.T("periodicity.day")
+	case db.PeriodicityWeek:
+		return tr.T("periodicity.week")
+	case db.PeriodicityWeekdays:
+		return tr.T("periodicity.weekdays", weekdayNames(tr, weekdays))
+	}
 
-		var msg strings.Builder
-		msg.WriteString("📅 События на сегодня:\n\n")
-
-		for i, e := range todayEvents {
-			msg.WriteString(fmt.Sprintf("%d. %s — %s\n", i+1, e.Text, e.DateTime.Format("15:04")))
-
-			if e.Periodicity != nil {
-				switch *e.Periodicity {
-				case "hour":
-					msg.WriteString("🔄 Каждый час\n")
-				case "day":
-					msg.WriteString("🔄 Ежедневно\n")
-				case "week":
-					msg.WriteString("🔄 Еженедельно\n")
-				case "weekdays":
-					var days []string
-					for _, d := range e.Weekdays {
-						days = append(days, DayName(d))
-					}
-					msg.WriteString(fmt.Sprintf("🔄 По дням: %s\n", strings.Join(days, ", ")))
-				}
-			} else {
-				msg.WriteString("⏹️ Без повтора\n")
-			}
-		}
+	return ""
+}
 
-		_, err = bm.b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: userID,
-			Text:   msg.String(),
-		})
-		bm.OnError(err)
+// weekdayNames returns comma separated short weekday names.
+func weekdayNames(tr *i18n.Localizer, days []int) string {
+	names := make([]string, 0, len(days))
+	for _, day := range days {
+		names = append(names, tr.Weekday(day))
 	}
-}
 
-type EditState struct {
-	EventID    int
-	WaitingFor string
+	return strings.Join(names, ", ")
 }
 
 type BotManager struct {
 	embedlog.Logger
 	b          *bot.Bot
 	EventsRepo db.EventsRepo
-	EditStates map[int64]*EditState
 	Mu         sync.RWMutex
+
+	// TrashRetention is how long deleted events are kept in trash.
+	TrashRetention time.Duration
+	// PublicURL is the public URL of HTTP server used in calendar feed links, feeds are off if it is empty.
+	PublicURL string
+	// AdminIDs are Telegram IDs of users allowed to use admin commands.
+	AdminIDs []int64
+
+	callbacks     *callback.Codec
+	searchQueries map[int64]*searchQuery
+	selections    map[int64]*selection
+	imports       map[int64]*importPlan
+	restores      map[int64]*restorePlan
+	users         map[int64]*model.User
+	broadcasts    map[int64]*broadcast
+	broadcasting  atomic.Bool
 }
 
-func NewBotManager(b *bot.Bot, eventsRepo db.EventsRepo, logger embedlog.Logger) *BotManager {
+// NewBotManager returns bot manager. Callback secret signs inline button payloads and must not change between restarts.
+func NewBotManager(b *bot.Bot, eventsRepo db.EventsRepo, callbackSecret string, logger embedlog.Logger) *BotManager {
 	return &BotManager{
 		b:          b,
 		EventsRepo: eventsRepo,
 		Logger:     logger,
-		EditStates: make(map[int64]*EditState),
 		Mu:         sync.RWMutex{},
+
+		callbacks:     NewCallbackCodec(callbackSecret),
+		searchQueries: make(map[int64]*searchQuery),
+		selections:    make(map[int64]*selection),
+		imports:       make(map[int64]*importPlan),
+		restores:      make(map[int64]*restorePlan),
+		users:         make(map[int64]*model.User),
+		broadcasts:    make(map[int64]*broadcast),
 	}
 }
 
@@ -421,70 +353,111 @@ func (bm *BotManager) OnError(err error) {
 }
 
 func (bm *BotManager) SendReminder(ctx context.Context, chatID int64, text string, eventID int) {
+	tr := bm.Tr(ctx, chatID)
 	keyboard := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: "⏱️ 5 мин", CallbackData: fmt.Sprintf("snooze_%d_5", eventID)},
-				{Text: "⏱️ 10 мин", CallbackData: fmt.Sprintf("snooze_%d_10", eventID)},
+		InlineKeyboard: append(bm.snoozeRows(ctx, chatID, ActionSnooze, eventID),
+			[]models.InlineKeyboardButton{
+				bm.button(tr.T("reminder.snooze_custom"), ActionSnoozeCustom, eventID),
 			},
-			{
-				{Text: "📅 Выбрать время", CallbackData: fmt.Sprintf("snooze_custom_%d", eventID)},
+			[]models.InlineKeyboardButton{
+				bm.button(tr.T("reminder.done"), ActionDone, eventID),
 			},
-			{
-				{Text: "✅ Выполнено", CallbackData: fmt.Sprintf("done_%d", eventID)},
-			},
-		},
+		),
 	}
 
 	_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        "🔔 Напоминание: " + text,
+		Text:        tr.T("reminder.text", text),
 		ReplyMarkup: keyboard,
 	})
-	if err != nil {
-		return
-	}
+	bm.recordSend(ctx, err)
 }
 
-func (bm *BotManager) SendReminderPeriodicity(ctx context.Context, chatID int64, text string) {
+// SendReminderPeriodicity sends occurrence of periodic event with occurrence actions and stop series button.
+func (bm *BotManager) SendReminderPeriodicity(ctx context.Context, chatID int64, text string, eventID int) {
+	tr := bm.Tr(ctx, chatID)
+	keyboard := &models.InlineKeyboardMarkup{
+		InlineKeyboard: append(bm.snoozeRows(ctx, chatID, ActionOccurrenceSnooze, eventID),
+			[]models.InlineKeyboardButton{
+				bm.button(tr.T("reminder.done"), ActionOccurrenceDone, eventID),
+				bm.button(tr.T("reminder.skip"), ActionOccurrenceSkip, eventID),
+			},
+			[]models.InlineKeyboardButton{
+				bm.button(tr.T("reminder.stop_series"), ActionStopSeries, eventID),
+			},
+		),
+	}
+
 	_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: chatID,
-		Text:   "🔔 Напоминание: " + text,
+		ChatID:      chatID,
+		Text:        tr.T("reminder.text", text),
+		ReplyMarkup: keyboard,
 	})
-	bm.OnError(err)
+	bm.recordSend(ctx, err)
 }
 
 func (bm *BotManager) AddEvent(ctx context.Context, chatId int64, parts []string) (*model.Event, error) {
 	datePart := parts[0]
 	timePart := parts[1]
-	text := parts[2]
+	title, notes := SplitTitleNotes(parts[2])
 
-	if len(text) > 200 {
-		return nil, fmt.Errorf("text_too_long")
+	dt, err := time.ParseInLocation("2006-01-02 15:04", datePart+" "+timePart, bm.UserLocation(ctx, chatId))
+	if err != nil {
+		return nil, fmt.Errorf("invalid_format")
 	}
 
-	loc, err := time.LoadLocation("Europe/Moscow")
+	addedEvent, err := bm.CreateEvent(ctx, chatId, title, notes, dt)
 	if err != nil {
-		bm.Errorf("Ошибка загрузки часового пояса: %v", err)
-		loc = time.Local
+		return nil, err
 	}
 
-	dt, err := time.ParseInLocation("2006-01-02 15:04", datePart+" "+timePart, loc)
+	err = bm.askForPeriodicity(ctx, chatId, addedEvent.ID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid_format")
+		bm.Errorf("Ошибка запроса периодичности: %v", err)
+	}
+
+	return model.NewEvent(addedEvent), nil
+}
+
+// CreateEvent validates and saves one-off event with tags from its text.
+func (bm *BotManager) CreateEvent(ctx context.Context, chatID int64, title, notes string, at time.Time) (*db.Event, error) {
+	return bm.CreatePeriodicEvent(ctx, chatID, title, notes, at, "", nil)
+}
+
+// CreatePeriodicEvent validates and saves event with tags from its text. Empty periodicity makes the event one-off,
+// weekdays are used only with weekdays periodicity.
+func (bm *BotManager) CreatePeriodicEvent(ctx context.Context, chatID int64, title, notes string, at time.Time, periodicity string, weekdays []int) (*db.Event, error) {
+	if title == "" {
+		return nil, fmt.Errorf("empty_title")
+	}
+	if utf8.RuneCountInString(title) > MaxTitleLength {
+		return nil, fmt.Errorf("text_too_long")
+	}
+	if utf8.RuneCountInString(notes) > MaxNotesLength {
+		return nil, fmt.Errorf("notes_too_long")
 	}
 
-	if dt.Before(time.Now()) {
+	if at.Before(time.Now()) {
 		return nil, fmt.Errorf("past_date")
 	}
 
+	days, err := bm.CheckEventPeriodicity(ctx, chatID, nil, periodicity, weekdays)
+	if err != nil {
+		return nil, err
+	}
+
 	event := &db.Event{
-		UserTgID:    chatId,
-		Message:     text,
-		SendAt:      dt,
-		StatusID:    db.StatusEnabled,
-		Weekdays:    []int{},
-		Periodicity: nil,
+		UserTgID: chatID,
+		Message:  title,
+		SendAt:   at,
+		StatusID: db.StatusEnabled,
+		Weekdays: days,
+	}
+	if periodicity != "" {
+		event.Periodicity = &periodicity
+	}
+	if notes != "" {
+		event.Notes = &notes
 	}
 
 	addedEvent, err := bm.EventsRepo.AddEvent(ctx, event)
@@ -492,167 +465,83 @@ func (bm *BotManager) AddEvent(ctx context.Context, chatId int64, parts []string
 		bm.Errorf("Ошибка сохранения события: %v", err)
 		return nil, err
 	}
+	bm.SyncTags(ctx, addedEvent)
 
-	err = bm.askForPeriodicity(ctx, chatId, addedEvent.ID)
-	if err != nil {
-		bm.Errorf("Ошибка запроса периодичности: %v", err)
-	}
+	return addedEvent, nil
+}
 
-	return model.NewEvent(addedEvent), nil
+// SplitTitleNotes splits event text into a title (the first line) and notes (everything after it).
+func SplitTitleNotes(text string) (title, notes string) {
+	title, notes, _ = strings.Cut(text, "\n")
+	return strings.TrimSpace(title), strings.TrimSpace(notes)
 }
 
 func (bm *BotManager) askForPeriodicity(ctx context.Context, chatID int64, eventID int) error {
-	keyboard := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: "🕐 Каждый час", CallbackData: fmt.Sprintf("period:hour:%d", eventID)},
-			},
-			{
-				{Text: "📅 Каждый день", CallbackData: fmt.Sprintf("period:day:%d", eventID)},
-			},
-			{
-				{Text: "🗓️ Каждую неделю", CallbackData: fmt.Sprintf("period:week:%d", eventID)},
-			},
-			{
-				{Text: "🔢 Выбранные дни недели", CallbackData: fmt.Sprintf("period:weekdays:%d", eventID)},
-			},
-			{
-				{Text: "❌ Без повтора", CallbackData: fmt.Sprintf("period:none:%d", eventID)},
-			},
-		},
-	}
+	tr := bm.Tr(ctx, chatID)
+	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: bm.periodicityRows(tr, ActionPeriod, eventID)}
 
 	_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        "📅 Выберите периодичность уведомления:",
+		Text:        tr.T("periodicity.ask"),
 		ReplyMarkup: keyboard,
 	})
 	return err
 }
 
 func (bm *BotManager) askForWeekdays(ctx context.Context, chatID int64, eventID int, selectedDays []int) error {
-	keyboard := bm.makeWeekdaysKeyboard(eventID, selectedDays)
+	tr := bm.Tr(ctx, chatID)
+	keyboard := bm.makeWeekdaysKeyboard(tr, eventID, selectedDays)
 	_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID:      chatID,
-		Text:        "Выберите дни недели:",
+		Text:        tr.T("weekdays.ask"),
 		ReplyMarkup: keyboard,
 	})
 	return err
 }
 
-func (bm *BotManager) makeWeekdaysKeyboard(eventID int, selectedDays []int) *models.InlineKeyboardMarkup {
+func (bm *BotManager) makeWeekdaysKeyboard(tr *i18n.Localizer, eventID int, selectedDays []int) *models.InlineKeyboardMarkup {
 	res := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
 
-	for _, weekDay := range weekDays {
-		buttonText := weekDay.Name
-		dayInt, err := strconv.Atoi(weekDay.ID)
-		if err != nil {
-			bm.Errorf("Ошибка конвертации дня недели: %v", err)
-			continue
-		}
-
-		if slices.Contains(selectedDays, dayInt) {
-			buttonText = "✅ " + weekDay.Name
-		} else {
-			buttonText = "❌ " + weekDay.Name
+	for _, day := range weekdays {
+		buttonText := "❌ " + tr.WeekdayName(day)
+		if slices.Contains(selectedDays, day) {
+			buttonText = "✅ " + tr.WeekdayName(day)
 		}
 
-		res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{{
-			Text:         buttonText,
-			CallbackData: fmt.Sprintf("weekday:%s:%d", weekDay.ID, eventID),
-		}})
+		res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{
+			bm.button(buttonText, ActionWeekday, eventID, day),
+		})
 	}
 
 	res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{
-		{
-			Text:         "✅ Готово",
-			CallbackData: fmt.Sprintf("weekdays_done:%d", eventID),
-		},
+		bm.button(tr.T("button.done"), ActionWeekdaysDone, eventID),
 	})
 
 	return res
 }
 
-func (bm *BotManager) HandlePeriodicityCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.CallbackQuery == nil {
-		return
-	}
-
-	data := update.CallbackQuery.Data
-	chatID := update.CallbackQuery.From.ID
-	messageID := update.CallbackQuery.Message.Message.ID
-
-	_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-		CallbackQueryID: update.CallbackQuery.ID,
-	})
-	bm.OnError(err)
-
-	bm.handlePeriodicityCallback(ctx, b, data, chatID, messageID)
-}
-
-func (bm *BotManager) HandleWeekdayCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.CallbackQuery == nil {
-		return
-	}
-
-	data := update.CallbackQuery.Data
-	chatID := update.CallbackQuery.From.ID
-	messageID := update.CallbackQuery.Message.Message.ID
-
-	_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-		CallbackQueryID: update.CallbackQuery.ID,
-	})
-	bm.OnError(err)
-
-	bm.handleWeekdayCallback(ctx, b, data, chatID, messageID)
-}
-
-func (bm *BotManager) HandleWeekdaysDoneCallback(ctx context.Context, b *bot.Bot, update *models.Update) {
-	if update.CallbackQuery == nil {
-		return
-	}
-
-	data := update.CallbackQuery.Data
-	chatID := update.CallbackQuery.From.ID
-	messageID := update.CallbackQuery.Message.Message.ID
-
-	_, err := b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
-		CallbackQueryID: update.CallbackQuery.ID,
-	})
-	bm.OnError(err)
-
-	bm.handleWeekdaysDoneCallback(ctx, b, data, chatID, messageID)
-}
-
-func (bm *BotManager) handlePeriodicityCallback(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	parts := strings.Split(data, ":")
-	if len(parts) < 3 {
+// HandlePeriodicity sets periodicity of a new event.
+func (bm *BotManager) HandlePeriodicity(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+	periodType, ok := periodicityArg(data.Arg(1))
+	if !ok {
 		return
 	}
 
-	periodType := parts[1]
-	eventID, err := strconv.Atoi(parts[2])
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Ошибка: событие не найдено",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if periodType != "none" && periodType != "weekdays" {
+	tr := bm.Tr(ctx, chatID)
+	if periodType != periodNone && periodType != db.PeriodicityWeekdays {
 		count, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
 		if err != nil {
 			bm.Errorf("Ошибка подсчёта событий: %v", err)
 			_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "❌ Не удалось проверить количество событий",
+				Text:   tr.T("periodicity.count_error"),
 			})
 			bm.OnError(err)
 			return
@@ -661,7 +550,7 @@ func (bm *BotManager) handlePeriodicityCallback(ctx context.Context, b *bot.Bot,
 		if count >= MaxPeriodic {
 			_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "⚠️ Превышен лимит: максимум 100 периодических напоминаний на одного пользователя.",
+				Text:   tr.T("periodicity.limit", MaxPeriodic),
 			})
 			bm.OnError(err)
 			_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -674,10 +563,10 @@ func (bm *BotManager) handlePeriodicityCallback(ctx context.Context, b *bot.Bot,
 	}
 
 	switch periodType {
-	case "none":
+	case periodNone:
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "✅ Событие добавлено без повтора!",
+			Text:   tr.T("event.added_once"),
 		})
 		bm.OnError(err)
 		_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -687,7 +576,7 @@ func (bm *BotManager) handlePeriodicityCallback(ctx context.Context, b *bot.Bot,
 		bm.OnError(err)
 		return
 
-	case "weekdays":
+	case db.PeriodicityWeekdays:
 		err := bm.askForWeekdays(ctx, chatID, eventID, event.Weekdays)
 		bm.OnError(err)
 		_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -703,17 +592,16 @@ func (bm *BotManager) handlePeriodicityCallback(ctx context.Context, b *bot.Bot,
 		if err != nil {
 			_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "❌ Ошибка обновления события",
+				Text:   tr.T("event.update_error"),
 			})
 			bm.OnError(err)
 			return
 		}
 	}
 
-	periodicityText := getPeriodicityText(periodType)
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   fmt.Sprintf("✅ Событие добавлено! %s", periodicityText),
+		Text:   tr.T("event.added", periodicityText(tr, &periodType, nil)),
 	})
 	bm.OnError(err)
 	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -723,29 +611,20 @@ func (bm *BotManager) handlePeriodicityCallback(ctx context.Context, b *bot.Bot,
 	bm.OnError(err)
 }
 
-func (bm *BotManager) handleWeekdayCallback(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	parts := strings.Split(data, ":")
-	if len(parts) < 3 {
-		return
-	}
-
-	dayStr := parts[1]
-	eventID, err := strconv.Atoi(parts[2])
-	if err != nil {
-		return
-	}
-
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
+// HandleWeekday toggles weekday of a new event.
+func (bm *BotManager) HandleWeekday(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID, day := data.Arg(0), data.Arg(1)
+	if day < 1 || day > 7 {
 		return
 	}
 
-	day, err := strconv.Atoi(dayStr)
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	newWeekdays := toggleDayInSlice(event.Weekdays, day)
+	newWeekdays := toggleInSlice(event.Weekdays, day)
 	event.Weekdays = newWeekdays
 
 	_, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns("weekdays"))
@@ -754,7 +633,7 @@ func (bm *BotManager) handleWeekdayCallback(ctx context.Context, b *bot.Bot, dat
 		return
 	}
 
-	keyboard := bm.makeWeekdaysKeyboard(eventID, newWeekdays)
+	keyboard := bm.makeWeekdaysKeyboard(bm.Tr(ctx, chatID), eventID, newWeekdays)
 	_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
 		ChatID:      chatID,
 		MessageID:   messageID,
@@ -763,31 +642,20 @@ func (bm *BotManager) handleWeekdayCallback(ctx context.Context, b *bot.Bot, dat
 	bm.OnError(err)
 }
 
-func (bm *BotManager) handleWeekdaysDoneCallback(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	parts := strings.Split(data, ":")
-	if len(parts) < 2 {
-		return
-	}
-
-	eventID, err := strconv.Atoi(parts[1])
+// HandleWeekdaysDone saves weekdays periodicity of a new event.
+func (bm *BotManager) HandleWeekdaysDone(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Ошибка: событие не найдено",
-		})
-		bm.OnError(err)
-		return
-	}
-
+	tr := bm.Tr(ctx, chatID)
 	if len(event.Weekdays) == 0 {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Выберите хотя бы один день недели",
+			Text:   tr.T("weekdays.empty"),
 		})
 		bm.OnError(err)
 		return
@@ -799,20 +667,15 @@ func (bm *BotManager) handleWeekdaysDoneCallback(ctx context.Context, b *bot.Bot
 	if err != nil {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ошибка обновления события",
+			Text:   tr.T("event.update_error"),
 		})
 		bm.OnError(err)
 		return
 	}
 
-	var daysNames []string
-	for _, day := range event.Weekdays {
-		daysNames = append(daysNames, DayName(day))
-	}
-
 	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
 		ChatID: chatID,
-		Text:   fmt.Sprintf("✅ Событие добавлено! 🔄 По дням: %s", strings.Join(daysNames, ", ")),
+		Text:   tr.T("event.added", periodicityText(tr, event.Periodicity, event.Weekdays)),
 	})
 	bm.OnError(err)
 	_, err = b.DeleteMessage(ctx, &bot.DeleteMessageParams{
@@ -822,53 +685,27 @@ func (bm *BotManager) handleWeekdaysDoneCallback(ctx context.Context, b *bot.Bot
 	bm.OnError(err)
 }
 
-func toggleDayInSlice(slice []int, day int) []int {
-	if slices.Contains(slice, day) {
+func toggleInSlice(slice []int, v int) []int {
+	if slices.Contains(slice, v) {
 		var newSlice []int
 		for _, d := range slice {
-			if d != day {
+			if d != v {
 				newSlice = append(newSlice, d)
 			}
 		}
 		return newSlice
 	}
-	return append(slice, day)
-}
-
-func getPeriodicityText(periodType string) string {
-	switch periodType {
-	case db.PeriodicityHour:
-		return "🔄 Каждый час"
-	case db.PeriodicityDay:
-		return "🔄 Ежедневно"
-	case db.PeriodicityWeek:
-		return "🔄 Еженедельно"
-	default:
-		return ""
-	}
+	return append(slice, v)
 }
 
-func (bm *BotManager) DeleteEventByID(ctx context.Context, id int) error {
-	event, err := bm.EventsRepo.EventByID(ctx, id)
-	if err != nil {
+// DeleteEventByID moves user event to trash.
+func (bm *BotManager) DeleteEventByID(ctx context.Context, chatID int64, id int) error {
+	if _, err := bm.UserEvent(ctx, chatID, id); err != nil {
 		return err
 	}
 
-	if event == nil {
-		return fmt.Errorf("event not found")
-	}
-
-	deleted, err := bm.EventsRepo.DeleteEvent(ctx, id)
-	if err != nil {
-		return err
-	}
-
-	if !deleted {
-		return fmt.Errorf("event not found")
-	}
-
-	return nil
-}
+	return bm.trashEvent(ctx, id)
+}
 
 func (bm *BotManager) GetUserEvents(ctx context.Context, chatID int64) ([]model.Event, error) {
 	statusId := db.StatusEnabled
@@ -888,39 +725,27 @@ func (bm *BotManager) GetUserEvents(ctx context.Context, chatID int64) ([]model.
 	return model.NewEvents(dbEvents), nil
 }
 
-func (bm *BotManager) GetEventByID(ctx context.Context, id int) (*model.Event, error) {
-	dbEvent, err := bm.EventsRepo.EventByID(ctx, id)
+func (bm *BotManager) GetEventByID(ctx context.Context, chatID int64, id int) (*model.Event, error) {
+	dbEvent, err := bm.UserEvent(ctx, chatID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if dbEvent == nil {
-		return nil, nil
-	}
-
 	return model.NewEvent(dbEvent), nil
 }
 
 var (
-	ErrNotFound     = errors.New("event not found")
-	ErrAccessDenied = errors.New("access denied")
-	ErrInactive     = errors.New("event not active")
-	ErrPastDate     = errors.New("past_date")
+	ErrInactive           = errors.New("event not active")
+	ErrPastDate           = errors.New("past_date")
+	ErrInvalidPeriodicity = errors.New("invalid_periodicity")
 )
 
 func (bm *BotManager) SnoozeEvent(ctx context.Context, eventID int, userTgID int64, newTime time.Time) error {
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
+	event, err := bm.UserEvent(ctx, userTgID, eventID)
 	if err != nil {
 		return err
 	}
 
-	if event == nil {
-		return ErrNotFound
-	}
-	if event.UserTgID != userTgID {
-		return ErrAccessDenied
-	}
-
 	if event.StatusID != db.StatusEnabled {
 		return ErrInactive
 	}
@@ -937,68 +762,159 @@ func (bm *BotManager) SnoozeEvent(ctx context.Context, eventID int, userTgID int
 
 	return nil
 }
-func (bm *BotManager) HandleEventDetail(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, EventDetailPrefix)
-	eventID, err := strconv.Atoi(eventIDStr)
+
+// SetEventDate sets event time without status checks, so paused events could be rescheduled too.
+func (bm *BotManager) SetEventDate(ctx context.Context, chatID int64, eventID int, at time.Time) error {
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
-		return
+		return err
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
-		})
-		bm.OnError(err)
-		return
+	if at.Before(time.Now()) {
+		return ErrPastDate
 	}
 
-	if event.UserTgID != chatID {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
-		})
-		bm.OnError(err)
-		return
+	event.SendAt = at
+	_, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.SendAt))
+	return err
+}
+
+// EditEvent updates title, notes, time and periodicity of user event in one write. Nil fields are kept, empty notes are removed,
+// empty periodicity makes the event one-off. Weekdays are used only with weekdays periodicity.
+func (bm *BotManager) EditEvent(ctx context.Context, chatID int64, eventID int, title, notes *string, at *time.Time, periodicity *string, weekdays []int) (*db.Event, error) {
+	event, err := bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
+		return nil, err
 	}
 
-	var msg strings.Builder
-	msg.WriteString(fmt.Sprintf("📅 %s\n", event.SendAt.Format("2006-01-02 15:04")))
-	msg.WriteString(fmt.Sprintf("📝 %s\n", event.Message))
+	var columns []string
+	if title != nil {
+		switch {
+		case *title == "":
+			return nil, fmt.Errorf("empty_title")
+		case utf8.RuneCountInString(*title) > MaxTitleLength:
+			return nil, fmt.Errorf("text_too_long")
+		}
+		event.Message = *title
+		columns = append(columns, db.Columns.Event.Message)
+	}
+	if notes != nil {
+		if utf8.RuneCountInString(*notes) > MaxNotesLength {
+			return nil, fmt.Errorf("notes_too_long")
+		}
+		event.Notes = nil
+		if *notes != "" {
+			event.Notes = notes
+		}
+		columns = append(columns, db.Columns.Event.Notes)
+	}
+	if at != nil {
+		if at.Before(time.Now()) {
+			return nil, ErrPastDate
+		}
+		event.SendAt = *at
+		columns = append(columns, db.Columns.Event.SendAt)
+	}
+	if periodicity != nil {
+		days, err := bm.CheckEventPeriodicity(ctx, chatID, event, *periodicity, weekdays)
+		if err != nil {
+			return nil, err
+		}
+		event.Periodicity, event.Weekdays = nil, days
+		if *periodicity != "" {
+			event.Periodicity = periodicity
+		}
+		columns = append(columns, db.Columns.Event.Periodicity, db.Columns.Event.Weekdays)
+	}
+
+	if len(columns) == 0 {
+		return event, nil
+	}
+
+	if _, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(columns...)); err != nil {
+		return nil, err
+	}
+	if title != nil || notes != nil {
+		bm.SyncTags(ctx, event)
+	}
+
+	return event, nil
+}
 
-	if event.Periodicity != nil {
-		switch *event.Periodicity {
-		case "hour":
-			msg.WriteString("🔄 Каждый час\n")
-		case "day":
-			msg.WriteString("🔄 Ежедневно\n")
-		case "week":
-			msg.WriteString("🔄 Еженедельно\n")
-		case "weekdays":
-			var days []string
-			for _, day := range event.Weekdays {
-				days = append(days, DayName(day))
+// CheckEventPeriodicity validates periodicity of existing or new (nil) event without changing it and returns weekdays to store:
+// sorted unique days for weekdays periodicity, empty otherwise. Making one-off event periodic also checks MaxPeriodic limit.
+func (bm *BotManager) CheckEventPeriodicity(ctx context.Context, chatID int64, event *db.Event, periodicity string, weekdays []int) ([]int, error) {
+	days := []int{}
+	switch periodicity {
+	case "", db.PeriodicityHour, db.PeriodicityDay, db.PeriodicityWeek:
+	case db.PeriodicityWeekdays:
+		for _, day := range weekdays {
+			if day < 1 || day > 7 {
+				return nil, ErrInvalidPeriodicity
 			}
-			msg.WriteString(fmt.Sprintf("🔄 По дням: %s\n", strings.Join(days, ", ")))
+			if !slices.Contains(days, day) {
+				days = append(days, day)
+			}
+		}
+		if len(days) == 0 {
+			return nil, ErrInvalidPeriodicity
 		}
-	} else {
-		msg.WriteString("⏹️ Без повтора\n")
+		slices.Sort(days)
+	default:
+		return nil, ErrInvalidPeriodicity
 	}
 
-	msg.WriteString("\nВыберите действие:")
+	if periodicity != "" && (event == nil || event.Periodicity == nil) {
+		count, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= MaxPeriodic {
+			return nil, ErrPeriodicLimit
+		}
+	}
 
-	keyboard := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{
-				{Text: "✏️ Изменить", CallbackData: fmt.Sprintf("%s%d", eventEditPrefix, eventID)},
-				{Text: "🗑️ Удалить", CallbackData: fmt.Sprintf("%s%d", eventDeletePrefix, eventID)},
-			},
-			{
-				{Text: "◀️ Назад", CallbackData: eventBackToList},
-			},
+	return days, nil
+}
+
+func (bm *BotManager) HandleEventDetail(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+
+	event, err := bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+
+	var msg strings.Builder
+	msg.WriteString(fmt.Sprintf("🆔 id%d\n", event.ID))
+	msg.WriteString(fmt.Sprintf("📅 %s\n", tr.DateTime(event.SendAt)))
+	msg.WriteString(fmt.Sprintf("📝 %s\n", event.Message))
+	if event.Notes != nil && *event.Notes != "" {
+		msg.WriteString(fmt.Sprintf("🗒 %s\n", *event.Notes))
+	}
+
+	msg.WriteString(periodicityLine(tr, event.Periodicity, event.Weekdays))
+	msg.WriteString(pauseLine(tr, event))
+
+	msg.WriteString("\n" + tr.T("event.choose_action"))
+
+	rows := [][]models.InlineKeyboardButton{
+		{
+			bm.button(tr.T("event.edit"), ActionEventEdit, eventID),
+			bm.button(tr.T("event.delete"), ActionEventDelete, eventID),
 		},
 	}
+	if event.StatusID == db.StatusEnabled {
+		rows = append(rows, bm.snoozeRows(ctx, chatID, ActionPostponePreset, eventID)...)
+	}
+	rows = append(rows,
+		[]models.InlineKeyboardButton{bm.pauseButton(tr, event)},
+		[]models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionBackToList)},
+	)
+	keyboard := &models.InlineKeyboardMarkup{InlineKeyboard: rows}
 
 	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:      chatID,
@@ -1009,265 +925,114 @@ func (bm *BotManager) HandleEventDetail(ctx context.Context, b *bot.Bot, data st
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandleEventEdit(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, eventEditPrefix)
-	eventID, err := strconv.Atoi(eventIDStr)
-	if err != nil {
+func (bm *BotManager) HandleEventEdit(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
+	tr := bm.Tr(ctx, chatID)
 	keyboard := &models.InlineKeyboardMarkup{
 		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "📅 Дата", CallbackData: fmt.Sprintf("%s%d", editDatePrefix, eventID)}},
-			{{Text: "📝 Описание", CallbackData: fmt.Sprintf("%s%d", editDescPrefix, eventID)}},
-			{{Text: "🔄 Периодичность", CallbackData: fmt.Sprintf("%s%d", editPeriodicityPrefix, eventID)}},
-			{{Text: "◀️ Назад", CallbackData: fmt.Sprintf("%s%d", EventDetailPrefix, eventID)}},
+			{bm.button(tr.T("edit.date"), ActionEditDate, eventID)},
+			{bm.button(tr.T("edit.title"), ActionEditDescription, eventID)},
+			{bm.button(tr.T("edit.notes"), ActionEditNotes, eventID)},
+			{bm.button(tr.T("edit.periodicity"), ActionEditPeriodicity, eventID)},
+			{bm.button(tr.T("button.back"), ActionEventDetail, eventID)},
 		},
 	}
 
-	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:      chatID,
 		MessageID:   messageID,
-		Text:        "Выберите, что хотите изменить:",
+		Text:        tr.T("edit.ask"),
 		ReplyMarkup: keyboard,
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandleEventDelete(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, eventDeletePrefix)
-	eventID, err := strconv.Atoi(eventIDStr)
-	if err != nil {
-		return
-	}
-
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
-		})
-		bm.OnError(err)
-		return
-	}
+func (bm *BotManager) HandleEventDelete(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
 
-	if event.UserTgID != chatID {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
-		})
-		bm.OnError(err)
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	_, err = bm.EventsRepo.DeleteEvent(ctx, eventID)
+	tr := bm.Tr(ctx, chatID)
+	err := bm.trashEvent(ctx, eventID)
 	if err != nil {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ошибка при удалении события",
+			Text:   tr.T("event.delete_error"),
 		})
 		bm.OnError(err)
 		return
 	}
 
 	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:    chatID,
-		MessageID: messageID,
-		Text:      "✅ Событие удалено!",
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        tr.T("event.trashed_short"),
+		ReplyMarkup: bm.UndoKeyboard(tr, eventID),
 	})
 	bm.OnError(err)
 }
 
 func (bm *BotManager) HandleBackToList(ctx context.Context, b *bot.Bot, chatID int64, messageID int) {
-	page := 1
-	pageSize := 10
-
-	events, err := bm.GetUserEvents(ctx, chatID)
-	if err != nil {
-		bm.Errorf("Ошибка загрузки событий: %v", err)
-		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Ошибка при загрузке событий",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if len(events) == 0 {
-		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-			ChatID:    chatID,
-			MessageID: messageID,
-			Text:      "🔍 Нет событий",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	total := len(events)
-	start := (page - 1) * pageSize
-	end := start + pageSize
-	if end > total {
-		end = total
-	}
-
-	pageEvents := events[start:end]
-
-	periodicCount, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
-	if err != nil {
-		bm.Errorf("Ошибка подсчета периодических событий: %v", err)
-		periodicCount = 0
-	}
-
-	var msg strings.Builder
-	msg.WriteString("📅 Список событий:\n\n")
-	msg.WriteString(fmt.Sprintf("📊 Периодических уведомлений: %d/%d\n\n", periodicCount, MaxPeriodic))
-
-	for i, e := range pageEvents {
-		msg.WriteString(fmt.Sprintf("%d. %s — ", start+i+1, e.Text))
-		msg.WriteString(fmt.Sprintf("%s\n", e.DateTime.Format("2006-01-02 15:04")))
-
-		if e.Periodicity != nil {
-			switch *e.Periodicity {
-			case "hour":
-				msg.WriteString("🔄 Каждый час\n")
-			case "day":
-				msg.WriteString("🔄 Ежедневно\n")
-			case "week":
-				msg.WriteString("🔄 Еженедельно\n")
-			case "weekdays":
-				var days []string
-				for _, day := range e.Weekdays {
-					days = append(days, DayName(day))
-				}
-				msg.WriteString(fmt.Sprintf("🔄 По дням: %s\n", strings.Join(days, ", ")))
-			}
-		} else {
-			msg.WriteString("⏹️ Без повтора\n")
-		}
-	}
-
-	var buttons [][]models.InlineKeyboardButton
-
-	var row []models.InlineKeyboardButton
-	for i := range pageEvents {
-		eventNum := start + i + 1
-		row = append(row, models.InlineKeyboardButton{
-			Text:         fmt.Sprintf("%d", eventNum),
-			CallbackData: fmt.Sprintf("%s%d", EventDetailPrefix, pageEvents[i].ID),
-		})
-
-		if len(row) == 5 {
-			buttons = append(buttons, row)
-			row = []models.InlineKeyboardButton{}
-		}
-	}
-	if len(row) > 0 {
-		buttons = append(buttons, row)
-	}
+	bm.editListPage(ctx, b, chatID, messageID, 1, 0)
+}
 
-	var navRow []models.InlineKeyboardButton
-	if page > 1 {
-		navRow = append(navRow, models.InlineKeyboardButton{
-			Text:         "⬅️ Назад",
-			CallbackData: fmt.Sprintf("page_%d", page-1),
-		})
-	}
-	if end < total {
-		navRow = append(navRow, models.InlineKeyboardButton{
-			Text:         "➡️ Далее",
-			CallbackData: fmt.Sprintf("page_%d", page+1),
-		})
-	}
-	if len(navRow) > 0 {
-		buttons = append(buttons, navRow)
-	}
+func (bm *BotManager) HandleEditDate(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
 
-	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:    chatID,
-		MessageID: messageID,
-		Text:      msg.String(),
-		ReplyMarkup: &models.InlineKeyboardMarkup{
-			InlineKeyboard: buttons,
-		},
-	})
-	bm.OnError(err)
-}
-func (bm *BotManager) HandleEditDate(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, editDatePrefix)
-	eventID, err := strconv.Atoi(eventIDStr)
-	if err != nil {
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
+	tr := bm.Tr(ctx, chatID)
 	keyboard := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "⏰ Перенести на час", CallbackData: fmt.Sprintf("%s%d", postponeHour, eventID)}},
-			{{Text: "📅 Перенести на день", CallbackData: fmt.Sprintf("%s%d", postponeDay, eventID)}},
-			{{Text: "📆 Перенести на неделю", CallbackData: fmt.Sprintf("%s%d", postponeWeek, eventID)}},
-			{{Text: "✍️ Ввести свою дату", CallbackData: fmt.Sprintf("%s%d", postponeCustom, eventID)}},
-			{{Text: "◀️ Назад", CallbackData: fmt.Sprintf("%s%d", eventEditPrefix, eventID)}},
-		},
+		InlineKeyboard: append(bm.snoozeRows(ctx, chatID, ActionPostponePreset, eventID),
+			[]models.InlineKeyboardButton{bm.button(tr.T("postpone.custom"), ActionPostponeCustom, eventID)},
+			[]models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionEventEdit, eventID)},
+		),
 	}
 
-	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:      chatID,
 		MessageID:   messageID,
-		Text:        "Выберите действие:",
+		Text:        tr.T("event.choose_action"),
 		ReplyMarkup: keyboard,
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandlePostpone(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	var duration time.Duration
-	var eventID int
-	var err error
-
-	if strings.HasPrefix(data, postponeHour) {
-		eventIDStr := strings.TrimPrefix(data, postponeHour)
-		eventID, err = strconv.Atoi(eventIDStr)
-		duration = time.Hour
-	} else if strings.HasPrefix(data, postponeDay) {
-		eventIDStr := strings.TrimPrefix(data, postponeDay)
-		eventID, err = strconv.Atoi(eventIDStr)
-		duration = 24 * time.Hour
-	} else if strings.HasPrefix(data, postponeWeek) {
-		eventIDStr := strings.TrimPrefix(data, postponeWeek)
-		eventID, err = strconv.Atoi(eventIDStr)
-		duration = 7 * 24 * time.Hour
-	}
-
-	if err != nil {
+// HandlePostpone moves event by number of hours from callback. Kept for buttons sent before snooze presets.
+func (bm *BotManager) HandlePostpone(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID, hours := data.Arg(0), data.Arg(1)
+	if hours <= 0 {
 		return
 	}
+	duration := time.Duration(hours) * time.Hour
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if event.UserTgID != chatID {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
-		})
-		bm.OnError(err)
+	event, err := bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
 	newTime := event.SendAt.Add(duration)
 	event.SendAt = newTime
 
+	tr := bm.Tr(ctx, chatID)
 	_, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.SendAt))
 	if err != nil {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ошибка при обновлении события",
+			Text:   tr.T("event.update_error"),
 		})
 		bm.OnError(err)
 		return
@@ -1276,132 +1041,111 @@ func (bm *BotManager) HandlePostpone(ctx context.Context, b *bot.Bot, data strin
 	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:    chatID,
 		MessageID: messageID,
-		Text:      fmt.Sprintf("✅ Дата изменена на %s", newTime.Format("2006-01-02 15:04")),
+		Text:      tr.T("event.date_changed", tr.DateTime(newTime)),
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandlePostponeCustom(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, postponeCustom)
-	eventID, err := strconv.Atoi(eventIDStr)
-	if err != nil {
+func (bm *BotManager) HandlePostponeCustom(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	bm.Mu.Lock()
-	bm.EditStates[chatID] = &EditState{
-		EventID:    eventID,
-		WaitingFor: "custom_date",
+	if err := bm.StartConversation(ctx, chatID, StateEventDate, db.ConversationParams{EventID: eventID}); err != nil {
+		bm.Errorf("%v", err)
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   bm.Tr(ctx, chatID).T("common.retry_error"),
+		})
+		bm.OnError(err)
+		return
 	}
-	bm.Mu.Unlock()
 
-	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:    chatID,
-		MessageID: messageID,
-		Text:      "📅 Введите новую дату и время в формате:\nYYYY-MM-DD HH:MM\n\nНапример: 2025-12-31 23:59",
+	text, keyboard := bm.DatePicker(ctx, chatID, PickerEventDate, eventID)
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        text,
+		ReplyMarkup: keyboard,
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandleEditDescription(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, editDescPrefix)
-	eventID, err := strconv.Atoi(eventIDStr)
-	if err != nil {
-		return
-	}
+func (bm *BotManager) HandleEditDescription(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
 
-	bm.Mu.Lock()
-	bm.EditStates[chatID] = &EditState{
-		EventID:    eventID,
-		WaitingFor: "description",
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
+		return
 	}
-	bm.Mu.Unlock()
 
-	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
-		ChatID:    chatID,
-		MessageID: messageID,
-		Text:      "📝 Введите новое описание события (не более 200 символов):",
-	})
-	bm.OnError(err)
+	bm.startConversation(ctx, b, chatID, messageID, StateEventTitle, db.ConversationParams{EventID: eventID},
+		bm.Tr(ctx, chatID).T("event.title_ask", MaxTitleLength))
 }
 
-func (bm *BotManager) HandleEditPeriodicity(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	eventIDStr := strings.TrimPrefix(data, editPeriodicityPrefix)
-	eventID, err := strconv.Atoi(eventIDStr)
-	if err != nil {
+func (bm *BotManager) HandleEditNotes(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
-		})
-		bm.OnError(err)
+	bm.startConversation(ctx, b, chatID, messageID, StateEventNotes, db.ConversationParams{EventID: eventID},
+		bm.Tr(ctx, chatID).T("event.notes_ask", MaxNotesLength))
+}
+
+func (bm *BotManager) HandleEditPeriodicity(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
+	tr := bm.Tr(ctx, chatID)
 	keyboard := &models.InlineKeyboardMarkup{
-		InlineKeyboard: [][]models.InlineKeyboardButton{
-			{{Text: "🕐 Каждый час", CallbackData: fmt.Sprintf("edit_period:hour:%d", eventID)}},
-			{{Text: "📅 Каждый день", CallbackData: fmt.Sprintf("edit_period:day:%d", eventID)}},
-			{{Text: "🗓️ Каждую неделю", CallbackData: fmt.Sprintf("edit_period:week:%d", eventID)}},
-			{{Text: "🔢 Выбранные дни недели", CallbackData: fmt.Sprintf("edit_period:weekdays:%d", eventID)}},
-			{{Text: "❌ Без повтора", CallbackData: fmt.Sprintf("edit_period:none:%d", eventID)}},
-			{{Text: "◀️ Назад", CallbackData: fmt.Sprintf("%s%d", eventEditPrefix, eventID)}},
-		},
+		InlineKeyboard: append(bm.periodicityRows(tr, ActionEditPeriod, eventID),
+			[]models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionEventEdit, eventID)},
+		),
 	}
 
-	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:      chatID,
 		MessageID:   messageID,
-		Text:        "📅 Выберите периодичность уведомления:",
+		Text:        tr.T("periodicity.ask"),
 		ReplyMarkup: keyboard,
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandleEditPeriodicityCallback(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	parts := strings.Split(data, ":")
-	if len(parts) < 3 {
+// HandleEditPeriod changes periodicity of existing event.
+func (bm *BotManager) HandleEditPeriod(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+	periodType, ok := periodicityArg(data.Arg(1))
+	if !ok {
 		return
 	}
 
-	periodType := parts[1]
-	eventID, err := strconv.Atoi(parts[2])
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Событие не найдено",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if event.UserTgID != chatID {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
-		})
-		bm.OnError(err)
-		return
-	}
-
+	tr := bm.Tr(ctx, chatID)
 	switch periodType {
-	case "none":
+	case periodNone:
 		event.Periodicity = nil
 		event.Weekdays = []int{}
 		_, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns("periodicity", "weekdays"))
 		if err != nil {
 			_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "❌ Ошибка обновления события",
+				Text:   tr.T("event.update_error"),
 			})
 			bm.OnError(err)
 			return
@@ -1410,13 +1154,13 @@ func (bm *BotManager) HandleEditPeriodicityCallback(ctx context.Context, b *bot.
 		_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 			ChatID:    chatID,
 			MessageID: messageID,
-			Text:      "✅ Периодичность отключена!",
+			Text:      tr.T("periodicity.disabled"),
 		})
 		bm.OnError(err)
 		return
 
-	case "weekdays":
-		err := bm.askForWeekdaysEdit(ctx, b, chatID, messageID, eventID, event.Weekdays)
+	case db.PeriodicityWeekdays:
+		err := bm.askForWeekdaysEdit(ctx, b, tr, chatID, messageID, eventID, event.Weekdays)
 		bm.OnError(err)
 		return
 
@@ -1427,94 +1171,70 @@ func (bm *BotManager) HandleEditPeriodicityCallback(ctx context.Context, b *bot.
 		if err != nil {
 			_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "❌ Ошибка обновления события",
+				Text:   tr.T("event.update_error"),
 			})
 			bm.OnError(err)
 			return
 		}
 	}
 
-	periodicityText := getPeriodicityText(periodType)
 	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:    chatID,
 		MessageID: messageID,
-		Text:      fmt.Sprintf("✅ Периодичность изменена! %s", periodicityText),
+		Text:      tr.T("periodicity.changed", periodicityText(tr, &periodType, nil)),
 	})
 	bm.OnError(err)
 }
 
-func (bm *BotManager) askForWeekdaysEdit(ctx context.Context, b *bot.Bot, chatID int64, messageID int, eventID int, selectedDays []int) error {
-	keyboard := bm.makeWeekdaysEditKeyboard(eventID, selectedDays)
+func (bm *BotManager) askForWeekdaysEdit(ctx context.Context, b *bot.Bot, tr *i18n.Localizer, chatID int64, messageID int, eventID int, selectedDays []int) error {
+	keyboard := bm.makeWeekdaysEditKeyboard(tr, eventID, selectedDays)
 	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:      chatID,
 		MessageID:   messageID,
-		Text:        "Выберите дни недели:",
+		Text:        tr.T("weekdays.ask"),
 		ReplyMarkup: keyboard,
 	})
 	return err
 }
 
-func (bm *BotManager) makeWeekdaysEditKeyboard(eventID int, selectedDays []int) *models.InlineKeyboardMarkup {
+func (bm *BotManager) makeWeekdaysEditKeyboard(tr *i18n.Localizer, eventID int, selectedDays []int) *models.InlineKeyboardMarkup {
 	res := &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{}}
 
-	for _, weekDay := range weekDays {
-		buttonText := weekDay.Name
-		dayInt, err := strconv.Atoi(weekDay.ID)
-		if err != nil {
-			bm.Errorf("Ошибка конвертации дня недели: %v", err)
-			continue
+	for _, day := range weekdays {
+		buttonText := "❌ " + tr.WeekdayName(day)
+		if slices.Contains(selectedDays, day) {
+			buttonText = "✅ " + tr.WeekdayName(day)
 		}
 
-		if slices.Contains(selectedDays, dayInt) {
-			buttonText = "✅ " + weekDay.Name
-		} else {
-			buttonText = "❌ " + weekDay.Name
-		}
-
-		res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{{
-			Text:         buttonText,
-			CallbackData: fmt.Sprintf("edit_weekday:%s:%d", weekDay.ID, eventID),
-		}})
+		res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{
+			bm.button(buttonText, ActionEditWeekday, eventID, day),
+		})
 	}
 
 	res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{
-		{Text: "✅ Готово", CallbackData: fmt.Sprintf("edit_weekdays_done:%d", eventID)},
+		bm.button(tr.T("button.done"), ActionEditWeekdaysDone, eventID),
 	})
 
 	res.InlineKeyboard = append(res.InlineKeyboard, []models.InlineKeyboardButton{
-		{Text: "◀️ Назад", CallbackData: fmt.Sprintf("%s%d", editPeriodicityPrefix, eventID)},
+		bm.button(tr.T("button.back"), ActionEditPeriodicity, eventID),
 	})
 
 	return res
 }
 
-func (bm *BotManager) HandleEditWeekday(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	parts := strings.Split(data, ":")
-	if len(parts) < 3 {
+func (bm *BotManager) HandleEditWeekday(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID, day := data.Arg(0), data.Arg(1)
+	if day < 1 || day > 7 {
 		return
 	}
 
-	dayStr := parts[1]
-	eventID, err := strconv.Atoi(parts[2])
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		return
-	}
-
-	if event.UserTgID != chatID {
-		return
-	}
-
-	day, err := strconv.Atoi(dayStr)
-	if err != nil {
-		return
-	}
-
-	newWeekdays := toggleDayInSlice(event.Weekdays, day)
+	newWeekdays := toggleInSlice(event.Weekdays, day)
 	event.Weekdays = newWeekdays
 
 	_, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns("weekdays"))
@@ -1523,7 +1243,7 @@ func (bm *BotManager) HandleEditWeekday(ctx context.Context, b *bot.Bot, data st
 		return
 	}
 
-	keyboard := bm.makeWeekdaysEditKeyboard(eventID, newWeekdays)
+	keyboard := bm.makeWeekdaysEditKeyboard(bm.Tr(ctx, chatID), eventID, newWeekdays)
 	_, err = b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
 		ChatID:      chatID,
 		MessageID:   messageID,
@@ -1532,40 +1252,20 @@ func (bm *BotManager) HandleEditWeekday(ctx context.Context, b *bot.Bot, data st
 	bm.OnError(err)
 }
 
-func (bm *BotManager) HandleEditWeekdaysDone(ctx context.Context, b *bot.Bot, data string, chatID int64, messageID int) {
-	parts := strings.Split(data, ":")
-	if len(parts) < 2 {
-		return
-	}
+func (bm *BotManager) HandleEditWeekdaysDone(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
 
-	eventID, err := strconv.Atoi(parts[1])
+	event, err := bm.UserEvent(ctx, chatID, eventID)
 	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
 		return
 	}
 
-	event, err := bm.EventsRepo.EventByID(ctx, eventID)
-	if err != nil || event == nil {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ Ошибка: событие не найдено",
-		})
-		bm.OnError(err)
-		return
-	}
-
-	if event.UserTgID != chatID {
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   "❌ У вас нет доступа к этому событию",
-		})
-		bm.OnError(err)
-		return
-	}
-
+	tr := bm.Tr(ctx, chatID)
 	if len(event.Weekdays) == 0 {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Выберите хотя бы один день недели",
+			Text:   tr.T("weekdays.empty"),
 		})
 		bm.OnError(err)
 		return
@@ -1577,21 +1277,16 @@ func (bm *BotManager) HandleEditWeekdaysDone(ctx context.Context, b *bot.Bot, da
 	if err != nil {
 		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ошибка обновления события",
+			Text:   tr.T("event.update_error"),
 		})
 		bm.OnError(err)
 		return
 	}
 
-	var daysNames []string
-	for _, day := range event.Weekdays {
-		daysNames = append(daysNames, DayName(day))
-	}
-
 	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
 		ChatID:    chatID,
 		MessageID: messageID,
-		Text:      fmt.Sprintf("✅ Периодичность изменена! 🔄 По дням: %s", strings.Join(daysNames, ", ")),
+		Text:      tr.T("periodicity.changed", periodicityText(tr, event.Periodicity, event.Weekdays)),
 	})
 	bm.OnError(err)
 }
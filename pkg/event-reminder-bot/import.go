@@ -0,0 +1,495 @@
+package event_reminder_bot
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/ical"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// maxImportSize limits size of uploaded calendar file.
+	maxImportSize = 1 << 20
+
+	// importTTL is how long import preview could be confirmed.
+	importTTL = 30 * time.Minute
+
+	// maxImportLines limits lists of conflicts and problems in import preview.
+	maxImportLines = 10
+
+	// allDayHour is reminder time of all-day events without alarms.
+	allDayHour = 9
+)
+
+// importPlan is a previewed import waiting for confirmation.
+type importPlan struct {
+	Events    []db.Event
+	Periodic  int
+	Total     int
+	Past      int
+	Cancelled int
+	Existing  int
+	Limit     int
+	Conflicts []string
+	Skipped   []string
+	Notes     []string
+	CreatedAt time.Time
+}
+
+// IsCalendarFile returns true if document looks like iCalendar file.
+func IsCalendarFile(d *models.Document) bool {
+	return d != nil && (strings.HasSuffix(strings.ToLower(d.FileName), ".ics") || strings.HasPrefix(d.MimeType, "text/calendar"))
+}
+
+func (bm *BotManager) ImportHelpHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: update.Message.Chat.ID,
+		Text:   bm.Tr(ctx, update.Message.Chat.ID).T("import.ask"),
+	})
+	bm.OnError(err)
+}
+
+// ImportHandler downloads uploaded calendar or backup file and shows import preview.
+func (bm *BotManager) ImportHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID, doc := update.Message.Chat.ID, update.Message.Document
+	tr := bm.Tr(ctx, chatID)
+
+	reply := func(text string, keyboard models.ReplyMarkup) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        text,
+			ReplyMarkup: keyboard,
+		})
+		bm.OnError(err)
+	}
+
+	format := BackupFormat(doc)
+	if !IsCalendarFile(doc) && format == "" {
+		reply(tr.T("import.unsupported_file"), nil)
+		return
+	}
+	if doc.FileSize > maxImportSize {
+		reply(tr.T("import.too_large", maxImportSize>>10), nil)
+		return
+	}
+
+	data, err := bm.downloadFile(ctx, b, doc.FileID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки файла календаря: %v", err)
+		reply(tr.T("import.error"), nil)
+		return
+	}
+
+	if format != "" {
+		bm.restoreBackup(ctx, b, chatID, format, data)
+		return
+	}
+
+	plan, err := bm.PlanImport(ctx, chatID, data, time.Now())
+	switch {
+	case errors.Is(err, ical.ErrInvalidCalendar):
+		reply(tr.T("import.invalid"), nil)
+		return
+	case err != nil:
+		bm.Errorf("Ошибка разбора файла календаря пользователя %d: %v", chatID, err)
+		reply(tr.T("import.error"), nil)
+		return
+	}
+
+	bm.setImport(chatID, plan)
+
+	text, keyboard := bm.importPreview(tr, plan)
+	if keyboard == nil {
+		// typed nil keyboard is not omitted from request
+		reply(text, nil)
+		return
+	}
+	reply(text, keyboard)
+}
+
+// HandleImport creates previewed events or cancels import.
+func (bm *BotManager) HandleImport(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	tr := bm.Tr(ctx, chatID)
+	plan := bm.takeImport(chatID)
+
+	var text string
+	switch {
+	case data.Action == ActionImportCancel:
+		text = tr.T("import.cancelled")
+	case plan == nil || time.Since(plan.CreatedAt) > importTTL:
+		text = tr.T("import.expired")
+	default:
+		created, err := bm.ApplyImport(ctx, chatID, plan)
+		if err != nil {
+			bm.Errorf("Ошибка импорта событий пользователя %d: %v", chatID, err)
+			text = tr.T("import.failed", created)
+		} else {
+			text = tr.N("import.done", created)
+		}
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	})
+	bm.OnError(err)
+}
+
+// PlanImport maps calendar events to bot events. Past, cancelled and already added events are skipped,
+// periodic events over MaxPeriodic limit are skipped too.
+func (bm *BotManager) PlanImport(ctx context.Context, chatID int64, data []byte, now time.Time) (*importPlan, error) {
+	tr := bm.Tr(ctx, chatID)
+	loc := bm.UserLocation(ctx, chatID)
+
+	vevents, err := ical.Decode(bytes.NewReader(data), loc)
+	if err != nil {
+		return nil, err
+	}
+
+	// active and paused events, deleted ones are filtered out by default
+	existing, err := bm.EventsRepo.EventsByFilters(ctx, &db.EventSearch{UserTgID: &chatID}, db.PagerNoLimit)
+	if err != nil {
+		return nil, err
+	}
+	periodic, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &importPlan{Total: len(vevents), CreatedAt: now}
+	for _, v := range vevents {
+		title := importTitle(tr, v)
+		if v.Status == "CANCELLED" {
+			plan.Cancelled++
+			continue
+		}
+
+		event, reason, notes := mapEvent(tr, v, loc, now)
+		plan.Notes = append(plan.Notes, prefixAll(title, notes)...)
+		switch {
+		case reason == "past":
+			plan.Past++
+			continue
+		case reason != "":
+			plan.Skipped = append(plan.Skipped, title+": "+tr.T(reason))
+			continue
+		}
+		event.UserTgID = chatID
+
+		if conflict := findConflict(existing, event); conflict != nil {
+			if conflict.Message == event.Message {
+				plan.Existing++
+				continue
+			}
+			plan.Conflicts = append(plan.Conflicts, tr.DateTime(event.SendAt)+" "+event.Message+" ↔ "+conflict.Message)
+		}
+
+		if event.Periodicity != nil {
+			if periodic+plan.Periodic >= MaxPeriodic {
+				plan.Limit++
+				continue
+			}
+			plan.Periodic++
+		}
+
+		plan.Events = append(plan.Events, *event)
+		existing = append(existing, *event)
+	}
+
+	return plan, nil
+}
+
+// ApplyImport creates events of import plan with tags from their text. It returns the number of created events.
+func (bm *BotManager) ApplyImport(ctx context.Context, chatID int64, plan *importPlan) (int, error) {
+	periodic, err := bm.EventsRepo.CountUserPeriodicEvents(ctx, chatID)
+	if err != nil {
+		return 0, err
+	}
+
+	var created int
+	for i := range plan.Events {
+		event := plan.Events[i]
+		if event.Periodicity != nil {
+			// periodic events could be added after preview
+			if periodic >= MaxPeriodic {
+				continue
+			}
+			periodic++
+		}
+
+		added, err := bm.EventsRepo.AddEvent(ctx, &event)
+		if err != nil {
+			return created, err
+		}
+		bm.SyncTags(ctx, added)
+		created++
+	}
+
+	return created, nil
+}
+
+// mapEvent converts calendar event to bot event. It returns skip reason message key or "past",
+// and notes about parts of the event which could not be mapped exactly.
+func mapEvent(tr *i18n.Localizer, v ical.VEvent, loc *time.Location, now time.Time) (*db.Event, string, []string) {
+	switch {
+	case v.Invalid:
+		return nil, "import.reason.invalid", nil
+	case v.RecurrenceID != "":
+		return nil, "import.reason.override", nil
+	}
+
+	var notes []string
+	if v.UnknownTZ != "" {
+		notes = append(notes, tr.T("import.note.timezone", v.UnknownTZ))
+	}
+
+	// all-day events are reminded in the morning of the day unless they have alarms
+	if v.AllDay && len(v.Alarms) == 0 {
+		v.Start = v.Start.Add(allDayHour * time.Hour)
+		notes = append(notes, tr.T("import.note.all_day", tr.Time(v.Start.In(loc))))
+	}
+	// reminders are sent at alarm time, the offset is kept for every occurrence
+	at := v.Reminder()
+	offset := at.Sub(v.Start)
+
+	title, text := importText(tr, v)
+	event := &db.Event{
+		Message:  title,
+		SendAt:   at,
+		StatusID: db.StatusEnabled,
+		Weekdays: []int{},
+	}
+	if text != "" {
+		event.Notes = &text
+	}
+	if utf8.RuneCountInString(v.Summary) > MaxTitleLength {
+		notes = append(notes, tr.T("import.note.title_cut"))
+	}
+
+	if v.RRule == "" {
+		if at.Before(now) {
+			return nil, "past", nil
+		}
+		return event, "", notes
+	}
+
+	series, err := ical.ParseRRule(v.RRule, v.Start)
+	if err != nil {
+		return nil, "import.reason.rrule", nil
+	}
+	event.Periodicity, event.Weekdays = &series.Periodicity, series.Weekdays
+	if event.Weekdays == nil {
+		event.Weekdays = []int{}
+	}
+
+	// the series is stored by its next occurrence, excluded dates are skipped only at the start
+	excluded := make(map[int64]bool, len(v.ExDates))
+	for _, t := range v.ExDates {
+		excluded[t.Unix()] = true
+	}
+
+	re := model.ReminderEvent{DateTime: v.Start, Periodicity: event.Periodicity, Weekdays: event.Weekdays}
+	start := v.Start
+	if at.Before(now) {
+		next := model.NextAfter(re, now.Add(-offset))
+		if next == nil {
+			return nil, "past", nil
+		}
+		start = *next
+	}
+	for excluded[start.Unix()] {
+		re.DateTime = start
+		next := model.NextTime(re)
+		if next == nil {
+			return nil, "past", nil
+		}
+		start = *next
+	}
+	if series.Until != nil && start.After(*series.Until) {
+		return nil, "past", nil
+	}
+	event.SendAt = start.Add(offset)
+
+	if series.Until != nil {
+		notes = append(notes, tr.T("import.note.until", tr.Date(series.Until.In(loc))))
+	}
+	for _, t := range v.ExDates {
+		if t.After(start) {
+			notes = append(notes, tr.T("import.note.exdate"))
+			break
+		}
+	}
+
+	return event, "", notes
+}
+
+// importText returns event title and notes: description and location of calendar event.
+func importText(tr *i18n.Localizer, v ical.VEvent) (string, string) {
+	title := strings.TrimSpace(strings.ReplaceAll(v.Summary, "\n", " "))
+	if title == "" {
+		title = tr.T("import.untitled")
+	}
+
+	var notes []string
+	if d := strings.TrimSpace(v.Description); d != "" {
+		notes = append(notes, d)
+	}
+	if l := strings.TrimSpace(v.Location); l != "" {
+		notes = append(notes, "📍 "+l)
+	}
+
+	return truncate(title, MaxTitleLength), truncate(strings.Join(notes, "\n"), MaxNotesLength)
+}
+
+// importTitle returns short event name for preview lists.
+func importTitle(tr *i18n.Localizer, v ical.VEvent) string {
+	title, _ := importText(tr, v)
+	return truncate(title, 40)
+}
+
+// findConflict returns event reminded at the same minute.
+func findConflict(events []db.Event, event *db.Event) *db.Event {
+	for i := range events {
+		if events[i].SendAt.Truncate(time.Minute).Equal(event.SendAt.Truncate(time.Minute)) {
+			return &events[i]
+		}
+	}
+
+	return nil
+}
+
+// importPreview returns import summary with confirmation buttons. Keyboard is nil if there is nothing to import.
+func (bm *BotManager) importPreview(tr *i18n.Localizer, plan *importPlan) (string, *models.InlineKeyboardMarkup) {
+	var msg strings.Builder
+	msg.WriteString(tr.T("import.title") + "\n\n")
+	msg.WriteString(tr.T("import.total", plan.Total) + "\n")
+	msg.WriteString(tr.T("import.new", len(plan.Events), len(plan.Events)-plan.Periodic, plan.Periodic) + "\n")
+
+	counts := []struct {
+		key   string
+		count int
+	}{
+		{"import.existing", plan.Existing},
+		{"import.past", plan.Past},
+		{"import.cancelled_events", plan.Cancelled},
+		{"import.limit", plan.Limit},
+	}
+	for _, c := range counts {
+		if c.count > 0 {
+			msg.WriteString(tr.T(c.key, c.count) + "\n")
+		}
+	}
+	if plan.Limit > 0 {
+		msg.WriteString(tr.T("periodicity.limit", MaxPeriodic) + "\n")
+	}
+
+	lists := []struct {
+		key   string
+		lines []string
+	}{
+		{"import.conflicts", plan.Conflicts},
+		{"import.skipped", plan.Skipped},
+		{"import.notes", plan.Notes},
+	}
+	for _, l := range lists {
+		if len(l.lines) == 0 {
+			continue
+		}
+		msg.WriteString("\n" + tr.T(l.key) + "\n")
+		for i, line := range l.lines {
+			if i == maxImportLines {
+				msg.WriteString(tr.T("import.more", len(l.lines)-maxImportLines) + "\n")
+				break
+			}
+			msg.WriteString("• " + line + "\n")
+		}
+	}
+
+	if len(plan.Events) == 0 {
+		msg.WriteString("\n" + tr.T("import.nothing"))
+		return msg.String(), nil
+	}
+
+	return msg.String(), &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{{
+			bm.button(tr.T("import.confirm", len(plan.Events)), ActionImportConfirm),
+			bm.button(tr.T("import.cancel"), ActionImportCancel),
+		}},
+	}
+}
+
+// downloadFile returns content of Telegram file up to maxImportSize.
+func (bm *BotManager) downloadFile(ctx context.Context, b *bot.Bot, fileID string) ([]byte, error) {
+	file, err := b.GetFile(ctx, &bot.GetFileParams{FileID: fileID})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxImportSize))
+}
+
+func (bm *BotManager) setImport(chatID int64, plan *importPlan) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	bm.imports[chatID] = plan
+}
+
+// takeImport returns and forgets pending import of the user.
+func (bm *BotManager) takeImport(chatID int64) *importPlan {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	plan := bm.imports[chatID]
+	delete(bm.imports, chatID)
+
+	return plan
+}
+
+func prefixAll(prefix string, lines []string) []string {
+	res := make([]string, 0, len(lines))
+	for _, l := range lines {
+		res = append(res, prefix+": "+l)
+	}
+
+	return res
+}
+
+// truncate cuts s to n runes.
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n-1]) + "…"
+}
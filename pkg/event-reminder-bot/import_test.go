@@ -0,0 +1,54 @@
+package event_reminder_bot
+
+import (
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/ical"
+)
+
+func TestMapEvent(t *testing.T) {
+	tr := i18n.For(i18n.English)
+	loc := time.UTC
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, loc)
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2026, time.October, day, hour, minute, 0, 0, loc)
+	}
+
+	tests := []struct {
+		name   string
+		event  ical.VEvent
+		sendAt time.Time
+		reason string
+	}{
+		{"one-off", ical.VEvent{Summary: "Dentist", Start: at(20, 10, 0)}, at(20, 10, 0), ""},
+		{"past", ical.VEvent{Summary: "Dentist", Start: at(17, 10, 0)}, time.Time{}, "past"},
+		{"alarm", ical.VEvent{Summary: "Flight", Start: at(20, 10, 0), Alarms: []ical.Alarm{{Offset: -2 * time.Hour}, {Offset: -30 * time.Minute}}}, at(20, 8, 0), ""},
+		{"all day", ical.VEvent{Summary: "Birthday", Start: at(25, 0, 0), AllDay: true}, at(25, allDayHour, 0), ""},
+		{"daily from past", ical.VEvent{Summary: "Pills", Start: at(1, 9, 0), RRule: "FREQ=DAILY"}, at(19, 9, 0), ""},
+		{"excluded next", ical.VEvent{Summary: "Pills", Start: at(1, 9, 0), RRule: "FREQ=DAILY", ExDates: []time.Time{at(19, 9, 0)}}, at(20, 9, 0), ""},
+		{"alarm of series", ical.VEvent{Summary: "Standup", Start: at(1, 10, 0), RRule: "FREQ=DAILY", Alarms: []ical.Alarm{{Offset: -15 * time.Minute}}}, at(19, 9, 45), ""},
+		{"ended series", ical.VEvent{Summary: "Course", Start: at(1, 9, 0), RRule: "FREQ=DAILY;COUNT=5"}, time.Time{}, "past"},
+		{"monthly", ical.VEvent{Summary: "Rent", Start: at(1, 9, 0), RRule: "FREQ=MONTHLY"}, time.Time{}, "import.reason.rrule"},
+		{"override", ical.VEvent{Summary: "Standup", Start: at(20, 9, 0), RecurrenceID: "20261020T090000Z"}, time.Time{}, "import.reason.override"},
+		{"invalid", ical.VEvent{Summary: "Broken", Invalid: true}, time.Time{}, "import.reason.invalid"},
+	}
+
+	for _, tt := range tests {
+		event, reason, _ := mapEvent(tr, tt.event, loc, now)
+		if reason != tt.reason {
+			t.Errorf("%s: reason = %q, want %q", tt.name, reason, tt.reason)
+			continue
+		}
+		if reason == "" && !event.SendAt.Equal(tt.sendAt) {
+			t.Errorf("%s: sendAt = %v, want %v", tt.name, event.SendAt, tt.sendAt)
+		}
+	}
+
+	event, _, _ := mapEvent(tr, ical.VEvent{Start: at(20, 9, 0), RRule: "FREQ=WEEKLY;BYDAY=MO,TU"}, loc, now)
+	if event.Message != "Untitled" || event.Periodicity == nil || *event.Periodicity != db.PeriodicityWeekdays || len(event.Weekdays) != 2 {
+		t.Errorf("weekdays event = %+v", event)
+	}
+}
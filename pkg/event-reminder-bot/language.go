@@ -0,0 +1,129 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"strings"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// languageAuto is a /language argument to use Telegram language.
+const languageAuto = "auto"
+
+// languageChoices are /language buttons, empty language is Telegram language. Index is stored in callback data, so append only.
+var languageChoices = []i18n.Lang{"", i18n.Russian, i18n.English}
+
+// Tr returns localizer for the user language and time zone.
+func (bm *BotManager) Tr(ctx context.Context, chatID int64) *i18n.Localizer {
+	u := bm.User(ctx, chatID)
+	return i18n.For(userLang(u)).In(u.Location)
+}
+
+// Language returns language chosen by the user or matched from Telegram language code.
+func (bm *BotManager) Language(ctx context.Context, chatID int64) i18n.Lang {
+	return userLang(bm.User(ctx, chatID))
+}
+
+// SetLanguage sets user language, empty language resets it to Telegram language.
+func (bm *BotManager) SetLanguage(ctx context.Context, chatID int64, lang i18n.Lang) error {
+	user := &db.User{UserTgID: chatID}
+	if lang != "" {
+		s := string(lang)
+		user.Language = &s
+	}
+
+	return bm.UpdateUser(ctx, user, db.Columns.User.Language)
+}
+
+func (bm *BotManager) LanguageHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	arg := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/language")))
+
+	if arg == "" {
+		tr := bm.Tr(ctx, chatID)
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        tr.T("language.current", tr.T("language.name")),
+			ReplyMarkup: bm.languageKeyboard(tr),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	lang, ok := i18n.Parse(arg)
+	if arg == languageAuto {
+		lang, ok = "", true
+	}
+	if !ok {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   bm.Tr(ctx, chatID).T("language.usage"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   bm.applyLanguage(ctx, chatID, lang),
+	})
+	bm.OnError(err)
+}
+
+// HandleLanguage sets language chosen with button.
+func (bm *BotManager) HandleLanguage(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	i := data.Arg(0)
+	if i < 0 || i >= len(languageChoices) {
+		return
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      bm.applyLanguage(ctx, chatID, languageChoices[i]),
+	})
+	bm.OnError(err)
+}
+
+// applyLanguage sets user language and returns confirmation in the new language.
+func (bm *BotManager) applyLanguage(ctx context.Context, chatID int64, lang i18n.Lang) string {
+	if err := bm.SetLanguage(ctx, chatID, lang); err != nil {
+		bm.Errorf("Ошибка сохранения языка пользователя %d: %v", chatID, err)
+		return bm.Tr(ctx, chatID).T("language.error")
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	if lang == "" {
+		return tr.T("language.auto_set", tr.T("language.name"))
+	}
+
+	return tr.T("language.set", tr.T("language.name"))
+}
+
+func (bm *BotManager) languageKeyboard(tr *i18n.Localizer) *models.InlineKeyboardMarkup {
+	var rows [][]models.InlineKeyboardButton
+	for i, lang := range languageChoices {
+		text := tr.T("language.auto")
+		if lang != "" {
+			text = i18n.For(lang).T("language.name")
+		}
+
+		rows = append(rows, []models.InlineKeyboardButton{bm.button(text, ActionLanguage, i)})
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+func userLang(u *model.User) i18n.Lang {
+	if lang, ok := i18n.Parse(u.Language); ok {
+		return lang
+	}
+
+	return i18n.Match(u.LanguageCode)
+}
@@ -0,0 +1,247 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// pauseOptions are auto-resume periods in days offered in the pause menu, 0 means no auto-resume.
+// Options without message key are titled with number of days.
+var pauseOptions = []struct {
+	Days int
+	Key  string
+}{
+	{Days: 0, Key: "pause.forever"},
+	{Days: 1},
+	{Days: 3},
+	{Days: 7, Key: "pause.week"},
+	{Days: 30, Key: "pause.month"},
+}
+
+// PauseEvent disables event sending. If resumeAt is set, event will be resumed automatically.
+func (bm *BotManager) PauseEvent(ctx context.Context, chatID int64, id int, resumeAt *time.Time) (*db.Event, error) {
+	event, err := bm.UserEvent(ctx, chatID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if resumeAt != nil && resumeAt.Before(time.Now()) {
+		return nil, ErrPastDate
+	}
+
+	event.StatusID = db.StatusDisabled
+	event.ResumeAt = resumeAt
+	_, err = bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.StatusID, db.Columns.Event.ResumeAt))
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ResumeEvent enables paused event.
+func (bm *BotManager) ResumeEvent(ctx context.Context, chatID int64, id int) (*db.Event, error) {
+	event, err := bm.UserEvent(ctx, chatID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if event.StatusID != db.StatusDisabled {
+		return event, nil
+	}
+
+	return event, bm.resume(ctx, event)
+}
+
+// ResumeDueEvents resumes paused events with auto-resume time in the past and notifies their owners.
+func (bm *BotManager) ResumeDueEvents(ctx context.Context) error {
+	events, err := bm.EventsRepo.EventsToResume(ctx)
+	if err != nil {
+		return fmt.Errorf("ошибка загрузки приостановленных событий: %w", err)
+	}
+
+	for i := range events {
+		event := &events[i]
+		if err := bm.resume(ctx, event); err != nil {
+			bm.Errorf("Ошибка возобновления события %d: %v", event.ID, err)
+			continue
+		}
+
+		tr := bm.Tr(ctx, event.UserTgID)
+		_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: event.UserTgID,
+			Text:   tr.T("pause.resumed", event.Message, tr.DateTime(event.SendAt)),
+		})
+		bm.OnError(err)
+	}
+
+	return nil
+}
+
+// resume enables event and moves periodic event to its next future occurrence.
+func (bm *BotManager) resume(ctx context.Context, event *db.Event) error {
+	event.StatusID = db.StatusEnabled
+	event.ResumeAt = nil
+	if next := model.NextAfter(model.NewReminderEvent(event), time.Now()); next != nil {
+		event.SendAt = *next
+	}
+
+	_, err := bm.EventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.StatusID, db.Columns.Event.ResumeAt, db.Columns.Event.SendAt))
+	return err
+}
+
+// pauseLine returns pause status line for event details.
+func pauseLine(tr *i18n.Localizer, event *db.Event) string {
+	if event.StatusID != db.StatusDisabled {
+		return ""
+	}
+
+	if event.ResumeAt == nil {
+		return tr.T("pause.paused") + "\n"
+	}
+
+	return tr.T("pause.paused_until", tr.DateTime(*event.ResumeAt)) + "\n"
+}
+
+// pauseButton returns pause or resume button depending on event status.
+func (bm *BotManager) pauseButton(tr *i18n.Localizer, event *db.Event) models.InlineKeyboardButton {
+	if event.StatusID == db.StatusDisabled {
+		return bm.button(tr.T("pause.resume"), ActionResume, event.ID)
+	}
+
+	return bm.button(tr.T("pause.pause"), ActionPauseMenu, event.ID)
+}
+
+// HandlePause handles pause menu, pause for days and pause until date callbacks.
+func (bm *BotManager) HandlePause(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+
+	switch data.Action {
+	case ActionPauseMenu:
+		bm.showPauseMenu(ctx, b, chatID, messageID, eventID)
+		return
+	case ActionPauseDate:
+		if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+			bm.sendPauseError(ctx, b, chatID, err)
+			return
+		}
+
+		bm.startConversation(ctx, b, chatID, messageID, StateResumeDate, db.ConversationParams{EventID: eventID},
+			bm.Tr(ctx, chatID).T("pause.date_ask"))
+		return
+	}
+
+	days := data.Arg(1)
+	if days < 0 {
+		return
+	}
+
+	var resumeAt *time.Time
+	if days > 0 {
+		t := time.Now().AddDate(0, 0, days)
+		resumeAt = &t
+	}
+
+	if _, err := bm.PauseEvent(ctx, chatID, eventID, resumeAt); err != nil {
+		bm.sendPauseError(ctx, b, chatID, err)
+		return
+	}
+
+	bm.HandleEventDetail(ctx, b, callback.New(ActionEventDetail, eventID), chatID, messageID)
+}
+
+func (bm *BotManager) HandleResume(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID := data.Arg(0)
+	if _, err := bm.ResumeEvent(ctx, chatID, eventID); err != nil {
+		bm.sendPauseError(ctx, b, chatID, err)
+		return
+	}
+
+	bm.HandleEventDetail(ctx, b, callback.New(ActionEventDetail, eventID), chatID, messageID)
+}
+
+func (bm *BotManager) showPauseMenu(ctx context.Context, b *bot.Bot, chatID int64, messageID, eventID int) {
+	tr := bm.Tr(ctx, chatID)
+
+	var keyboard [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+	for _, o := range pauseOptions {
+		title := tr.N("pause.days", o.Days)
+		if o.Key != "" {
+			title = tr.T(o.Key)
+		}
+
+		row = append(row, bm.button(title, ActionPause, eventID, o.Days))
+		if len(row) == 3 {
+			keyboard = append(keyboard, row)
+			row = nil
+		}
+	}
+	if len(row) > 0 {
+		keyboard = append(keyboard, row)
+	}
+	keyboard = append(keyboard,
+		[]models.InlineKeyboardButton{bm.button(tr.T("pause.until_date"), ActionPauseDate, eventID)},
+		[]models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionEventDetail, eventID)},
+	)
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        tr.T("pause.ask"),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: keyboard},
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) sendPauseError(ctx context.Context, b *bot.Bot, chatID int64, err error) {
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   bm.pauseErrorText(bm.Tr(ctx, chatID), err),
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) pauseErrorText(tr *i18n.Localizer, err error) string {
+	if errors.Is(err, ErrPastDate) {
+		return tr.T("pause.past_date")
+	}
+
+	return bm.accessErrorText(tr, err)
+}
+
+// PauseUntil pauses event until date entered by user.
+func (bm *BotManager) PauseUntil(ctx context.Context, b *bot.Bot, chatID int64, text string, params db.ConversationParams) {
+	tr := bm.Tr(ctx, chatID)
+	resumeAt, err := time.ParseInLocation("2006-01-02 15:04", text, bm.UserLocation(ctx, chatID))
+	if err != nil {
+		_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("pause.invalid_date"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	event, err := bm.PauseEvent(ctx, chatID, params.EventID, &resumeAt)
+	if err != nil {
+		bm.sendPauseError(ctx, b, chatID, err)
+		return
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   tr.T("pause.done", event.Message, tr.DateTime(resumeAt)),
+	})
+	bm.OnError(err)
+}
@@ -0,0 +1,305 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// PickerTarget is what the date picked in calendar is used for. Values are stored in callback data, so append only.
+type PickerTarget int
+
+const (
+	PickerEventDate PickerTarget = iota // ref is event ID
+	PickerSnooze                        // ref is event ID
+	PickerAdd                           // ref is not used
+	PickerVacation                      // ref is the first vacation day or 0
+)
+
+const (
+	pickerMinuteStep = 5
+	pickerHourCols   = 6
+	pickerMinuteCols = 4
+
+	// pickerDisabled is a label of past days and times.
+	pickerDisabled = "·"
+)
+
+// DatePicker returns prompt and calendar of the current month for target.
+func (bm *BotManager) DatePicker(ctx context.Context, chatID int64, target PickerTarget, ref int) (string, *models.InlineKeyboardMarkup) {
+	tr := bm.Tr(ctx, chatID)
+	now := time.Now().In(bm.UserLocation(ctx, chatID))
+
+	return pickerPrompt(tr, target, ref, now.Location()), &models.InlineKeyboardMarkup{
+		InlineKeyboard: bm.calendarRows(tr, target, ref, monthIndex(now), now),
+	}
+}
+
+// HandlePicker handles calendar and time picker navigation and applies picked date to target.
+func (bm *BotManager) HandlePicker(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	target, ref := PickerTarget(data.Arg(0)), data.Arg(1)
+	tr := bm.Tr(ctx, chatID)
+	now := time.Now().In(bm.UserLocation(ctx, chatID))
+
+	var rows [][]models.InlineKeyboardButton
+	switch data.Action {
+	case ActionPickerMonth:
+		rows = bm.calendarRows(tr, target, ref, data.Arg(2), now)
+	case ActionPickerDay:
+		if target == PickerVacation {
+			if ref == 0 {
+				ref = data.Arg(2)
+				rows = bm.calendarRows(tr, target, ref, monthIndex(dayTime(ref, 0, now.Location())), now)
+				break
+			}
+			bm.pickVacation(ctx, b, chatID, messageID, ref, data.Arg(2))
+			return
+		}
+		rows = bm.hourRows(tr, target, ref, data.Arg(2), now)
+	case ActionPickerHour:
+		rows = bm.minuteRows(tr, target, ref, data.Arg(2), data.Arg(3), now)
+	case ActionPickerTime:
+		bm.applyPicker(ctx, b, chatID, messageID, target, ref, dayTime(data.Arg(2), data.Arg(3), now.Location()))
+		return
+	default:
+		return
+	}
+
+	_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        pickerPrompt(tr, target, ref, now.Location()),
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+	})
+	bm.OnError(err)
+}
+
+// applyPicker applies date and time picked for event targets or asks for title of a new event.
+func (bm *BotManager) applyPicker(ctx context.Context, b *bot.Bot, chatID int64, messageID int, target PickerTarget, ref int, at time.Time) {
+	tr := bm.Tr(ctx, chatID)
+
+	var text string
+	var err error
+	switch target {
+	case PickerEventDate:
+		text = tr.T("event.date_changed", tr.DateTime(at))
+		err = bm.SetEventDate(ctx, chatID, ref, at)
+	case PickerSnooze:
+		text = tr.T("snooze.moved", tr.DateTime(at))
+		err = bm.SnoozeEvent(ctx, ref, chatID, at)
+	case PickerAdd:
+		if !at.After(time.Now()) {
+			text, err = tr.T("date.past"), nil
+			break
+		}
+		bm.startConversation(ctx, b, chatID, messageID, StateAddTitle, db.ConversationParams{SendAt: &at},
+			tr.T("add.title_ask", tr.DateTime(at)))
+		return
+	default:
+		return
+	}
+
+	switch {
+	case err == nil:
+		// date is picked, so typed input is not expected anymore
+		if target != PickerAdd {
+			if _, err = bm.TakeConversation(ctx, chatID); err != nil {
+				bm.Errorf("%v", err)
+			}
+		}
+	case errors.Is(err, ErrNotFound), errors.Is(err, ErrAccessDenied):
+		text = bm.accessErrorText(tr, err)
+	case errors.Is(err, ErrInactive):
+		text = tr.T("event.inactive")
+	case errors.Is(err, ErrPastDate):
+		text = tr.T("date.past")
+	default:
+		bm.Errorf("Ошибка изменения даты события %d: %v", ref, err)
+		text = tr.T("event.update_error")
+	}
+
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	})
+	bm.OnError(err)
+}
+
+// pickVacation saves vacation for picked days.
+func (bm *BotManager) pickVacation(ctx context.Context, b *bot.Bot, chatID int64, messageID int, firstDay, lastDay int) {
+	loc := bm.UserLocation(ctx, chatID)
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+	_, err := bm.SetVacation(ctx, chatID, dayTime(firstDay, 0, loc), dayTime(lastDay, 0, loc))
+	if err == nil {
+		text, keyboard, err = bm.vacationMessage(ctx, chatID)
+	}
+	if err != nil {
+		text, keyboard = bm.vacationErrorText(bm.Tr(ctx, chatID), err), nil
+	}
+
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.EditMessageText(ctx, params)
+	bm.OnError(err)
+}
+
+// pickerPrompt returns message text shown above the picker.
+func pickerPrompt(tr *i18n.Localizer, target PickerTarget, ref int, loc *time.Location) string {
+	switch target {
+	case PickerAdd:
+		return tr.T("add.date_ask")
+	case PickerVacation:
+		if ref == 0 {
+			return tr.T("vacation.pick_start")
+		}
+		return tr.T("vacation.pick_end", tr.Date(dayTime(ref, 0, loc)))
+	default:
+		return tr.T("event.date_ask") + "\n\n" + tr.T("conversation.cancel_hint")
+	}
+}
+
+// calendarRows returns month calendar with navigation. Past days and, for vacation end, days before its start are disabled.
+func (bm *BotManager) calendarRows(tr *i18n.Localizer, target PickerTarget, ref, month int, now time.Time) [][]models.InlineKeyboardButton {
+	t := int(target)
+	minDay := civilDay(now)
+	if target == PickerVacation && ref > minDay {
+		minDay = ref
+	}
+	first := time.Date(month/12, time.Month(month%12+1), 1, 0, 0, 0, 0, time.UTC)
+
+	prev := bm.noopButton(" ")
+	if month > monthIndex(dayTime(minDay, 0, time.UTC)) {
+		prev = bm.button("«", ActionPickerMonth, t, ref, month-1)
+	}
+	rows := [][]models.InlineKeyboardButton{{
+		prev,
+		bm.noopButton(fmt.Sprintf("%s %d", tr.Month(first.Month()), first.Year())),
+		bm.button("»", ActionPickerMonth, t, ref, month+1),
+	}}
+
+	header := make([]models.InlineKeyboardButton, 0, 7)
+	for day := 1; day <= 7; day++ {
+		header = append(header, bm.noopButton(tr.Weekday(day)))
+	}
+	rows = append(rows, header)
+
+	week := make([]models.InlineKeyboardButton, 0, 7)
+	for range model.Weekday(first) - 1 {
+		week = append(week, bm.noopButton(" "))
+	}
+	for d := first; d.Month() == first.Month(); d = d.AddDate(0, 0, 1) {
+		day := civilDay(d)
+		label := strconv.Itoa(d.Day())
+		switch {
+		case day < minDay:
+			week = append(week, bm.noopButton(pickerDisabled))
+		case target == PickerVacation && day == ref:
+			week = append(week, bm.button("["+label+"]", ActionPickerDay, t, ref, day))
+		default:
+			week = append(week, bm.button(label, ActionPickerDay, t, ref, day))
+		}
+
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]models.InlineKeyboardButton, 0, 7)
+		}
+	}
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, bm.noopButton(" "))
+		}
+		rows = append(rows, week)
+	}
+
+	return rows
+}
+
+// hourRows returns hours of the day. Hours without future minutes are disabled.
+func (bm *BotManager) hourRows(tr *i18n.Localizer, target PickerTarget, ref, day int, now time.Time) [][]models.InlineKeyboardButton {
+	t, loc := int(target), now.Location()
+	rows := [][]models.InlineKeyboardButton{
+		{bm.button("◀️ "+tr.Date(dayTime(day, 0, loc)), ActionPickerMonth, t, ref, monthIndex(dayTime(day, 0, loc)))},
+	}
+
+	var row []models.InlineKeyboardButton
+	for h := range 24 {
+		if dayTime(day, (h+1)*60-pickerMinuteStep, loc).After(now) {
+			row = append(row, bm.button(fmt.Sprintf("%02d", h), ActionPickerHour, t, ref, day, h))
+		} else {
+			row = append(row, bm.noopButton(pickerDisabled))
+		}
+
+		if len(row) == pickerHourCols {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	return rows
+}
+
+// minuteRows returns minutes of the hour with pickerMinuteStep. Past minutes are disabled.
+func (bm *BotManager) minuteRows(tr *i18n.Localizer, target PickerTarget, ref, day, hour int, now time.Time) [][]models.InlineKeyboardButton {
+	t, loc := int(target), now.Location()
+	rows := [][]models.InlineKeyboardButton{
+		{bm.button("◀️ "+tr.DateTime(dayTime(day, hour*60, loc)), ActionPickerDay, t, ref, day)},
+	}
+
+	var row []models.InlineKeyboardButton
+	for m := 0; m < 60; m += pickerMinuteStep {
+		minutes := hour*60 + m
+		if dayTime(day, minutes, loc).After(now) {
+			row = append(row, bm.button(fmt.Sprintf("%02d:%02d", hour, m), ActionPickerTime, t, ref, day, minutes))
+		} else {
+			row = append(row, bm.noopButton(pickerDisabled))
+		}
+
+		if len(row) == pickerMinuteCols {
+			rows = append(rows, row)
+			row = nil
+		}
+	}
+
+	return rows
+}
+
+// noopButton returns button which does nothing: calendar headers and disabled days.
+func (bm *BotManager) noopButton(text string) models.InlineKeyboardButton {
+	return bm.button(text, ActionPickerNoop)
+}
+
+// civilDay returns number of days since 1970-01-01 for the date of t, so a day does not depend on time zone in callback data.
+func civilDay(t time.Time) int {
+	return int(time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// dayTime returns time of civil day in loc, minutes are counted from midnight.
+func dayTime(day, minutes int, loc *time.Location) time.Time {
+	d := time.Unix(int64(day)*86400, 0).UTC()
+	return time.Date(d.Year(), d.Month(), d.Day(), 0, minutes, 0, 0, loc)
+}
+
+// monthIndex returns number of months since year 0.
+func monthIndex(t time.Time) int {
+	return t.Year()*12 + int(t.Month()) - 1
+}
@@ -0,0 +1,117 @@
+package event_reminder_bot
+
+import (
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot/models"
+)
+
+func TestCivilDay(t *testing.T) {
+	loc, err := time.LoadLocation("Asia/Vladivostok")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	at := time.Date(2026, time.March, 29, 1, 30, 0, 0, loc)
+	day := civilDay(at)
+	if got := dayTime(day, 90, loc); !got.Equal(at) {
+		t.Errorf("dayTime(civilDay(%v)) = %v", at, got)
+	}
+	if got := civilDay(at.UTC()); got != day-1 {
+		t.Errorf("civilDay in UTC = %d, want %d", got, day-1)
+	}
+}
+
+func TestCalendarRows(t *testing.T) {
+	bm := &BotManager{callbacks: NewCallbackCodec("secret")}
+	tr := i18n.For(i18n.English)
+	now := time.Date(2026, time.October, 18, 12, 0, 0, 0, time.UTC)
+
+	rows := bm.calendarRows(tr, PickerEventDate, 1, monthIndex(now), now)
+
+	// navigation, weekdays and 5 weeks of October 2026 starting on Thursday
+	if len(rows) != 7 {
+		t.Fatalf("got %d rows, want 7", len(rows))
+	}
+	if got := rows[0][1].Text; got != "October 2026" {
+		t.Errorf("title = %q", got)
+	}
+	if got := rows[0][0].Text; got != " " {
+		t.Errorf("previous month of the current one is enabled: %q", got)
+	}
+	if got := rows[2][3].Text; got != pickerDisabled {
+		t.Errorf("Oct 1 = %q, want disabled", got)
+	}
+	if got := rows[4][5].Text; got != pickerDisabled {
+		t.Errorf("Oct 17 = %q, want disabled", got)
+	}
+	if got := rows[4][6].Text; got != "18" {
+		t.Errorf("Oct 18 = %q, want enabled", got)
+	}
+
+	d, err := bm.DecodeCallback(rows[4][6].CallbackData)
+	if err != nil || d.Action != ActionPickerDay || d.Arg(2) != civilDay(now) {
+		t.Errorf("Oct 18 callback = %v, %v", d, err)
+	}
+}
+
+func TestHourRows(t *testing.T) {
+	bm := &BotManager{callbacks: NewCallbackCodec("secret")}
+	tr := i18n.For(i18n.English)
+	now := time.Date(2026, time.October, 18, 12, 56, 0, 0, time.UTC)
+
+	rows := bm.hourRows(tr, PickerEventDate, 1, civilDay(now), now)
+	if len(rows) != 5 {
+		t.Fatalf("got %d rows, want 5", len(rows))
+	}
+	if got := rows[3][0].Text; got != pickerDisabled {
+		t.Errorf("12h = %q, want disabled", got)
+	}
+	if got := rows[3][1].Text; got != "13" {
+		t.Errorf("13h = %q, want enabled", got)
+	}
+}
+
+func TestMinuteRows(t *testing.T) {
+	bm := &BotManager{callbacks: NewCallbackCodec("secret")}
+	tr := i18n.For(i18n.English)
+	now := time.Date(2026, time.October, 18, 12, 56, 0, 0, time.UTC)
+
+	rows := bm.minuteRows(tr, PickerEventDate, 1, civilDay(now), 13, now)
+	d, err := bm.DecodeCallback(rows[1][1].CallbackData)
+	if err != nil || d.Action != ActionPickerTime || d.Arg(3) != 13*60+pickerMinuteStep {
+		t.Errorf("13:%02d callback = %v, %v", pickerMinuteStep, d, err)
+	}
+}
+
+// TestPickerKeyboards builds every picker keyboard for every target, so a mismatch
+// between encoded arguments and the action registry panics here instead of in chat.
+func TestPickerKeyboards(t *testing.T) {
+	bm := &BotManager{callbacks: NewCallbackCodec("secret")}
+	tr := i18n.For(i18n.English)
+	now := time.Date(2026, time.October, 18, 12, 56, 0, 0, time.UTC)
+	day := civilDay(now)
+
+	for _, target := range []PickerTarget{PickerEventDate, PickerSnooze, PickerAdd, PickerVacation} {
+		keyboards := map[string][][]models.InlineKeyboardButton{
+			"calendar":      bm.calendarRows(tr, target, 1, monthIndex(now), now),
+			"next calendar": bm.calendarRows(tr, target, 1, monthIndex(now)+1, now),
+			"hours":         bm.hourRows(tr, target, 1, day, now),
+			"tomorrow":      bm.hourRows(tr, target, 1, day+1, now),
+			"minutes":       bm.minuteRows(tr, target, 1, day, 13, now),
+		}
+
+		for name, rows := range keyboards {
+			for _, row := range rows {
+				for _, button := range row {
+					if _, err := bm.DecodeCallback(button.CallbackData); err != nil {
+						t.Errorf("target %d %s: button %q: %v", target, name, button.Text, err)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const searchPageSize = 10
+
+// searchQuery is the last /search query of the user used for result pages.
+type searchQuery struct {
+	Query     string
+	UpdatedAt time.Time
+}
+
+func (bm *BotManager) SearchHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	tr := bm.Tr(ctx, chatID)
+	query := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/search"))
+	if query == "" {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   tr.T("search.usage"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	bm.Mu.Lock()
+	bm.searchQueries[chatID] = &searchQuery{Query: query, UpdatedAt: time.Now()}
+	bm.Mu.Unlock()
+
+	text, keyboard, err := bm.searchPage(ctx, chatID, query, 1)
+	if err != nil {
+		bm.Errorf("Ошибка поиска событий: %v", err)
+		text, keyboard = tr.T("search.error"), nil
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.SendMessage(ctx, params)
+	bm.OnError(err)
+}
+
+func (bm *BotManager) HandleSearchPage(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	page := data.Arg(0)
+	if page < 1 {
+		return
+	}
+
+	var query string
+	bm.Mu.Lock()
+	q, ok := bm.searchQueries[chatID]
+	if ok {
+		query, q.UpdatedAt = q.Query, time.Now()
+	}
+	bm.Mu.Unlock()
+
+	if !ok {
+		_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+			Text:      bm.Tr(ctx, chatID).T("search.expired"),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	text, keyboard, err := bm.searchPage(ctx, chatID, query, page)
+	if err != nil {
+		bm.Errorf("Ошибка поиска событий: %v", err)
+		return
+	}
+
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.EditMessageText(ctx, params)
+	bm.OnError(err)
+}
+
+// searchPage returns text and keyboard for one page of search results.
+func (bm *BotManager) searchPage(ctx context.Context, chatID int64, query string, page int) (string, *models.InlineKeyboardMarkup, error) {
+	dbEvents, total, err := bm.EventsRepo.SearchUserEvents(ctx, chatID, query, db.NewPager(page, searchPageSize))
+	if err != nil {
+		return "", nil, err
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	if len(dbEvents) == 0 {
+		return tr.T("search.empty", query), nil, nil
+	}
+
+	events := model.NewEvents(dbEvents)
+	start := (page - 1) * searchPageSize
+
+	var msg strings.Builder
+	msg.WriteString(tr.N("search.found", total, query, total) + "\n\n")
+
+	var buttons [][]models.InlineKeyboardButton
+	var row []models.InlineKeyboardButton
+	for i, e := range events {
+		msg.WriteString(fmt.Sprintf("%d. %s — %s\n", start+i+1, e.Text, tr.DateTime(e.DateTime)))
+
+		row = append(row, bm.button(strconv.Itoa(start+i+1), ActionEventDetail, e.ID))
+		if len(row) == 5 {
+			buttons = append(buttons, row)
+			row = []models.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		buttons = append(buttons, row)
+	}
+
+	var navRow []models.InlineKeyboardButton
+	if page > 1 {
+		navRow = append(navRow, bm.button(tr.T("button.prev"), ActionSearchPage, page-1))
+	}
+	if start+searchPageSize < total {
+		navRow = append(navRow, bm.button(tr.T("button.next"), ActionSearchPage, page+1))
+	}
+	if len(navRow) > 0 {
+		buttons = append(buttons, navRow)
+	}
+
+	return msg.String(), &models.InlineKeyboardMarkup{InlineKeyboard: buttons}, nil
+}
@@ -0,0 +1,500 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+var (
+	ErrInvalidTimeZone = errors.New("invalid time zone")
+	ErrInvalidSetting  = errors.New("invalid setting")
+)
+
+// Settings sections. Values are stored in callback data, so append only.
+const (
+	settingsLanguage = iota
+	settingsTimeZone
+	settingsDigest
+	settingsQuiet
+	settingsSnooze
+	settingsPreview
+	settingsWeekly
+	settingsCalendar
+	settingsVacation
+)
+
+// Special settings choices, other choices are indexes of section choices.
+const (
+	choiceOff    = -1
+	choiceCustom = -2
+	choiceRotate = -3
+)
+
+// maxSnoozePresets limits snooze buttons in reminder keyboard.
+const maxSnoozePresets = 6
+
+// timeZoneChoices are time zones offered in /settings, other zones are entered as text. Index is stored in callback data, so append only.
+var timeZoneChoices = []string{
+	"Europe/Kaliningrad", "Europe/Moscow", "Europe/Samara", "Asia/Yekaterinburg",
+	"Asia/Omsk", "Asia/Novosibirsk", "Asia/Irkutsk", "Asia/Vladivostok",
+	"Europe/London", "Europe/Berlin", "America/New_York", "UTC",
+}
+
+// quietChoices are quiet hours [from, to) in minutes after midnight. Index is stored in callback data, so append only.
+var quietChoices = [][2]int{
+	{22 * 60, 7 * 60},
+	{23 * 60, 8 * 60},
+	{0, 9 * 60},
+}
+
+// scheduleChoices are digest times in minutes after midnight by settings section. Index is stored in callback data, so append only.
+var scheduleChoices = map[int][]int{
+	settingsDigest:  {7 * 60, 8 * 60, 9 * 60, 10 * 60},
+	settingsPreview: {19 * 60, 20 * 60, 21 * 60, 22 * 60},
+	settingsWeekly:  {17 * 60, 18 * 60, 19 * 60, 20 * 60},
+}
+
+// scheduleAsk are prompt message keys of digest sections.
+var scheduleAsk = map[int]string{
+	settingsDigest:  "settings.digest.ask",
+	settingsPreview: "settings.preview.ask",
+	settingsWeekly:  "settings.weekly.ask",
+}
+
+// snoozeChoices are snooze presets: durations in minutes and smart targets. Index is stored in callback data, so append only.
+var snoozeChoices = []int{5, 10, 15, 30, 60, 3 * 60, 24 * 60, model.SnoozeEvening, model.SnoozeMorning, model.SnoozeNextMonday}
+
+func (bm *BotManager) SettingsHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	text, keyboard := bm.settingsMessage(ctx, chatID)
+
+	_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        text,
+		ReplyMarkup: keyboard,
+	})
+	bm.OnError(err)
+}
+
+// HandleSettings handles settings menu, section and choice callbacks.
+func (bm *BotManager) HandleSettings(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+
+	switch data.Action {
+	case ActionSettings:
+		text, keyboard = bm.settingsMessage(ctx, chatID)
+	case ActionSettingsSection:
+		text, keyboard = bm.settingsSection(ctx, chatID, data.Arg(0))
+	case ActionSettingsSet:
+		section, choice := data.Arg(0), data.Arg(1)
+		if section == settingsTimeZone && choice == choiceCustom {
+			bm.startConversation(ctx, b, chatID, messageID, StateTimeZone, db.ConversationParams{},
+				bm.Tr(ctx, chatID).T("settings.timezone.ask"))
+			return
+		}
+
+		err := bm.applySetting(ctx, chatID, section, choice)
+		switch {
+		case errors.Is(err, ErrInvalidSetting):
+			return
+		case err != nil:
+			bm.Errorf("Ошибка сохранения настроек пользователя %d: %v", chatID, err)
+			text = bm.Tr(ctx, chatID).T("settings.error")
+		case section == settingsSnooze, section == settingsCalendar:
+			text, keyboard = bm.settingsSection(ctx, chatID, section)
+		default:
+			text, keyboard = bm.settingsMessage(ctx, chatID)
+		}
+	default:
+		return
+	}
+
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err := b.EditMessageText(ctx, params)
+	bm.OnError(err)
+}
+
+// TimeZoneInput sets time zone entered by user.
+func (bm *BotManager) TimeZoneInput(ctx context.Context, b *bot.Bot, chatID int64, text string, _ db.ConversationParams) {
+	tz, err := ParseTimeZone(text)
+	if err == nil {
+		err = bm.SetTimeZone(ctx, chatID, tz)
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	switch {
+	case errors.Is(err, ErrInvalidTimeZone):
+		text = tr.T("settings.timezone.invalid")
+	case err != nil:
+		bm.Errorf("Ошибка сохранения часового пояса пользователя %d: %v", chatID, err)
+		text = tr.T("settings.error")
+	default:
+		text = tr.T("settings.timezone.set", timeZoneText(bm.UserLocation(ctx, chatID)))
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
+	bm.OnError(err)
+}
+
+// SetTimeZone sets user time zone by IANA name.
+func (bm *BotManager) SetTimeZone(ctx context.Context, chatID int64, tz string) error {
+	return bm.UpdateUser(ctx, &db.User{UserTgID: chatID, TimeZone: tz}, db.Columns.User.TimeZone)
+}
+
+// ParseTimeZone returns IANA time zone name for name like "Europe/Moscow" or UTC offset like "UTC+3" or "-5".
+func ParseTimeZone(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || strings.EqualFold(s, "Local") {
+		return "", ErrInvalidTimeZone
+	}
+
+	offset := strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(strings.ToUpper(s), "UTC"), "GMT"))
+	if offset == "" {
+		return "UTC", nil
+	}
+
+	if offset[0] == '+' || offset[0] == '-' {
+		h, err := strconv.Atoi(offset)
+		if err != nil || h < -12 || h > 14 {
+			return "", ErrInvalidTimeZone
+		}
+		if h == 0 {
+			return "UTC", nil
+		}
+
+		// Etc/GMT zones have inverted sign: Etc/GMT-3 is UTC+3.
+		return fmt.Sprintf("Etc/GMT%+d", -h), nil
+	}
+
+	loc, err := time.LoadLocation(s)
+	if err != nil {
+		return "", ErrInvalidTimeZone
+	}
+
+	return loc.String(), nil
+}
+
+func (bm *BotManager) applySetting(ctx context.Context, chatID int64, section, choice int) error {
+	user := &db.User{UserTgID: chatID}
+
+	switch section {
+	case settingsLanguage:
+		if choice < 0 || choice >= len(languageChoices) {
+			return ErrInvalidSetting
+		}
+		return bm.SetLanguage(ctx, chatID, languageChoices[choice])
+	case settingsTimeZone:
+		tz, ok := enumArg(timeZoneChoices, choice)
+		if !ok {
+			return ErrInvalidSetting
+		}
+		return bm.SetTimeZone(ctx, chatID, tz)
+	case settingsDigest, settingsPreview, settingsWeekly:
+		var minutes *int
+		if choice != choiceOff {
+			list := scheduleChoices[section]
+			if choice < 0 || choice >= len(list) {
+				return ErrInvalidSetting
+			}
+			m := list[choice]
+			minutes = &m
+		}
+
+		field, column := scheduleField(user, section)
+		*field = minutes
+		return bm.UpdateUser(ctx, user, column)
+	case settingsQuiet:
+		if choice != choiceOff {
+			if choice < 0 || choice >= len(quietChoices) {
+				return ErrInvalidSetting
+			}
+			q := quietChoices[choice]
+			user.QuietFrom, user.QuietTo = &q[0], &q[1]
+		}
+		return bm.UpdateUser(ctx, user, db.Columns.User.QuietFrom, db.Columns.User.QuietTo)
+	case settingsCalendar:
+		switch {
+		case bm.PublicURL == "":
+			return ErrInvalidSetting
+		case choice == choiceOff:
+			return bm.DisableICalFeed(ctx, chatID)
+		case choice == choiceRotate:
+			return bm.RotateICalToken(ctx, chatID)
+		}
+		return ErrInvalidSetting
+	case settingsSnooze:
+		if choice < 0 || choice >= len(snoozeChoices) {
+			return ErrInvalidSetting
+		}
+		presets := toggleSnoozePreset(bm.User(ctx, chatID).SnoozePresets, snoozeChoices[choice])
+		if len(presets) == 0 || len(presets) > maxSnoozePresets {
+			return ErrInvalidSetting
+		}
+		user.SnoozePresets = presets
+		return bm.UpdateUser(ctx, user, db.Columns.User.SnoozePresets)
+	case settingsVacation:
+		mode, ok := enumArg(vacationModeList, choice)
+		if !ok {
+			return ErrInvalidSetting
+		}
+		return bm.SetVacationMode(ctx, chatID, mode)
+	}
+
+	return ErrInvalidSetting
+}
+
+// toggleSnoozePreset adds or removes preset keeping durations sorted and smart targets after them.
+func toggleSnoozePreset(presets []int, preset int) []int {
+	if i := slices.Index(presets, preset); i >= 0 {
+		return slices.Delete(slices.Clone(presets), i, i+1)
+	}
+
+	res := append(slices.Clone(presets), preset)
+	slices.SortFunc(res, func(a, b int) int {
+		if a < 0 || b < 0 {
+			return b - a
+		}
+		return a - b
+	})
+	return res
+}
+
+// settingsMessage returns current user settings with sections keyboard.
+func (bm *BotManager) settingsMessage(ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup) {
+	u := bm.User(ctx, chatID)
+	tr := bm.Tr(ctx, chatID)
+
+	language := tr.T("language.name")
+	if u.Language == "" {
+		language = tr.T("settings.language.auto", language)
+	}
+
+	var msg strings.Builder
+	msg.WriteString(tr.T("settings.title") + "\n\n")
+	msg.WriteString(tr.T("settings.language.value", language) + "\n")
+	msg.WriteString(tr.T("settings.timezone.value", timeZoneText(u.Location)) + "\n")
+	msg.WriteString(tr.T("settings.digest.value", scheduleText(tr, u, u.DigestTime)) + "\n")
+	msg.WriteString(tr.T("settings.preview.value", scheduleText(tr, u, u.PreviewTime)) + "\n")
+	msg.WriteString(tr.T("settings.weekly.value", scheduleText(tr, u, u.WeeklyTime)) + "\n")
+	msg.WriteString(tr.T("settings.quiet.value", quietText(tr, u)) + "\n")
+	msg.WriteString(tr.T("settings.snooze.value", snoozePresetsText(tr, u)) + "\n")
+	msg.WriteString(tr.T("settings.vacation.value", tr.T(vacationModeKey(u.VacationMode))))
+	if bm.PublicURL != "" {
+		msg.WriteString("\n" + tr.T("settings.calendar.value", onOffText(tr, u.IcalToken != "")))
+	}
+
+	keyboard := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{bm.button(tr.T("settings.language.button"), ActionSettingsSection, settingsLanguage)},
+			{bm.button(tr.T("settings.timezone.button"), ActionSettingsSection, settingsTimeZone)},
+			{bm.button(tr.T("settings.digest.button"), ActionSettingsSection, settingsDigest)},
+			{bm.button(tr.T("settings.preview.button"), ActionSettingsSection, settingsPreview)},
+			{bm.button(tr.T("settings.weekly.button"), ActionSettingsSection, settingsWeekly)},
+			{bm.button(tr.T("settings.quiet.button"), ActionSettingsSection, settingsQuiet)},
+			{bm.button(tr.T("settings.snooze.button"), ActionSettingsSection, settingsSnooze)},
+			{bm.button(tr.T("settings.vacation.button"), ActionSettingsSection, settingsVacation)},
+		},
+	}
+	if bm.PublicURL != "" {
+		keyboard.InlineKeyboard = append(keyboard.InlineKeyboard,
+			[]models.InlineKeyboardButton{bm.button(tr.T("settings.calendar.button"), ActionSettingsSection, settingsCalendar)})
+	}
+
+	return msg.String(), keyboard
+}
+
+// settingsSection returns section prompt with choices keyboard.
+func (bm *BotManager) settingsSection(ctx context.Context, chatID int64, section int) (string, *models.InlineKeyboardMarkup) {
+	u := bm.User(ctx, chatID)
+	tr := bm.Tr(ctx, chatID)
+
+	var text string
+	var rows [][]models.InlineKeyboardButton
+	choice := func(selected bool, title string, i int) models.InlineKeyboardButton {
+		if selected {
+			title = "✅ " + title
+		}
+		return bm.button(title, ActionSettingsSet, section, i)
+	}
+
+	switch section {
+	case settingsLanguage:
+		text = tr.T("settings.language.ask")
+		for i, lang := range languageChoices {
+			title := tr.T("language.auto")
+			if lang != "" {
+				title = i18n.For(lang).T("language.name")
+			}
+			rows = append(rows, []models.InlineKeyboardButton{choice(u.Language == string(lang), title, i)})
+		}
+	case settingsTimeZone:
+		text = tr.T("settings.timezone.choose", timeZoneText(u.Location))
+		var row []models.InlineKeyboardButton
+		for i, tz := range timeZoneChoices {
+			row = append(row, choice(u.Location.String() == tz, tz, i))
+			if len(row) == 2 {
+				rows, row = append(rows, row), nil
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+		rows = append(rows, []models.InlineKeyboardButton{choice(false, tr.T("settings.timezone.custom"), choiceCustom)})
+	case settingsDigest, settingsPreview, settingsWeekly:
+		text = tr.T(scheduleAsk[section])
+		current := scheduleValue(u, section)
+		for i, m := range scheduleChoices[section] {
+			rows = append(rows, []models.InlineKeyboardButton{choice(current != nil && *current == m, clockText(tr, u, m), i)})
+		}
+		rows = append(rows, []models.InlineKeyboardButton{choice(current == nil, tr.T("settings.off"), choiceOff)})
+	case settingsQuiet:
+		text = tr.T("settings.quiet.ask")
+		for i, q := range quietChoices {
+			selected := u.QuietFrom != nil && u.QuietTo != nil && *u.QuietFrom == q[0] && *u.QuietTo == q[1]
+			rows = append(rows, []models.InlineKeyboardButton{choice(selected, clockText(tr, u, q[0])+" – "+clockText(tr, u, q[1]), i)})
+		}
+		rows = append(rows, []models.InlineKeyboardButton{choice(u.QuietFrom == nil, tr.T("settings.off"), choiceOff)})
+	case settingsSnooze:
+		text = tr.T("settings.snooze.ask", maxSnoozePresets)
+		var row []models.InlineKeyboardButton
+		for i, preset := range snoozeChoices {
+			title := "❌ " + snoozeTitle(tr, u, preset)
+			if slices.Contains(u.SnoozePresets, preset) {
+				title = "✅ " + snoozeTitle(tr, u, preset)
+			}
+			row = append(row, bm.button(title, ActionSettingsSet, section, i))
+			if len(row) == 2 {
+				rows, row = append(rows, row), nil
+			}
+		}
+		if len(row) > 0 {
+			rows = append(rows, row)
+		}
+	case settingsVacation:
+		text = tr.T("settings.vacation.ask")
+		for i, mode := range vacationModeList {
+			rows = append(rows, []models.InlineKeyboardButton{choice(u.VacationMode == mode, tr.T(vacationModeKey(mode)), i)})
+		}
+	case settingsCalendar:
+		if bm.PublicURL == "" {
+			return bm.settingsMessage(ctx, chatID)
+		}
+		if url := bm.ICalURL(u); url != "" {
+			text = tr.T("settings.calendar.link", url)
+			rows = append(rows,
+				[]models.InlineKeyboardButton{bm.button(tr.T("settings.calendar.rotate"), ActionSettingsSet, section, choiceRotate)},
+				[]models.InlineKeyboardButton{bm.button(tr.T("settings.calendar.disable"), ActionSettingsSet, section, choiceOff)},
+			)
+		} else {
+			text = tr.T("settings.calendar.ask")
+			rows = append(rows, []models.InlineKeyboardButton{bm.button(tr.T("settings.calendar.enable"), ActionSettingsSet, section, choiceRotate)})
+		}
+	default:
+		return bm.settingsMessage(ctx, chatID)
+	}
+
+	rows = append(rows, []models.InlineKeyboardButton{bm.button(tr.T("button.back"), ActionSettings)})
+
+	return text, &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// timeZoneText returns time zone name with current UTC offset.
+func timeZoneText(loc *time.Location) string {
+	return fmt.Sprintf("%s (UTC%s)", loc, time.Now().In(loc).Format("-07:00"))
+}
+
+// clockText formats time of day given in minutes after midnight.
+func clockText(tr *i18n.Localizer, u *model.User, minutes int) string {
+	return tr.Time(time.Date(2000, 1, 1, minutes/60, minutes%60, 0, 0, u.Location))
+}
+
+// scheduleField returns digest time field and column of settings section.
+func scheduleField(user *db.User, section int) (**int, string) {
+	switch section {
+	case settingsPreview:
+		return &user.PreviewTime, db.Columns.User.PreviewTime
+	case settingsWeekly:
+		return &user.WeeklyTime, db.Columns.User.WeeklyTime
+	default:
+		return &user.DigestTime, db.Columns.User.DigestTime
+	}
+}
+
+// scheduleValue returns user digest time of settings section.
+func scheduleValue(u *model.User, section int) *int {
+	switch section {
+	case settingsPreview:
+		return u.PreviewTime
+	case settingsWeekly:
+		return u.WeeklyTime
+	default:
+		return u.DigestTime
+	}
+}
+
+func scheduleText(tr *i18n.Localizer, u *model.User, minutes *int) string {
+	if minutes == nil {
+		return tr.T("settings.off")
+	}
+
+	return clockText(tr, u, *minutes)
+}
+
+func onOffText(tr *i18n.Localizer, on bool) string {
+	if on {
+		return tr.T("settings.on")
+	}
+
+	return tr.T("settings.off")
+}
+
+func quietText(tr *i18n.Localizer, u *model.User) string {
+	if u.QuietFrom == nil || u.QuietTo == nil {
+		return tr.T("settings.off")
+	}
+
+	return clockText(tr, u, *u.QuietFrom) + " – " + clockText(tr, u, *u.QuietTo)
+}
+
+// vacationModeKey returns message key of vacation mode title.
+func vacationModeKey(mode string) string {
+	if mode == db.VacationModeShift {
+		return "settings.vacation.shift"
+	}
+
+	return "settings.vacation.summary"
+}
+
+func snoozePresetsText(tr *i18n.Localizer, u *model.User) string {
+	res := make([]string, 0, len(u.SnoozePresets))
+	for _, preset := range u.SnoozePresets {
+		res = append(res, snoozeTitle(tr, u, preset))
+	}
+
+	return strings.Join(res, ", ")
+}
@@ -0,0 +1,59 @@
+package event_reminder_bot
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"event-reminder-bot/pkg/model"
+)
+
+func TestParseTimeZone(t *testing.T) {
+	tests := map[string]string{
+		"Europe/Moscow": "Europe/Moscow",
+		" Asia/Tbilisi": "Asia/Tbilisi",
+		"UTC":           "UTC",
+		"gmt":           "UTC",
+		"UTC+4":         "Etc/GMT-4",
+		"utc -5":        "Etc/GMT+5",
+		"+3":            "Etc/GMT-3",
+		"UTC+0":         "UTC",
+		"UTC+14":        "Etc/GMT-14",
+	}
+
+	for s, want := range tests {
+		got, err := ParseTimeZone(s)
+		if err != nil || got != want {
+			t.Errorf("%q: got %q, %v, want %q", s, got, err, want)
+		}
+	}
+
+	for _, s := range []string{"", "Local", "Moscow", "UTC+15", "UTC-13", "UTC+3:30"} {
+		if _, err := ParseTimeZone(s); !errors.Is(err, ErrInvalidTimeZone) {
+			t.Errorf("%q: got %v, want %v", s, err, ErrInvalidTimeZone)
+		}
+	}
+}
+
+func TestToggleSnoozePreset(t *testing.T) {
+	presets := []int{5, 10}
+
+	if got := toggleSnoozePreset(presets, 60); !slices.Equal(got, []int{5, 10, 60}) {
+		t.Errorf("add: got %v", got)
+	}
+	if got := toggleSnoozePreset(presets, 1); !slices.Equal(got, []int{1, 5, 10}) {
+		t.Errorf("add sorted: got %v", got)
+	}
+	if got := toggleSnoozePreset(presets, 5); !slices.Equal(got, []int{10}) {
+		t.Errorf("remove: got %v", got)
+	}
+	if got := toggleSnoozePreset([]int{5, model.SnoozeNextMonday}, model.SnoozeEvening); !slices.Equal(got, []int{5, model.SnoozeEvening, model.SnoozeNextMonday}) {
+		t.Errorf("add smart: got %v", got)
+	}
+	if got := toggleSnoozePreset([]int{model.SnoozeEvening}, 60); !slices.Equal(got, []int{60, model.SnoozeEvening}) {
+		t.Errorf("add duration: got %v", got)
+	}
+	if !slices.Equal(presets, []int{5, 10}) {
+		t.Errorf("presets changed: %v", presets)
+	}
+}
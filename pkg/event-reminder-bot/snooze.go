@@ -0,0 +1,127 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// snoozeTitle returns snooze preset name: duration or smart target with its time.
+func snoozeTitle(tr *i18n.Localizer, u *model.User, preset int) string {
+	switch preset {
+	case model.SnoozeEvening:
+		return tr.T("snooze.preset.evening", clockText(tr, u, model.EveningHour*60))
+	case model.SnoozeMorning:
+		return tr.T("snooze.preset.morning", clockText(tr, u, model.MorningHour*60))
+	case model.SnoozeNextMonday:
+		return tr.T("snooze.preset.monday", clockText(tr, u, model.MorningHour*60))
+	}
+
+	return FormatDuration(tr, time.Duration(preset)*time.Minute)
+}
+
+// snoozeRows returns user snooze presets as buttons with action(eventID, preset), two buttons per row.
+func (bm *BotManager) snoozeRows(ctx context.Context, chatID int64, action callback.Action, eventID int) [][]models.InlineKeyboardButton {
+	u := bm.User(ctx, chatID)
+	tr := bm.Tr(ctx, chatID)
+
+	var rows [][]models.InlineKeyboardButton
+	for i, preset := range u.SnoozePresets {
+		button := bm.button(tr.T("reminder.snooze", snoozeTitle(tr, u, preset)), action, eventID, preset)
+		if i%2 == 0 {
+			rows = append(rows, []models.InlineKeyboardButton{button})
+		} else {
+			rows[len(rows)-1] = append(rows[len(rows)-1], button)
+		}
+	}
+
+	return rows
+}
+
+// SnoozeTime returns new time of reminder snoozed with preset from now in user time zone.
+func (bm *BotManager) SnoozeTime(ctx context.Context, chatID int64, preset int) (time.Time, bool) {
+	now := time.Now().In(bm.UserLocation(ctx, chatID))
+	return model.SnoozeAt(preset, now, now)
+}
+
+// HandlePostponePreset moves event with snooze preset. Durations are added to event time if it is in the future.
+func (bm *BotManager) HandlePostponePreset(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	eventID, preset := data.Arg(0), data.Arg(1)
+
+	event, err := bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
+		bm.sendAccessError(ctx, b, chatID, err)
+		return
+	}
+
+	now := time.Now().In(bm.UserLocation(ctx, chatID))
+	from := now
+	if event.SendAt.After(now) {
+		from = event.SendAt
+	}
+
+	newTime, ok := model.SnoozeAt(preset, from, now)
+	if !ok {
+		return
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	text := tr.T("event.date_changed", tr.DateTime(newTime))
+	if err = bm.SnoozeEvent(ctx, eventID, chatID, newTime); err != nil {
+		switch {
+		case errors.Is(err, ErrInactive):
+			text = tr.T("event.inactive")
+		default:
+			bm.Errorf("Ошибка переноса события %d: %v", eventID, err)
+			text = tr.T("event.update_error")
+		}
+	}
+
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	})
+	bm.OnError(err)
+}
+
+// SnoozeOccurrence schedules one more delivery of periodic event occurrence at newTime. Event sendAt is not changed.
+func (bm *BotManager) SnoozeOccurrence(ctx context.Context, chatID int64, eventID int, newTime time.Time) error {
+	event, err := bm.UserEvent(ctx, chatID, eventID)
+	if err != nil {
+		return err
+	}
+
+	if event.StatusID != db.StatusEnabled {
+		return ErrInactive
+	}
+
+	if newTime.Before(time.Now()) {
+		return ErrPastDate
+	}
+
+	_, err = bm.EventsRepo.AddSnooze(ctx, &db.Snooze{
+		EventID:  eventID,
+		UserTgID: chatID,
+		SendAt:   newTime,
+	})
+
+	return err
+}
+
+// CompleteOccurrence marks periodic event occurrence as handled and cancels its pending snoozes.
+func (bm *BotManager) CompleteOccurrence(ctx context.Context, chatID int64, eventID int) error {
+	if _, err := bm.UserEvent(ctx, chatID, eventID); err != nil {
+		return err
+	}
+
+	return bm.EventsRepo.DeleteEventSnoozes(ctx, eventID)
+}
@@ -0,0 +1,71 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+)
+
+// TestDBSnoozeOccurrence checks that snoozed occurrence does not move the series and is cancelled when it is done.
+func TestDBSnoozeOccurrence(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Minute)
+	tests := []struct {
+		name     string
+		statusID int
+		snoozeAt time.Time
+		wantErr  error
+		snoozes  int
+	}{
+		{"active series", db.StatusEnabled, now.Add(time.Hour), nil, 1},
+		{"past time", db.StatusEnabled, now.Add(-time.Hour), ErrPastDate, 0},
+		{"paused series", db.StatusDisabled, now.Add(time.Hour), ErrInactive, 0},
+	}
+
+	for _, tt := range tests {
+		event, err := bm.EventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID:    ownerID,
+			Message:     tt.name,
+			SendAt:      now.Add(24 * time.Hour),
+			StatusID:    tt.statusID,
+			Weekdays:    []int{},
+			Periodicity: test.Ptr(db.PeriodicityDay),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = bm.SnoozeOccurrence(ctx, ownerID, event.ID, tt.snoozeAt); !errors.Is(err, tt.wantErr) {
+			t.Errorf("%s: got error %v, want %v", tt.name, err, tt.wantErr)
+		}
+
+		got, err := bm.EventsRepo.EventByID(ctx, event.ID)
+		if err != nil {
+			t.Fatal(err)
+		} else if !got.SendAt.Equal(event.SendAt) {
+			t.Errorf("%s: series moved to %v", tt.name, got.SendAt)
+		}
+
+		snoozes, err := bm.EventsRepo.CountSnoozes(ctx, &db.SnoozeSearch{EventID: &event.ID})
+		if err != nil {
+			t.Fatal(err)
+		} else if snoozes != tt.snoozes {
+			t.Errorf("%s: got %d snoozes, want %d", tt.name, snoozes, tt.snoozes)
+		}
+
+		if err = bm.CompleteOccurrence(ctx, ownerID, event.ID); err != nil {
+			t.Fatal(err)
+		}
+		if snoozes, err = bm.EventsRepo.CountSnoozes(ctx, &db.SnoozeSearch{EventID: &event.ID}); err != nil {
+			t.Fatal(err)
+		} else if snoozes != 0 {
+			t.Errorf("%s: %d snoozes left after done", tt.name, snoozes)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// maxEventTags limits number of tags parsed from one event.
+	maxEventTags = 10
+	// maxTagLength is tags.title length.
+	maxTagLength = 64
+	// maxTagButtons limits tag picker size.
+	maxTagButtons = 12
+)
+
+var (
+	ErrTagNotFound = errors.New("tag not found")
+
+	tagRe = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+	// tagSpaceRe matches hashtag with preceding spaces, so removing it keeps the rest of the text as is.
+	tagSpaceRe = regexp.MustCompile(`[ \t]*#[\p{L}\p{N}_]+`)
+)
+
+// ParseTags returns unique lowercase hashtags from text without "#".
+func ParseTags(text string) []string {
+	var tags []string
+	for _, m := range tagRe.FindAllStringSubmatch(text, -1) {
+		tag := strings.ToLower(m[1])
+		if utf8.RuneCountInString(tag) > maxTagLength || slices.Contains(tags, tag) {
+			continue
+		}
+
+		tags = append(tags, tag)
+		if len(tags) == maxEventTags {
+			break
+		}
+	}
+
+	return tags
+}
+
+// SyncTags stores hashtags from event title and notes as event tags.
+func (bm *BotManager) SyncTags(ctx context.Context, event *db.Event) {
+	text := event.Message
+	if event.Notes != nil {
+		text += "\n" + *event.Notes
+	}
+
+	if err := bm.EventsRepo.SetEventTags(ctx, event, ParseTags(text)); err != nil {
+		bm.Errorf("Ошибка сохранения тегов события %d: %v", event.ID, err)
+	}
+}
+
+// tagFromArgs extracts "#tag" from command arguments. It returns tag ID (0 if no tag) and the rest of arguments.
+func (bm *BotManager) tagFromArgs(ctx context.Context, chatID int64, args string) (int, string, error) {
+	var (
+		title string
+		rest  []string
+	)
+	for _, f := range strings.Fields(args) {
+		if strings.HasPrefix(f, "#") && title == "" {
+			title = strings.ToLower(strings.TrimPrefix(f, "#"))
+			continue
+		}
+		rest = append(rest, f)
+	}
+
+	if title == "" {
+		return 0, strings.Join(rest, " "), nil
+	}
+
+	tag, err := bm.EventsRepo.TagByTitle(ctx, chatID, title)
+	if err != nil {
+		return 0, "", err
+	}
+	if tag == nil {
+		return 0, "", ErrTagNotFound
+	}
+
+	return tag.ID, strings.Join(rest, " "), nil
+}
+
+// tagsKeyboard returns tag picker rows. Each button callback is action with args and tag ID, "all" button uses tag ID 0.
+func (bm *BotManager) tagsKeyboard(tr *i18n.Localizer, tags []db.Tag, selectedID int, action callback.Action, args ...int) [][]models.InlineKeyboardButton {
+	if len(tags) == 0 {
+		return nil
+	}
+	if len(tags) > maxTagButtons {
+		tags = tags[:maxTagButtons]
+	}
+
+	allText := "🏷 " + tr.T("tags.all")
+	if selectedID == 0 {
+		allText = "✅ " + tr.T("tags.all")
+	}
+
+	var rows [][]models.InlineKeyboardButton
+	row := []models.InlineKeyboardButton{bm.button(allText, action, append(args, 0)...)}
+	for _, t := range tags {
+		text := "#" + t.Title
+		if t.ID == selectedID {
+			text = "✅ " + text
+		}
+
+		row = append(row, bm.button(text, action, append(args, t.ID)...))
+		if len(row) == 3 {
+			rows = append(rows, row)
+			row = []models.InlineKeyboardButton{}
+		}
+	}
+	if len(row) > 0 {
+		rows = append(rows, row)
+	}
+
+	return rows
+}
+
+// tagErrorText returns user message for tagFromArgs error.
+func tagErrorText(tr *i18n.Localizer, err error) string {
+	if errors.Is(err, ErrTagNotFound) {
+		return tr.T("tags.not_found")
+	}
+	return tr.T("tags.load_error")
+}
@@ -0,0 +1,117 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+
+	"event-reminder-bot/pkg/db"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// RPCPath is URL path of JSON-RPC API on HTTP server.
+	RPCPath = "/v1/rpc/"
+
+	// apiTokenBytes is random bytes count of API token.
+	apiTokenBytes = 32
+)
+
+// TokenHandler issues, replaces or revokes personal API token: /token [new | revoke].
+// Only token hash is stored, so the token is shown once.
+func (bm *BotManager) TokenHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	arg := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/token")))
+	tr := bm.Tr(ctx, chatID)
+
+	reply := func(text string) {
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		})
+		bm.OnError(err)
+	}
+
+	u, err := bm.EventsRepo.UserByID(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки пользователя %d: %v", chatID, err)
+		reply(tr.T("token.error"))
+		return
+	}
+	hasToken := u != nil && u.APITokenHash != nil
+
+	switch {
+	case arg == "revoke":
+		if !hasToken {
+			reply(tr.T("token.none"))
+			return
+		}
+		if err = bm.UpdateUser(ctx, &db.User{UserTgID: chatID}, db.Columns.User.APITokenHash); err != nil {
+			bm.Errorf("Ошибка отзыва токена пользователя %d: %v", chatID, err)
+			reply(tr.T("token.error"))
+			return
+		}
+		reply(tr.T("token.revoked"))
+	case arg == "" && hasToken:
+		reply(tr.T("token.active"))
+	case arg == "" || arg == "new":
+		token, err := bm.IssueAPIToken(ctx, chatID)
+		if err != nil {
+			bm.Errorf("Ошибка выпуска токена пользователя %d: %v", chatID, err)
+			reply(tr.T("token.error"))
+			return
+		}
+
+		text := tr.T("token.issued", token)
+		if bm.PublicURL != "" {
+			text += "\n\n" + tr.T("token.url", strings.TrimSuffix(bm.PublicURL, "/")+RPCPath)
+		}
+		reply(text)
+	default:
+		reply(tr.T("token.usage"))
+	}
+}
+
+// IssueAPIToken returns new API token of the user, the previous token stops working.
+func (bm *BotManager) IssueAPIToken(ctx context.Context, chatID int64) (string, error) {
+	b := make([]byte, apiTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(b)
+	hash := apiTokenHash(token)
+
+	// user row is created by TouchUser on every update, so it exists here
+	err := bm.UpdateUser(ctx, &db.User{UserTgID: chatID, APITokenHash: &hash}, db.Columns.User.APITokenHash)
+
+	return token, err
+}
+
+// APITokenUser returns ID of the user with API token. ErrNotFound is returned for unknown or revoked tokens.
+func (bm *BotManager) APITokenUser(ctx context.Context, token string) (int64, error) {
+	if token == "" {
+		return 0, ErrNotFound
+	}
+
+	hash := apiTokenHash(token)
+	u, err := bm.EventsRepo.OneUser(ctx, &db.UserSearch{APITokenHash: &hash})
+	if err != nil {
+		return 0, err
+	} else if u == nil {
+		return 0, ErrNotFound
+	}
+
+	return u.UserTgID, nil
+}
+
+// apiTokenHash returns hex SHA-256 of token. Tokens are random, so salt is not needed.
+func apiTokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,281 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// DefaultTrashRetention is used when retention is not set in config.
+	DefaultTrashRetention = 30 * 24 * time.Hour
+
+	undoWindow    = 5 * time.Minute
+	trashPageSize = 20
+)
+
+var (
+	ErrUndoExpired   = errors.New("undo expired")
+	ErrPeriodicLimit = errors.New("periodic limit")
+)
+
+// trashEvent moves event to trash. Event owner must be checked by caller.
+func (bm *BotManager) trashEvent(ctx context.Context, id int) error {
+	deleted, err := bm.EventsRepo.TrashEvent(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return ErrNotFound
+	}
+
+	return nil
+}
+
+// RestoreEvent returns event from trash with the status it had before deletion. If window is set,
+// only events deleted within window could be restored. Periodic events are moved to the next future occurrence,
+// past one-off events are restored paused, so they are not sent again until user sets a new date.
+func (bm *BotManager) RestoreEvent(ctx context.Context, chatID int64, id int, window time.Duration) (*db.Event, error) {
+	var event *db.Event
+	err := bm.EventsRepo.RunInTx(ctx, func(r db.EventsRepo) error {
+		e, err := r.TrashedEventByID(ctx, chatID, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if e == nil || e.DeletedAt == nil || e.DeletedAt.Before(now.Add(-bm.trashRetention())) {
+			return ErrNotFound
+		}
+
+		if window > 0 && e.DeletedAt.Before(now.Add(-window)) {
+			return ErrUndoExpired
+		}
+
+		if e.Periodicity != nil {
+			count, err := r.CountUserPeriodicEvents(ctx, chatID)
+			if err != nil {
+				return err
+			}
+			if count >= MaxPeriodic {
+				return ErrPeriodicLimit
+			}
+		}
+
+		e.StatusID = db.StatusEnabled
+		if e.PreviousStatusID != nil {
+			e.StatusID = *e.PreviousStatusID
+		}
+
+		if next := model.NextAfter(model.NewReminderEvent(e), now); next != nil {
+			e.SendAt = *next
+		} else if !e.SendAt.After(now) {
+			e.StatusID = db.StatusDisabled
+		}
+
+		restored, err := r.RestoreEvent(ctx, e)
+		if err != nil {
+			return err
+		}
+		if !restored {
+			return ErrNotFound
+		}
+
+		event = e
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// restoredText returns confirmation of restored event, paused events are mentioned explicitly.
+func restoredText(tr *i18n.Localizer, event *db.Event) string {
+	switch {
+	case event.StatusID != db.StatusDisabled:
+		return tr.T("trash.restored", event.Message)
+	case event.Periodicity == nil && !event.SendAt.After(time.Now()):
+		return tr.T("trash.restored_past", event.Message)
+	}
+
+	return tr.T("trash.restored_paused", event.Message)
+}
+
+// PurgeTrash removes events deleted earlier than trash retention period.
+func (bm *BotManager) PurgeTrash(ctx context.Context) error {
+	count, err := bm.EventsRepo.PurgeDeletedEvents(ctx, time.Now().Add(-bm.trashRetention()))
+	if err != nil {
+		return fmt.Errorf("ошибка очистки корзины: %w", err)
+	}
+
+	if count > 0 {
+		bm.Printf("Из корзины удалено событий: %d", count)
+	}
+
+	return nil
+}
+
+func (bm *BotManager) trashRetention() time.Duration {
+	if bm.TrashRetention > 0 {
+		return bm.TrashRetention
+	}
+
+	return DefaultTrashRetention
+}
+
+func (bm *BotManager) TrashHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+
+	text, keyboard, err := bm.trashList(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка получения корзины: %v", err)
+		text, keyboard = bm.Tr(ctx, chatID).T("trash.load_error"), nil
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.SendMessage(ctx, params)
+	bm.OnError(err)
+}
+
+func (bm *BotManager) trashList(ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup, error) {
+	retention := bm.trashRetention()
+	events, err := bm.EventsRepo.TrashedEvents(ctx, chatID, time.Now().Add(-retention), db.NewPager(1, trashPageSize))
+	if err != nil {
+		return "", nil, err
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	days := tr.N("trash.days", int(retention.Hours()/24))
+	if len(events) == 0 {
+		return tr.T("trash.empty", days), nil, nil
+	}
+
+	var msg strings.Builder
+	msg.WriteString(tr.T("trash.title", days) + "\n\n")
+
+	var keyboard [][]models.InlineKeyboardButton
+	for i, e := range events {
+		event := model.NewEvent(&e)
+		msg.WriteString(fmt.Sprintf("%d. %s\n", i+1, event.Text))
+		msg.WriteString(fmt.Sprintf("   📅 %s\n", tr.DateTime(event.DateTime)))
+		if periodicity := periodicityLine(tr, event.Periodicity, event.Weekdays); periodicity != "" {
+			msg.WriteString("   " + periodicity + "\n")
+		}
+		if e.DeletedAt != nil {
+			msg.WriteString("   " + tr.T("trash.deleted_at", tr.DateTime(*e.DeletedAt)) + "\n")
+		}
+		msg.WriteString("\n")
+
+		keyboard = append(keyboard, []models.InlineKeyboardButton{
+			bm.button(tr.T("trash.restore", i+1), ActionRestore, e.ID),
+		})
+	}
+
+	return msg.String(), &models.InlineKeyboardMarkup{InlineKeyboard: keyboard}, nil
+}
+
+// UndoKeyboard returns keyboard with undo button for delete confirmations.
+func (bm *BotManager) UndoKeyboard(tr *i18n.Localizer, eventID int) *models.InlineKeyboardMarkup {
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{bm.button(tr.T("trash.undo"), ActionUndo, eventID)},
+		},
+	}
+}
+
+func (bm *BotManager) HandleUndo(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	tr := bm.Tr(ctx, chatID)
+	event, err := bm.RestoreEvent(ctx, chatID, data.Arg(0), undoWindow)
+	if err != nil {
+		bm.logRestoreError(err)
+		_, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+			Text:      restoreErrorText(tr, err),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	_, err = b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      restoredText(tr, event),
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) HandleRestore(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	tr := bm.Tr(ctx, chatID)
+	event, err := bm.RestoreEvent(ctx, chatID, data.Arg(0), 0)
+	if err != nil {
+		bm.logRestoreError(err)
+		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   restoreErrorText(tr, err),
+		})
+		bm.OnError(err)
+		return
+	}
+
+	text, keyboard, err := bm.trashList(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка получения корзины: %v", err)
+	} else {
+		params := &bot.EditMessageTextParams{
+			ChatID:    chatID,
+			MessageID: messageID,
+			Text:      text,
+		}
+		if keyboard != nil {
+			params.ReplyMarkup = keyboard
+		}
+		_, err = b.EditMessageText(ctx, params)
+		bm.OnError(err)
+	}
+
+	_, err = b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   restoredText(tr, event),
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) logRestoreError(err error) {
+	if !errors.Is(err, ErrUndoExpired) && !errors.Is(err, ErrNotFound) && !errors.Is(err, ErrPeriodicLimit) {
+		bm.Errorf("Ошибка восстановления события: %v", err)
+	}
+}
+
+func restoreErrorText(tr *i18n.Localizer, err error) string {
+	switch {
+	case errors.Is(err, ErrUndoExpired):
+		return tr.T("trash.undo_expired")
+	case errors.Is(err, ErrNotFound):
+		return tr.T("trash.not_found")
+	case errors.Is(err, ErrPeriodicLimit):
+		return tr.T("periodicity.limit", MaxPeriodic)
+	default:
+		return tr.T("trash.restore_error")
+	}
+}
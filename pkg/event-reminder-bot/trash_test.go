@@ -0,0 +1,66 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+)
+
+// TestDBRestoreEvent checks that restored events get their previous status and past one-off events are not resent.
+func TestDBRestoreEvent(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Minute)
+	tests := []struct {
+		name        string
+		periodicity *string
+		statusID    int
+		sendAt      time.Time
+		wantStatus  int
+		wantSendAt  time.Time
+	}{
+		{"future one-off", nil, db.StatusEnabled, now.Add(time.Hour), db.StatusEnabled, now.Add(time.Hour)},
+		{"past one-off", nil, db.StatusEnabled, now.Add(-time.Hour), db.StatusDisabled, now.Add(-time.Hour)},
+		{"paused series", test.Ptr(db.PeriodicityDay), db.StatusDisabled, now.Add(time.Hour), db.StatusDisabled, now.Add(time.Hour)},
+		{"past series", test.Ptr(db.PeriodicityDay), db.StatusEnabled, now.Add(-time.Hour), db.StatusEnabled, now.Add(23 * time.Hour)},
+	}
+
+	for _, tt := range tests {
+		event, err := bm.EventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID:    ownerID,
+			Message:     tt.name,
+			SendAt:      tt.sendAt,
+			StatusID:    tt.statusID,
+			Weekdays:    []int{},
+			Periodicity: tt.periodicity,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = bm.DeleteEventByID(ctx, ownerID, event.ID); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = bm.RestoreEvent(ctx, ownerID, event.ID, undoWindow); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := bm.EventsRepo.EventByID(ctx, event.ID)
+		switch {
+		case err != nil:
+			t.Fatal(err)
+		case got == nil:
+			t.Fatalf("%s: event is not restored", tt.name)
+		case got.StatusID != tt.wantStatus:
+			t.Errorf("%s: got status %d, want %d", tt.name, got.StatusID, tt.wantStatus)
+		case !got.SendAt.Equal(tt.wantSendAt):
+			t.Errorf("%s: got sendAt %v, want %v", tt.name, got.SendAt, tt.wantSendAt)
+		case got.DeletedAt != nil || got.PreviousStatusID != nil:
+			t.Errorf("%s: trash fields are not cleared", tt.name)
+		}
+	}
+}
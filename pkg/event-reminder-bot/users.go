@@ -0,0 +1,112 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot/models"
+)
+
+const (
+	// lastSeenInterval is how often last seen time of active user is saved.
+	lastSeenInterval = time.Hour
+
+	maxUserNameLength = 128
+)
+
+// User returns user settings, unknown users get default settings.
+// Settings are cached, so handlers can call it on every update.
+func (bm *BotManager) User(ctx context.Context, chatID int64) *model.User {
+	bm.Mu.RLock()
+	u, ok := bm.users[chatID]
+	bm.Mu.RUnlock()
+	if ok {
+		return u
+	}
+
+	row, err := bm.EventsRepo.UserByID(ctx, chatID)
+	if err != nil {
+		bm.Errorf("Ошибка загрузки пользователя %d: %v", chatID, err)
+		return model.NewDefaultUser(chatID)
+	}
+
+	u = model.NewUser(row)
+	if u == nil {
+		u = model.NewDefaultUser(chatID)
+	}
+	bm.cacheUser(u)
+
+	return u
+}
+
+// UserLocation returns time zone of the user.
+func (bm *BotManager) UserLocation(ctx context.Context, chatID int64) *time.Location {
+	return bm.User(ctx, chatID).Location
+}
+
+// TouchUser saves Telegram profile of the user and last seen time.
+// DB is updated only if profile is changed or last seen time is outdated.
+func (bm *BotManager) TouchUser(ctx context.Context, chatID int64, from *models.User) {
+	name := strings.TrimSpace(from.FirstName + " " + from.LastName)
+	if name == "" {
+		name = from.Username
+	}
+	if utf8.RuneCountInString(name) > maxUserNameLength {
+		name = string([]rune(name)[:maxUserNameLength])
+	}
+
+	bm.Mu.RLock()
+	u, ok := bm.users[chatID]
+	bm.Mu.RUnlock()
+	if ok && u.Name == name && u.LanguageCode == from.LanguageCode && time.Since(u.LastSeenAt) < lastSeenInterval {
+		return
+	}
+
+	row := &db.User{UserTgID: chatID}
+	if name != "" {
+		row.Name = &name
+	}
+	if from.LanguageCode != "" {
+		row.LanguageCode = &from.LanguageCode
+	}
+
+	row, err := bm.EventsRepo.TouchUser(ctx, row)
+	if err != nil {
+		bm.Errorf("Ошибка сохранения пользователя %d: %v", chatID, err)
+		return
+	}
+
+	bm.cacheUser(model.NewUser(row))
+}
+
+// UpdateUser saves user settings columns and resets cached settings.
+func (bm *BotManager) UpdateUser(ctx context.Context, user *db.User, columns ...string) error {
+	_, err := bm.EventsRepo.UpdateUser(ctx, user, db.WithColumns(columns...))
+	if err != nil {
+		return err
+	}
+
+	bm.forgetUser(user.UserTgID)
+
+	return nil
+}
+
+// forgetUser drops cached user settings, so they are reloaded from DB.
+func (bm *BotManager) forgetUser(userTgID int64) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	delete(bm.users, userTgID)
+}
+
+func (bm *BotManager) cacheUser(u *model.User) {
+	bm.Mu.Lock()
+	defer bm.Mu.Unlock()
+
+	bm.users[u.ID] = u
+}
@@ -0,0 +1,270 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/callback"
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/i18n"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+var (
+	ErrInvalidVacation = errors.New("invalid vacation")
+	ErrNoVacation      = errors.New("no vacation")
+)
+
+// vacationOff are command arguments to cancel vacation.
+var vacationOff = []string{"off", "stop", "выкл", "стоп", "отмена"}
+
+// SetVacation replaces current user vacation with a new one for days [from, to] in user time zone.
+// Missed one-off events are handled according to user vacation mode setting.
+func (bm *BotManager) SetVacation(ctx context.Context, chatID int64, from, to time.Time) (*db.Vacation, error) {
+	endsAt := to.AddDate(0, 0, 1)
+	if !endsAt.After(from) || !endsAt.After(time.Now()) {
+		return nil, ErrInvalidVacation
+	}
+
+	if err := bm.CancelVacation(ctx, chatID); err != nil && !errors.Is(err, ErrNoVacation) {
+		return nil, err
+	}
+
+	return bm.EventsRepo.AddVacation(ctx, &db.Vacation{
+		UserTgID: chatID,
+		StartsAt: from,
+		EndsAt:   endsAt,
+		StatusID: db.StatusEnabled,
+	})
+}
+
+// CancelVacation removes current or planned user vacation.
+// Periodic events of started vacation are moved back from vacation end to their next occurrence.
+func (bm *BotManager) CancelVacation(ctx context.Context, chatID int64) error {
+	vacation, err := bm.EventsRepo.UserVacation(ctx, chatID)
+	if err != nil {
+		return err
+	}
+	if vacation == nil {
+		return ErrNoVacation
+	}
+
+	if _, err = bm.EventsRepo.DeleteVacation(ctx, vacation.ID); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if vacation.StartsAt.After(now) {
+		return nil
+	}
+
+	events, err := bm.EventsRepo.VacationSkippedEvents(ctx, chatID)
+	if err != nil {
+		return err
+	}
+
+	// series moved by the vacation go back to the skipped occurrences,
+	// events rescheduled by user since then keep their time
+	for _, event := range events {
+		skipped := model.NewReminderEvent(&event)
+		skipped.DateTime = *event.SkippedAt
+		if shifted := model.NextAfter(skipped, vacation.EndsAt); shifted != nil && shifted.Equal(event.SendAt) {
+			if next := model.NextAfter(skipped, now); next != nil {
+				event.SendAt = *next
+			}
+		}
+
+		event.SkippedAt = nil
+		if _, err = bm.EventsRepo.UpdateEvent(ctx, &event, db.WithColumns(db.Columns.Event.SendAt, db.Columns.Event.SkippedAt)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// SetVacationMode sets how one-off events missed during vacations are handled.
+func (bm *BotManager) SetVacationMode(ctx context.Context, chatID int64, mode string) error {
+	if !slices.Contains(vacationModeList, mode) {
+		return ErrInvalidSetting
+	}
+
+	return bm.UpdateUser(ctx, &db.User{UserTgID: chatID, VacationMode: mode}, db.Columns.User.VacationMode)
+}
+
+func (bm *BotManager) VacationHandler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	chatID := update.Message.Chat.ID
+	tr := bm.Tr(ctx, chatID)
+	args := strings.Fields(strings.ToLower(strings.TrimPrefix(update.Message.Text, "/vacation")))
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+	var err error
+
+	switch {
+	case len(args) == 0:
+		text, keyboard, err = bm.vacationMessage(ctx, chatID)
+	case len(args) == 1 && slices.Contains(vacationOff, args[0]):
+		err = bm.CancelVacation(ctx, chatID)
+		text = tr.T("vacation.cancelled")
+	case len(args) == 2:
+		text, keyboard, err = bm.setVacationFromArgs(ctx, chatID, args)
+	default:
+		err = ErrInvalidVacation
+	}
+
+	if err != nil {
+		text, keyboard = bm.vacationErrorText(tr, err), nil
+	}
+
+	params := &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.SendMessage(ctx, params)
+	bm.OnError(err)
+}
+
+func (bm *BotManager) setVacationFromArgs(ctx context.Context, chatID int64, args []string) (string, *models.InlineKeyboardMarkup, error) {
+	loc := bm.UserLocation(ctx, chatID)
+	from, err := time.ParseInLocation(time.DateOnly, args[0], loc)
+	if err != nil {
+		return "", nil, ErrInvalidVacation
+	}
+	to, err := time.ParseInLocation(time.DateOnly, args[1], loc)
+	if err != nil {
+		return "", nil, ErrInvalidVacation
+	}
+
+	if _, err = bm.SetVacation(ctx, chatID, from, to); err != nil {
+		return "", nil, err
+	}
+
+	return bm.vacationMessage(ctx, chatID)
+}
+
+// HandleVacation handles vacation cancel and mode callbacks. Mode buttons are left in old messages only,
+// mode is a user setting now.
+func (bm *BotManager) HandleVacation(ctx context.Context, b *bot.Bot, data callback.Data, chatID int64, messageID int) {
+	var err error
+	switch data.Action {
+	case ActionVacationCancel:
+		err = bm.CancelVacation(ctx, chatID)
+	case ActionVacationMode:
+		mode, ok := enumArg(vacationModeList, data.Arg(0))
+		if !ok {
+			return
+		}
+		err = bm.SetVacationMode(ctx, chatID, mode)
+	default:
+		return
+	}
+
+	var text string
+	var keyboard *models.InlineKeyboardMarkup
+	if err == nil {
+		text, keyboard, err = bm.vacationMessage(ctx, chatID)
+	}
+	if err != nil {
+		text, keyboard = bm.vacationErrorText(bm.Tr(ctx, chatID), err), nil
+	}
+
+	params := &bot.EditMessageTextParams{
+		ChatID:    chatID,
+		MessageID: messageID,
+		Text:      text,
+	}
+	if keyboard != nil {
+		params.ReplyMarkup = keyboard
+	}
+
+	_, err = b.EditMessageText(ctx, params)
+	bm.OnError(err)
+}
+
+// vacationMessage returns current vacation description with settings keyboard.
+func (bm *BotManager) vacationMessage(ctx context.Context, chatID int64) (string, *models.InlineKeyboardMarkup, error) {
+	vacation, err := bm.EventsRepo.UserVacation(ctx, chatID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	tr := bm.Tr(ctx, chatID)
+	pickButton := bm.button(tr.T("vacation.pick_dates"), ActionPickerMonth, int(PickerVacation), 0, monthIndex(time.Now().In(bm.UserLocation(ctx, chatID))))
+	if vacation == nil {
+		keyboard := &models.InlineKeyboardMarkup{
+			InlineKeyboard: [][]models.InlineKeyboardButton{{pickButton}},
+		}
+		return tr.T("vacation.none") + "\n\n" + tr.T("vacation.usage"), keyboard, nil
+	}
+
+	loc := bm.UserLocation(ctx, chatID)
+	from := vacation.StartsAt.In(loc)
+	to := vacation.EndsAt.In(loc).AddDate(0, 0, -1)
+
+	var msg strings.Builder
+	if from.After(time.Now()) {
+		msg.WriteString(tr.T("vacation.planned") + "\n")
+	} else {
+		msg.WriteString(tr.T("vacation.active") + "\n")
+	}
+	msg.WriteString(fmt.Sprintf("📅 %s — %s\n\n", tr.Date(from), tr.Date(to)))
+	msg.WriteString(tr.T("vacation.description") + "\n")
+	if bm.User(ctx, chatID).VacationMode == db.VacationModeShift {
+		msg.WriteString(tr.T("vacation.mode_shift"))
+	} else {
+		msg.WriteString(tr.T("vacation.mode_summary"))
+	}
+
+	keyboard := &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{bm.button(tr.T("vacation.mode_button"), ActionSettingsSection, settingsVacation)},
+			{pickButton},
+			{bm.button(tr.T("vacation.cancel"), ActionVacationCancel)},
+		},
+	}
+
+	return msg.String(), keyboard, nil
+}
+
+// SendVacationSummary sends list of one-off events skipped during vacation.
+func (bm *BotManager) SendVacationSummary(ctx context.Context, chatID int64, events []db.Event) {
+	loc := bm.UserLocation(ctx, chatID)
+	tr := bm.Tr(ctx, chatID)
+
+	var msg strings.Builder
+	msg.WriteString(tr.T("vacation.summary_title") + "\n\n")
+	for i, e := range events {
+		msg.WriteString(fmt.Sprintf("%d. %s — %s\n", i+1, e.Message, tr.DateTime(e.SendAt.In(loc))))
+	}
+	msg.WriteString("\n" + tr.T("vacation.summary_trash"))
+
+	_, err := bm.b.SendMessage(ctx, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   msg.String(),
+	})
+	bm.OnError(err)
+}
+
+func (bm *BotManager) vacationErrorText(tr *i18n.Localizer, err error) string {
+	switch {
+	case errors.Is(err, ErrInvalidVacation):
+		return tr.T("vacation.invalid") + "\n\n" + tr.T("vacation.usage")
+	case errors.Is(err, ErrNoVacation):
+		return tr.T("vacation.none")
+	default:
+		bm.Errorf("Ошибка обработки отпуска: %v", err)
+		return tr.T("vacation.error")
+	}
+}
@@ -0,0 +1,81 @@
+package event_reminder_bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+)
+
+// TestDBCancelVacation checks that only series skipped by vacation are moved back.
+func TestDBCancelVacation(t *testing.T) {
+	bm := newTestManager(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Minute)
+	vacation, err := bm.EventsRepo.AddVacation(ctx, &db.Vacation{
+		UserTgID: ownerID,
+		StartsAt: now.Add(-24 * time.Hour),
+		EndsAt:   now.Add(72 * time.Hour),
+		StatusID: db.StatusEnabled,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// daily series skipped an hour ago was moved to the first occurrence after vacation
+	skipped := now.Add(-time.Hour)
+	shifted := skipped.AddDate(0, 0, 4)
+	future := vacation.EndsAt.Add(48 * time.Hour)
+
+	tests := []struct {
+		name      string
+		sendAt    time.Time
+		skippedAt *time.Time
+		want      time.Time
+	}{
+		{"skipped series", shifted, &skipped, skipped.AddDate(0, 0, 1)},
+		{"future series", future, nil, future},
+		{"rescheduled series", future, &skipped, future},
+	}
+
+	events := make([]*db.Event, len(tests))
+	for i, tt := range tests {
+		events[i], err = bm.EventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID:    ownerID,
+			Message:     tt.name,
+			SendAt:      tt.sendAt,
+			StatusID:    db.StatusEnabled,
+			Weekdays:    []int{},
+			Periodicity: test.Ptr(db.PeriodicityDay),
+			SkippedAt:   tt.skippedAt,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = bm.CancelVacation(ctx, ownerID); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, tt := range tests {
+		got, err := bm.EventsRepo.EventByID(ctx, events[i].ID)
+		switch {
+		case err != nil:
+			t.Fatal(err)
+		case !got.SendAt.Equal(tt.want):
+			t.Errorf("%s: got sendAt %v, want %v", tt.name, got.SendAt, tt.want)
+		case got.SkippedAt != nil:
+			t.Errorf("%s: skippedAt is not cleared", tt.name)
+		}
+	}
+
+	if v, err := bm.EventsRepo.UserVacation(ctx, ownerID); err != nil {
+		t.Fatal(err)
+	} else if v != nil {
+		t.Errorf("vacation %d is not removed", v.ID)
+	}
+}
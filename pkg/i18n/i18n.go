@@ -0,0 +1,299 @@
+// Package i18n provides message catalogs with plural rules and locale-aware date formatting.
+//
+// Catalogs are TOML files embedded from locales/. Nested tables are flattened into dotted keys,
+// a table with "other" key and only plural form keys (one, few, many, other) is a plural message.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Lang is a supported language code.
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	// DefaultLang is used for users without language.
+	DefaultLang = Russian
+)
+
+// Langs are all supported languages.
+var Langs = []Lang{Russian, English}
+
+// Plural form names.
+const (
+	One   = "one"
+	Few   = "few"
+	Many  = "many"
+	Other = "other"
+)
+
+// pluralRules return plural form of integer n for each language.
+var pluralRules = map[Lang]func(n int) string{
+	Russian: func(n int) string {
+		if n < 0 {
+			n = -n
+		}
+		switch {
+		case n%10 == 1 && n%100 != 11:
+			return One
+		case n%10 >= 2 && n%10 <= 4 && (n%100 < 12 || n%100 > 14):
+			return Few
+		default:
+			return Many
+		}
+	},
+	English: func(n int) string {
+		if n == 1 || n == -1 {
+			return One
+		}
+		return Other
+	},
+}
+
+//go:embed locales/*.toml
+var localesFS embed.FS
+
+var localizers = mustLoad(localesFS)
+
+// message is a catalog entry: plain message has Other form only.
+type message map[string]string
+
+// Localizer translates messages and formats dates for one language.
+type Localizer struct {
+	lang     Lang
+	messages map[string]message
+	fallback *Localizer
+	loc      *time.Location
+}
+
+// For returns localizer of the language, unsupported languages get DefaultLang.
+func For(lang Lang) *Localizer {
+	if l, ok := localizers[lang]; ok {
+		return l
+	}
+
+	return localizers[DefaultLang]
+}
+
+// Match returns supported language for Telegram language_code like "en" or "en-US".
+// Empty code gets DefaultLang, other unsupported languages get English.
+func Match(code string) Lang {
+	if code == "" {
+		return DefaultLang
+	}
+
+	base, _, _ := strings.Cut(strings.ToLower(code), "-")
+	if lang := Lang(base); slices.Contains(Langs, lang) {
+		return lang
+	}
+
+	return English
+}
+
+// Parse returns supported language by its code.
+func Parse(code string) (Lang, bool) {
+	lang := Lang(strings.ToLower(strings.TrimSpace(code)))
+	return lang, slices.Contains(Langs, lang)
+}
+
+// Lang returns localizer language.
+func (l *Localizer) Lang() Lang {
+	return l.lang
+}
+
+// In returns localizer formatting dates in time zone loc.
+func (l *Localizer) In(loc *time.Location) *Localizer {
+	c := *l
+	c.loc = loc
+	return &c
+}
+
+// T returns message by key formatted with args. Missing messages are taken from DefaultLang, unknown key is returned as is.
+func (l *Localizer) T(key string, args ...any) string {
+	return l.format(l.lookup(key, Other), args)
+}
+
+// N returns plural form of message for n formatted with args. If args are empty, n is used as the only argument.
+func (l *Localizer) N(key string, n int, args ...any) string {
+	if len(args) == 0 {
+		args = []any{n}
+	}
+
+	return l.format(l.lookup(key, pluralRules[l.lang](n)), args)
+}
+
+// Has returns true if message exists in the catalog.
+func (l *Localizer) Has(key string) bool {
+	_, ok := l.messages[key]
+	return ok
+}
+
+// Weekday returns short weekday name for day in Monday=1..Sunday=7 notation.
+func (l *Localizer) Weekday(day int) string {
+	return l.numbered("weekday.short.", day)
+}
+
+// WeekdayName returns full weekday name for day in Monday=1..Sunday=7 notation.
+func (l *Localizer) WeekdayName(day int) string {
+	return l.numbered("weekday.long.", day)
+}
+
+// Month returns month name.
+func (l *Localizer) Month(m time.Month) string {
+	return l.numbered("month.", int(m))
+}
+
+// DateTime formats date and time.
+func (l *Localizer) DateTime(t time.Time) string {
+	return l.formatTime(t, "format.datetime")
+}
+
+// Date formats date with year.
+func (l *Localizer) Date(t time.Time) string {
+	return l.formatTime(t, "format.date")
+}
+
+// DayMonth formats date without year.
+func (l *Localizer) DayMonth(t time.Time) string {
+	return l.formatTime(t, "format.day_month")
+}
+
+// Time formats time of day.
+func (l *Localizer) Time(t time.Time) string {
+	return l.formatTime(t, "format.time")
+}
+
+func (l *Localizer) formatTime(t time.Time, key string) string {
+	if l.loc != nil {
+		t = t.In(l.loc)
+	}
+
+	return t.Format(l.T(key))
+}
+
+func (l *Localizer) numbered(prefix string, n int) string {
+	key := prefix + strconv.Itoa(n)
+	if !l.Has(key) {
+		return strconv.Itoa(n)
+	}
+
+	return l.T(key)
+}
+
+func (l *Localizer) lookup(key, form string) string {
+	for cur := l; cur != nil; cur = cur.fallback {
+		m, ok := cur.messages[key]
+		if !ok {
+			continue
+		}
+		if s, ok := m[form]; ok {
+			return s
+		}
+		return m[Other]
+	}
+
+	return key
+}
+
+func (l *Localizer) format(s string, args []any) string {
+	if len(args) == 0 {
+		return s
+	}
+
+	return fmt.Sprintf(s, args...)
+}
+
+// Keys returns sorted message keys of the catalog.
+func (l *Localizer) Keys() []string {
+	return slices.Sorted(maps.Keys(l.messages))
+}
+
+// Forms returns plural forms of the message, plain messages have Other form only.
+func (l *Localizer) Forms(key string) []string {
+	return slices.Sorted(maps.Keys(l.messages[key]))
+}
+
+// mustLoad parses embedded catalogs. Catalogs are part of the binary, so it panics on error.
+func mustLoad(fsys fs.FS) map[Lang]*Localizer {
+	res := make(map[Lang]*Localizer, len(Langs))
+	for _, lang := range Langs {
+		b, err := fs.ReadFile(fsys, path.Join("locales", string(lang)+".toml"))
+		if err != nil {
+			panic(fmt.Sprintf("i18n: %v", err))
+		}
+
+		var raw map[string]any
+		if err = toml.Unmarshal(b, &raw); err != nil {
+			panic(fmt.Sprintf("i18n: %s: %v", lang, err))
+		}
+
+		messages := map[string]message{}
+		if err = flatten(messages, "", raw); err != nil {
+			panic(fmt.Sprintf("i18n: %s: %v", lang, err))
+		}
+
+		res[lang] = &Localizer{lang: lang, messages: messages}
+	}
+
+	for lang, l := range res {
+		if lang != DefaultLang {
+			l.fallback = res[DefaultLang]
+		}
+	}
+
+	return res
+}
+
+func flatten(dst map[string]message, prefix string, raw map[string]any) error {
+	for k, v := range raw {
+		key := prefix + k
+
+		switch v := v.(type) {
+		case string:
+			dst[key] = message{Other: v}
+		case map[string]any:
+			if m, ok := pluralMessage(v); ok {
+				dst[key] = m
+				continue
+			}
+			if err := flatten(dst, key+".", v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("%s: unsupported value %T", key, v)
+		}
+	}
+
+	return nil
+}
+
+// pluralMessage returns message if table contains plural forms only.
+func pluralMessage(raw map[string]any) (message, bool) {
+	if _, ok := raw[Other]; !ok {
+		return nil, false
+	}
+
+	m := message{}
+	for k, v := range raw {
+		s, ok := v.(string)
+		if !ok || !slices.Contains([]string{One, Few, Many, Other}, k) {
+			return nil, false
+		}
+		m[k] = s
+	}
+
+	return m, true
+}
@@ -0,0 +1,151 @@
+package i18n
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPlural(t *testing.T) {
+	tests := []struct {
+		lang Lang
+		n    int
+		want string
+	}{
+		{Russian, 0, Many},
+		{Russian, 1, One},
+		{Russian, 2, Few},
+		{Russian, 4, Few},
+		{Russian, 5, Many},
+		{Russian, 11, Many},
+		{Russian, 12, Many},
+		{Russian, 21, One},
+		{Russian, 22, Few},
+		{Russian, 111, Many},
+		{Russian, 114, Many},
+		{Russian, 1001, One},
+		{English, 0, Other},
+		{English, 1, One},
+		{English, 2, Other},
+		{English, 21, Other},
+	}
+
+	for _, tt := range tests {
+		if got := pluralRules[tt.lang](tt.n); got != tt.want {
+			t.Errorf("%s %d: got %s, want %s", tt.lang, tt.n, got, tt.want)
+		}
+	}
+
+	if got := For(Russian).N("trash.days", 22); got != "22 дня" {
+		t.Errorf("ru trash.days: got %q", got)
+	}
+	if got := For(English).N("trash.days", 1); got != "1 day" {
+		t.Errorf("en trash.days: got %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	tests := map[string]Lang{
+		"":      Russian,
+		"ru":    Russian,
+		"RU":    Russian,
+		"en":    English,
+		"en-US": English,
+		"de":    English,
+	}
+
+	for code, want := range tests {
+		if got := Match(code); got != want {
+			t.Errorf("%q: got %s, want %s", code, got, want)
+		}
+	}
+}
+
+func TestFallback(t *testing.T) {
+	if got := For("de").Lang(); got != DefaultLang {
+		t.Errorf("unsupported language: got %s", got)
+	}
+	if got := For(English).T("no.such.key"); got != "no.such.key" {
+		t.Errorf("unknown key: got %q", got)
+	}
+
+	at := time.Date(2026, 3, 5, 14, 7, 0, 0, time.UTC)
+	if got := For(Russian).DateTime(at); got != "2026-03-05 14:07" {
+		t.Errorf("ru datetime: got %q", got)
+	}
+	if got := For(English).Time(at); got != "2:07 PM" {
+		t.Errorf("en time: got %q", got)
+	}
+}
+
+var verbRe = regexp.MustCompile(`%(\[\d+\])?[-+# 0]*\d*[a-zA-Z]`)
+
+// TestCatalogs checks that all catalogs have the same messages with the same format verbs.
+func TestCatalogs(t *testing.T) {
+	base := For(DefaultLang)
+	for _, lang := range Langs {
+		l := For(lang)
+		if !slices.Equal(l.Keys(), base.Keys()) {
+			t.Errorf("%s: keys differ from %s", lang, DefaultLang)
+		}
+
+		for _, key := range l.Keys() {
+			want := verbs(base.messages[key][Other])
+			for _, form := range l.Forms(key) {
+				if got := verbs(l.messages[key][form]); !slices.Equal(got, want) {
+					t.Errorf("%s %s.%s: verbs %v, want %v", lang, key, form, got, want)
+				}
+			}
+			if len(l.Forms(key)) > 1 && l.messages[key][One] == "" {
+				t.Errorf("%s %s: plural message without %s form", lang, key, One)
+			}
+		}
+	}
+}
+
+func verbs(s string) []string {
+	s = strings.ReplaceAll(s, "%%", "")
+
+	res := verbRe.FindAllString(s, -1)
+	for i, v := range res {
+		res[i] = v[len(v)-1:]
+	}
+	slices.Sort(res)
+
+	return res
+}
+
+var keyRe = regexp.MustCompile(`\.(?:T|N)\("([a-z0-9_.]+)"|Key: +"([a-z0-9_.]+)"`)
+
+// TestUsedKeys checks that literal message keys used in sources exist in the catalog.
+func TestUsedKeys(t *testing.T) {
+	l := For(DefaultLang)
+
+	err := filepath.WalkDir("..", func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return err
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range keyRe.FindAllStringSubmatch(string(b), -1) {
+			key := m[1] + m[2]
+			if !l.Has(key) {
+				t.Errorf("%s: unknown message %q", path, key)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
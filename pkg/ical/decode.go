@@ -0,0 +1,450 @@
+package ical
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/model"
+)
+
+var (
+	ErrInvalidCalendar = errors.New("invalid calendar")
+	ErrUnsupportedRule = errors.New("unsupported recurrence rule")
+)
+
+// maxSeriesSteps limits expansion of COUNT rules.
+const maxSeriesSteps = 10000
+
+const dateFormat = "20060102"
+
+// VEvent is a parsed VEVENT component. Times are in their time zones, floating and all-day times are in default location.
+type VEvent struct {
+	UID          string
+	Summary      string
+	Description  string
+	Location     string
+	Status       string
+	Start        time.Time
+	End          *time.Time
+	AllDay       bool
+	RRule        string
+	ExDates      []time.Time
+	Alarms       []Alarm
+	RecurrenceID string
+	// Invalid is true if the event has no start or its dates could not be parsed.
+	Invalid bool
+	// UnknownTZ is TZID which could not be loaded, default location is used instead.
+	UnknownTZ string
+}
+
+// Alarm is VALARM trigger: absolute time or offset from event start or end.
+type Alarm struct {
+	At       *time.Time
+	Offset   time.Duration
+	AfterEnd bool
+}
+
+// Time returns alarm time of the event.
+func (a Alarm) Time(e VEvent) time.Time {
+	if a.At != nil {
+		return *a.At
+	}
+	if a.AfterEnd && e.End != nil {
+		return e.End.Add(a.Offset)
+	}
+
+	return e.Start.Add(a.Offset)
+}
+
+// Reminder returns the earliest alarm time of the event or its start if it has no alarms.
+func (e VEvent) Reminder() time.Time {
+	if len(e.Alarms) == 0 {
+		return e.Start
+	}
+
+	res := e.Alarms[0].Time(e)
+	for _, a := range e.Alarms[1:] {
+		if t := a.Time(e); t.Before(res) {
+			res = t
+		}
+	}
+
+	return res
+}
+
+// property is a content line: name, parameters and raw value.
+type property struct {
+	Name   string
+	Params map[string]string
+	Value  string
+}
+
+// Decode returns VEVENTs of iCalendar file. Floating and all-day times are read in loc.
+func Decode(r io.Reader, loc *time.Location) ([]VEvent, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		res       []VEvent
+		event     *VEvent
+		alarm     *Alarm
+		stack     []string
+		hasHeader bool
+	)
+	for _, line := range lines {
+		p, ok := parseLine(line)
+		if !ok {
+			continue
+		}
+
+		switch p.Name {
+		case "BEGIN":
+			stack = append(stack, strings.ToUpper(p.Value))
+			switch stack[len(stack)-1] {
+			case "VCALENDAR":
+				hasHeader = true
+			case "VEVENT":
+				event = &VEvent{}
+			case "VALARM":
+				alarm = &Alarm{}
+			}
+			continue
+		case "END":
+			if len(stack) == 0 {
+				return nil, ErrInvalidCalendar
+			}
+			switch stack[len(stack)-1] {
+			case "VEVENT":
+				if event != nil {
+					event.Invalid = event.Invalid || event.Start.IsZero()
+					res = append(res, *event)
+				}
+				event = nil
+			case "VALARM":
+				if event != nil && alarm != nil {
+					event.Alarms = append(event.Alarms, *alarm)
+				}
+				alarm = nil
+			}
+			stack = stack[:len(stack)-1]
+			continue
+		}
+
+		if event == nil || len(stack) == 0 {
+			continue
+		}
+
+		// properties of nested components, e.g. VALARM description, are not event properties
+		switch stack[len(stack)-1] {
+		case "VEVENT":
+			event.set(p, loc)
+		case "VALARM":
+			if alarm != nil && p.Name == "TRIGGER" && !parseTrigger(p, loc, alarm) {
+				alarm = nil
+			}
+		}
+	}
+
+	if !hasHeader {
+		return nil, ErrInvalidCalendar
+	}
+
+	return res, nil
+}
+
+// set sets event field by property. Event with invalid dates is marked as invalid.
+func (e *VEvent) set(p property, loc *time.Location) {
+	switch p.Name {
+	case "UID":
+		e.UID = p.Value
+	case "SUMMARY":
+		e.Summary = unescape(p.Value)
+	case "DESCRIPTION":
+		e.Description = unescape(p.Value)
+	case "LOCATION":
+		e.Location = unescape(p.Value)
+	case "STATUS":
+		e.Status = strings.ToUpper(p.Value)
+	case "RRULE":
+		e.RRule = strings.ToUpper(p.Value)
+	case "RECURRENCE-ID":
+		e.RecurrenceID = p.Value
+	case "DTSTART":
+		t, allDay, unknownTZ, err := parseTime(p, loc)
+		e.Start, e.AllDay, e.UnknownTZ = t, allDay, unknownTZ
+		e.Invalid = e.Invalid || err != nil
+	case "DTEND":
+		if t, _, _, err := parseTime(p, loc); err == nil {
+			e.End = &t
+		}
+	case "EXDATE":
+		for _, v := range strings.Split(p.Value, ",") {
+			t, _, _, err := parseTime(property{Params: p.Params, Value: v}, loc)
+			e.Invalid = e.Invalid || err != nil
+			e.ExDates = append(e.ExDates, t)
+		}
+	}
+}
+
+// unfold returns content lines joining folded continuation lines.
+func unfold(r io.Reader) ([]string, error) {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	var lines []string
+	for sc.Scan() {
+		line := strings.TrimRight(sc.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines, sc.Err()
+}
+
+// parseLine parses "NAME;PARAM=value;PARAM="quoted:value":VALUE" content line.
+func parseLine(line string) (property, bool) {
+	p := property{Params: map[string]string{}}
+
+	quoted := false
+	start, name := 0, ""
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == ';' || c == ':':
+			part := line[start:i]
+			if name == "" {
+				name = part
+				p.Name = strings.ToUpper(name)
+			} else if k, v, ok := strings.Cut(part, "="); ok {
+				p.Params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+			}
+			start = i + 1
+
+			if c == ':' {
+				p.Value = line[i+1:]
+				return p, p.Name != ""
+			}
+		}
+	}
+
+	return p, false
+}
+
+// parseTime parses DATE or DATE-TIME value in TZID, UTC or loc. It returns true for dates and unknown TZID.
+func parseTime(p property, loc *time.Location) (t time.Time, allDay bool, unknownTZ string, err error) {
+	v := strings.TrimSpace(p.Value)
+	if p.Params["VALUE"] == "DATE" || len(v) == len(dateFormat) {
+		t, err = time.ParseInLocation(dateFormat, v, loc)
+		return t, true, "", err
+	}
+
+	if strings.HasSuffix(v, "Z") {
+		t, err = time.Parse(utcFormat, v)
+		return t, false, "", err
+	}
+
+	if tzid := p.Params["TZID"]; tzid != "" {
+		if l := loadLocation(tzid); l != nil {
+			loc = l
+		} else {
+			unknownTZ = tzid
+		}
+	}
+
+	t, err = time.ParseInLocation(localFormat, v, loc)
+	return t, false, unknownTZ, err
+}
+
+// loadLocation loads IANA time zone. Prefixes like "/mozilla.org/20070129_1/Europe/Moscow" are removed.
+func loadLocation(tzid string) *time.Location {
+	parts := strings.Split(strings.Trim(tzid, "/"), "/")
+	for i := range parts {
+		if loc, err := time.LoadLocation(strings.Join(parts[i:], "/")); err == nil && loc.String() != "Local" {
+			return loc
+		}
+	}
+
+	return nil
+}
+
+// parseTrigger parses VALARM trigger. It returns false for unsupported triggers.
+func parseTrigger(p property, loc *time.Location, a *Alarm) bool {
+	if p.Params["VALUE"] == "DATE-TIME" {
+		t, _, _, err := parseTime(property{Value: p.Value}, loc)
+		if err != nil {
+			return false
+		}
+		a.At = &t
+		return true
+	}
+
+	d, ok := ParseDuration(p.Value)
+	if !ok {
+		return false
+	}
+	a.Offset, a.AfterEnd = d, p.Params["RELATED"] == "END"
+
+	return true
+}
+
+// ParseDuration parses duration value like "-PT15M", "P1DT2H" or "-P1W".
+func ParseDuration(s string) (time.Duration, bool) {
+	s = strings.TrimSpace(strings.ToUpper(s))
+	sign := time.Duration(1)
+	switch {
+	case strings.HasPrefix(s, "-"):
+		sign, s = -1, s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+
+	s, ok := strings.CutPrefix(s, "P")
+	if !ok || s == "" {
+		return 0, false
+	}
+
+	var res time.Duration
+	inTime := false
+	num := ""
+	for _, c := range s {
+		switch {
+		case c >= '0' && c <= '9':
+			num += string(c)
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			return 0, false
+		}
+		num = ""
+
+		switch {
+		case c == 'W' && !inTime:
+			res += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			res += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			res += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			res += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			res += time.Duration(n) * time.Second
+		default:
+			return 0, false
+		}
+	}
+	if num != "" {
+		return 0, false
+	}
+
+	return sign * res, true
+}
+
+// Series is a bot recurrence of RRULE.
+type Series struct {
+	Periodicity string
+	Weekdays    []int
+	// Until is the last occurrence time, nil for endless series.
+	Until *time.Time
+}
+
+// ParseRRule maps RRULE to bot periodicity: hourly, daily, weekly and weekly on weekdays are supported, but only without intervals.
+// COUNT is converted to the last occurrence time.
+func ParseRRule(rule string, start time.Time) (*Series, error) {
+	var (
+		res   Series
+		count int
+		days  []int
+	)
+	for _, part := range strings.Split(strings.ToUpper(rule), ";") {
+		k, v, _ := strings.Cut(part, "=")
+		switch k {
+		case "FREQ":
+			switch v {
+			case "HOURLY":
+				res.Periodicity = db.PeriodicityHour
+			case "DAILY":
+				res.Periodicity = db.PeriodicityDay
+			case "WEEKLY":
+				res.Periodicity = db.PeriodicityWeek
+			default:
+				return nil, ErrUnsupportedRule
+			}
+		case "INTERVAL":
+			if v != "1" {
+				return nil, ErrUnsupportedRule
+			}
+		case "COUNT":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				return nil, ErrUnsupportedRule
+			}
+			count = n
+		case "UNTIL":
+			t, _, _, err := parseTime(property{Value: v}, start.Location())
+			if err != nil {
+				return nil, ErrUnsupportedRule
+			}
+			res.Until = &t
+		case "BYDAY":
+			for _, d := range strings.Split(v, ",") {
+				i := slices.Index(byDay, d)
+				if i < 1 {
+					return nil, ErrUnsupportedRule
+				}
+				days = append(days, i)
+			}
+		case "WKST":
+		default:
+			return nil, ErrUnsupportedRule
+		}
+	}
+
+	switch {
+	case res.Periodicity == "":
+		return nil, ErrUnsupportedRule
+	case len(days) > 0 && res.Periodicity == db.PeriodicityHour:
+		return nil, ErrUnsupportedRule
+	case len(days) > 0 && !(res.Periodicity == db.PeriodicityWeek && len(days) == 1 && days[0] == model.Weekday(start)):
+		slices.Sort(days)
+		res.Periodicity, res.Weekdays = db.PeriodicityWeekdays, slices.Compact(days)
+	}
+
+	if count > 0 {
+		e := model.ReminderEvent{DateTime: start, Periodicity: &res.Periodicity, Weekdays: res.Weekdays}
+		if res.Periodicity == db.PeriodicityWeekdays && !slices.Contains(res.Weekdays, model.Weekday(start)) {
+			// DTSTART which does not match the rule is not counted as occurrence
+			count++
+		}
+		for i := 1; i < count && i < maxSeriesSteps; i++ {
+			next := model.NextTime(e)
+			if next == nil {
+				break
+			}
+			e.DateTime = *next
+		}
+		res.Until = &e.DateTime
+	}
+
+	return &res, nil
+}
@@ -0,0 +1,158 @@
+package ical
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+)
+
+const testCalendar = `BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Test//EN
+BEGIN:VTIMEZONE
+TZID:Europe/Berlin
+BEGIN:STANDARD
+DTSTART:19701025T030000
+TZOFFSETFROM:+0200
+TZOFFSETTO:+0100
+END:STANDARD
+END:VTIMEZONE
+BEGIN:VEVENT
+UID:1
+SUMMARY:Standup\, daily
+DESCRIPTION:Room 5\nSecond line that is folded by the
+  client
+DTSTART;TZID=Europe/Berlin:20260302T093000
+RRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR
+EXDATE;TZID=Europe/Berlin:20260303T093000,20260304T093000
+BEGIN:VALARM
+ACTION:DISPLAY
+DESCRIPTION:Reminder
+TRIGGER:-PT15M
+END:VALARM
+END:VEVENT
+BEGIN:VEVENT
+UID:2
+SUMMARY:Holiday
+DTSTART;VALUE=DATE:20260501
+END:VEVENT
+BEGIN:VEVENT
+UID:3
+SUMMARY:Call
+DTSTART;TZID="/mozilla.org/20070129_1/Asia/Tokyo":20260302T180000
+END:VEVENT
+BEGIN:VEVENT
+UID:4
+SUMMARY:Broken
+DTSTART;TZID=Mars/Olympus:20260302T180000
+END:VEVENT
+END:VCALENDAR
+`
+
+func TestDecode(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	events, err := Decode(strings.NewReader(testCalendar), loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("got %d events, want 4", len(events))
+	}
+
+	e := events[0]
+	if e.Summary != "Standup, daily" || e.Description != "Room 5\nSecond line that is folded by the client" {
+		t.Errorf("text = %q, %q", e.Summary, e.Description)
+	}
+	if e.Start.Location().String() != "Europe/Berlin" || e.Start.Hour() != 9 {
+		t.Errorf("start = %v", e.Start)
+	}
+	if len(e.ExDates) != 2 || e.ExDates[1].Day() != 4 {
+		t.Errorf("exdates = %v", e.ExDates)
+	}
+	if got := e.Reminder(); !got.Equal(e.Start.Add(-15 * time.Minute)) {
+		t.Errorf("reminder = %v", got)
+	}
+
+	if !events[1].AllDay || events[1].Start.Location() != loc {
+		t.Errorf("all day event = %+v", events[1])
+	}
+	if events[2].Start.Location().String() != "Asia/Tokyo" {
+		t.Errorf("mozilla TZID = %v", events[2].Start.Location())
+	}
+	if events[3].UnknownTZ != "Mars/Olympus" || events[3].Start.Location() != loc {
+		t.Errorf("unknown TZID = %q, %v", events[3].UnknownTZ, events[3].Start.Location())
+	}
+
+	if _, err = Decode(strings.NewReader("hello"), loc); !errors.Is(err, ErrInvalidCalendar) {
+		t.Errorf("not a calendar: got %v", err)
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"-PT15M":   -15 * time.Minute,
+		"PT0S":     0,
+		"-P1D":     -24 * time.Hour,
+		"P1DT2H":   26 * time.Hour,
+		"-P1W":     -7 * 24 * time.Hour,
+		"+PT1H30M": 90 * time.Minute,
+	}
+	for s, want := range tests {
+		if got, ok := ParseDuration(s); !ok || got != want {
+			t.Errorf("ParseDuration(%q) = %v, %v, want %v", s, got, ok, want)
+		}
+	}
+
+	for _, s := range []string{"", "P", "PT15", "15M", "P1H"} {
+		if _, ok := ParseDuration(s); ok {
+			t.Errorf("ParseDuration(%q) is valid", s)
+		}
+	}
+}
+
+func TestParseRRule(t *testing.T) {
+	monday := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		rule     string
+		want     string
+		weekdays []int
+	}{
+		{"FREQ=HOURLY", db.PeriodicityHour, nil},
+		{"FREQ=DAILY;INTERVAL=1", db.PeriodicityDay, nil},
+		{"FREQ=WEEKLY", db.PeriodicityWeek, nil},
+		{"FREQ=WEEKLY;BYDAY=MO;WKST=MO", db.PeriodicityWeek, nil},
+		{"FREQ=WEEKLY;BYDAY=FR,MO", db.PeriodicityWeekdays, []int{1, 5}},
+		{"FREQ=DAILY;BYDAY=SA,SU", db.PeriodicityWeekdays, []int{6, 7}},
+	}
+	for _, tt := range tests {
+		s, err := ParseRRule(tt.rule, monday)
+		if err != nil || s.Periodicity != tt.want || !slices.Equal(s.Weekdays, tt.weekdays) || s.Until != nil {
+			t.Errorf("ParseRRule(%q) = %+v, %v", tt.rule, s, err)
+		}
+	}
+
+	for _, rule := range []string{"FREQ=MONTHLY", "FREQ=DAILY;INTERVAL=2", "FREQ=MONTHLY;BYDAY=1MO", "FREQ=WEEKLY;BYDAY=1MO", "FREQ=DAILY;BYHOUR=9", "INTERVAL=1"} {
+		if _, err := ParseRRule(rule, monday); !errors.Is(err, ErrUnsupportedRule) {
+			t.Errorf("ParseRRule(%q) = %v, want unsupported", rule, err)
+		}
+	}
+
+	s, err := ParseRRule("FREQ=WEEKLY;BYDAY=MO,WE;COUNT=3", monday)
+	if err != nil || s.Until == nil || !s.Until.Equal(monday.AddDate(0, 0, 7)) {
+		t.Errorf("COUNT rule = %+v, %v", s, err)
+	}
+
+	s, err = ParseRRule("FREQ=DAILY;UNTIL=20260310T090000Z", monday)
+	if err != nil || s.Until == nil || !s.Until.Equal(monday.AddDate(0, 0, 8)) {
+		t.Errorf("UNTIL rule = %+v, %v", s, err)
+	}
+}
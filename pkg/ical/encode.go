@@ -0,0 +1,168 @@
+// Package ical converts events to and from iCalendar format, see RFC 5545.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/model"
+)
+
+const (
+	// ContentType is MIME type of iCalendar files.
+	ContentType = "text/calendar; charset=utf-8"
+
+	prodID = "-//event-reminder-bot//EN"
+
+	// maxLineLength is line length in octets, longer lines are folded.
+	maxLineLength = 75
+
+	localFormat = "20060102T150405"
+	utcFormat   = "20060102T150405Z"
+)
+
+// byDay are RRULE weekday names in Monday=1..Sunday=7 notation used by Event.Weekdays.
+var byDay = []string{1: "MO", 2: "TU", 3: "WE", 4: "TH", 5: "FR", 6: "SA", 7: "SU"}
+
+// Calendar is a set of user events shown in user time zone.
+type Calendar struct {
+	Name     string
+	Location *time.Location
+	Events   []model.Event
+}
+
+// Encode writes calendar with one VEVENT per event, periodic events get RRULE.
+// Reminders have no duration, so events end at start time.
+func Encode(w io.Writer, c Calendar, now time.Time) error {
+	e := &encoder{w: bufio.NewWriter(w)}
+	tz := timeZone(c.Location)
+
+	e.line("BEGIN:VCALENDAR")
+	e.line("VERSION:2.0")
+	e.line("PRODID:" + prodID)
+	e.line("CALSCALE:GREGORIAN")
+	e.line("METHOD:PUBLISH")
+	if c.Name != "" {
+		e.line("X-WR-CALNAME:" + escape(c.Name))
+	}
+	if tz != "" {
+		e.line("X-WR-TIMEZONE:" + tz)
+	}
+	e.line("REFRESH-INTERVAL;VALUE=DURATION:PT1H")
+	e.line("X-PUBLISHED-TTL:PT1H")
+
+	for _, ev := range c.Events {
+		e.line("BEGIN:VEVENT")
+		e.line("UID:event-" + strconv.Itoa(ev.ID) + "@event-reminder-bot")
+		e.line("DTSTAMP:" + now.UTC().Format(utcFormat))
+		e.line("DTSTART" + dateTime(ev.DateTime, c.Location, tz))
+		e.line("SUMMARY:" + escape(ev.Text))
+		if ev.Notes != "" {
+			e.line("DESCRIPTION:" + escape(ev.Notes))
+		}
+		if rule := RRule(ev); rule != "" {
+			e.line("RRULE:" + rule)
+		}
+		e.line("END:VEVENT")
+	}
+
+	e.line("END:VCALENDAR")
+	if e.err != nil {
+		return e.err
+	}
+
+	return e.w.Flush()
+}
+
+// RRule returns recurrence rule of periodic event or empty string for one-off events.
+func RRule(e model.Event) string {
+	if e.Periodicity == nil {
+		return ""
+	}
+
+	switch *e.Periodicity {
+	case db.PeriodicityHour:
+		return "FREQ=HOURLY"
+	case db.PeriodicityDay:
+		return "FREQ=DAILY"
+	case db.PeriodicityWeek:
+		return "FREQ=WEEKLY"
+	case db.PeriodicityWeekdays:
+		days := make([]string, 0, len(e.Weekdays))
+		for _, d := range e.Weekdays {
+			if d >= 1 && d <= 7 {
+				days = append(days, byDay[d])
+			}
+		}
+		if len(days) == 0 {
+			return ""
+		}
+		return "FREQ=WEEKLY;BYDAY=" + strings.Join(days, ",")
+	}
+
+	return ""
+}
+
+// timeZone returns IANA name of loc for TZID parameter or empty string if times should be written in UTC.
+func timeZone(loc *time.Location) string {
+	if loc == nil || loc == time.UTC || loc.String() == "Local" || loc.String() == "UTC" {
+		return ""
+	}
+
+	return loc.String()
+}
+
+// dateTime returns DTSTART parameters and value: local time with TZID keeps recurrences at the same wall clock time over DST changes.
+func dateTime(t time.Time, loc *time.Location, tz string) string {
+	if tz == "" {
+		return ":" + t.UTC().Format(utcFormat)
+	}
+
+	return ";TZID=" + tz + ":" + t.In(loc).Format(localFormat)
+}
+
+// escape escapes TEXT value.
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// unescape unescapes TEXT value.
+func unescape(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n").Replace(s)
+}
+
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes content line folded at maxLineLength octets without splitting UTF-8 characters.
+func (e *encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+
+	limit := maxLineLength
+	for len(s) > limit {
+		i := limit
+		for i > 0 && !utf8.RuneStart(s[i]) {
+			i--
+		}
+		e.write(s[:i] + "\r\n ")
+		s = s[i:]
+		// continuation lines start with a space
+		limit = maxLineLength - 1
+	}
+	e.write(s + "\r\n")
+}
+
+func (e *encoder) write(s string) {
+	if e.err == nil {
+		_, e.err = e.w.WriteString(s)
+	}
+}
@@ -0,0 +1,86 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/model"
+)
+
+func TestEncode(t *testing.T) {
+	loc, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	weekdays := db.PeriodicityWeekdays
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, loc)
+	c := Calendar{
+		Name:     "Reminders",
+		Location: loc,
+		Events: []model.Event{
+			{ID: 1, Text: "Call mom, dad; grandma", Notes: "line 1\nline 2", DateTime: at},
+			{ID: 2, Text: "Gym", DateTime: at, Periodicity: &weekdays, Weekdays: []int{1, 3, 5}},
+			{ID: 3, Text: strings.Repeat("Длинное название ", 10), DateTime: at.UTC()},
+		},
+	}
+
+	var sb strings.Builder
+	if err = Encode(&sb, c, at); err != nil {
+		t.Fatal(err)
+	}
+	out := sb.String()
+
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"X-WR-TIMEZONE:Europe/Moscow\r\n",
+		"UID:event-1@event-reminder-bot\r\n",
+		"DTSTART;TZID=Europe/Moscow:20260302T093000\r\n",
+		`SUMMARY:Call mom\, dad\; grandma` + "\r\n",
+		`DESCRIPTION:line 1\nline 2` + "\r\n",
+		"RRULE:FREQ=WEEKLY;BYDAY=MO,WE,FR\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("no %q in:\n%s", want, out)
+		}
+	}
+
+	if n := strings.Count(out, "BEGIN:VEVENT"); n != 3 {
+		t.Errorf("got %d events, want 3", n)
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineLength {
+			t.Errorf("line is longer than %d octets: %q", maxLineLength, line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("line splits UTF-8 character: %q", line)
+		}
+	}
+}
+
+func TestRRule(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	tests := []struct {
+		event model.Event
+		want  string
+	}{
+		{model.Event{}, ""},
+		{model.Event{Periodicity: str(db.PeriodicityHour)}, "FREQ=HOURLY"},
+		{model.Event{Periodicity: str(db.PeriodicityDay)}, "FREQ=DAILY"},
+		{model.Event{Periodicity: str(db.PeriodicityWeek)}, "FREQ=WEEKLY"},
+		{model.Event{Periodicity: str(db.PeriodicityWeekdays), Weekdays: []int{6, 7}}, "FREQ=WEEKLY;BYDAY=SA,SU"},
+		{model.Event{Periodicity: str(db.PeriodicityWeekdays)}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := RRule(tt.event); got != tt.want {
+			t.Errorf("RRule(%v) = %q, want %q", tt.event.Periodicity, got, tt.want)
+		}
+	}
+}
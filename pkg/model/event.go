@@ -11,9 +11,12 @@ type Event struct {
 	OriginalID  int
 	ChatID      int64
 	Text        string
+	Notes       string
 	DateTime    time.Time
 	Weekdays    []int
 	Periodicity *string
+	Paused      bool
+	ResumeAt    *time.Time
 }
 
 type ReminderEvent struct {
@@ -21,6 +24,7 @@ type ReminderEvent struct {
 	OriginalID  int
 	ChatID      int64
 	Text        string
+	Notes       string
 	DateTime    time.Time
 	Weekdays    []int
 	Periodicity *string
@@ -35,9 +39,12 @@ func NewEvent(dbEvent *db.Event) *Event {
 		OriginalID:  dbEvent.ID,
 		ChatID:      dbEvent.UserTgID,
 		Text:        dbEvent.Message,
+		Notes:       notes(dbEvent),
 		DateTime:    dbEvent.SendAt,
 		Weekdays:    dbEvent.Weekdays,
 		Periodicity: dbEvent.Periodicity,
+		Paused:      dbEvent.StatusID == db.StatusDisabled,
+		ResumeAt:    dbEvent.ResumeAt,
 	}
 }
 
@@ -49,9 +56,12 @@ func NewEvents(dbEvents []db.Event) []Event {
 			OriginalID:  dbEvent.ID,
 			ChatID:      dbEvent.UserTgID,
 			Text:        dbEvent.Message,
+			Notes:       notes(&dbEvent),
 			DateTime:    dbEvent.SendAt,
 			Weekdays:    dbEvent.Weekdays,
 			Periodicity: dbEvent.Periodicity,
+			Paused:      dbEvent.StatusID == db.StatusDisabled,
+			ResumeAt:    dbEvent.ResumeAt,
 		}
 	}
 	return events
@@ -63,6 +73,7 @@ func NewReminderEvent(dbEvent *db.Event) ReminderEvent {
 		OriginalID:  dbEvent.ID,
 		ChatID:      dbEvent.UserTgID,
 		Text:        dbEvent.Message,
+		Notes:       notes(dbEvent),
 		DateTime:    dbEvent.SendAt,
 		Weekdays:    dbEvent.Weekdays,
 		Periodicity: dbEvent.Periodicity,
@@ -75,8 +86,24 @@ func ToDB(event *Event) ReminderEvent {
 		OriginalID:  event.OriginalID,
 		ChatID:      event.ChatID,
 		Text:        event.Text,
+		Notes:       event.Notes,
 		DateTime:    event.DateTime,
 		Weekdays:    event.Weekdays,
 		Periodicity: event.Periodicity,
 	}
 }
+
+// FullText returns the event title followed by its notes, if any.
+func (e ReminderEvent) FullText() string {
+	if e.Notes == "" {
+		return e.Text
+	}
+	return e.Text + "\n\n" + e.Notes
+}
+
+func notes(dbEvent *db.Event) string {
+	if dbEvent.Notes == nil {
+		return ""
+	}
+	return *dbEvent.Notes
+}
@@ -0,0 +1,107 @@
+package model
+
+import (
+	"slices"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+)
+
+// maxOccurrences limits expansion of a single event within requested range, e.g. hourly events in a week.
+const maxOccurrences = 200
+
+// NextTime returns the next occurrence of periodic event after e.DateTime or nil for one-off events.
+func NextTime(e ReminderEvent) *time.Time {
+	if e.Periodicity == nil {
+		return nil
+	}
+
+	currentTime := e.DateTime
+
+	switch *e.Periodicity {
+	case db.PeriodicityHour:
+		t := currentTime.Add(time.Hour)
+		return &t
+	case db.PeriodicityDay:
+		t := currentTime.Add(24 * time.Hour)
+		return &t
+	case db.PeriodicityWeek:
+		t := currentTime.Add(7 * 24 * time.Hour)
+		return &t
+	case db.PeriodicityWeekdays:
+		return nextWeekday(currentTime, e.Weekdays)
+	default:
+		return nil
+	}
+}
+
+// Occurrences returns at most maxOccurrences event times within [from, to). DateTime is treated as the first occurrence.
+// All times are returned in loc.
+func Occurrences(e ReminderEvent, from, to time.Time, loc *time.Location) []time.Time {
+	var res []time.Time
+
+	e.DateTime = e.DateTime.In(loc)
+	if e.DateTime.Before(from) {
+		// old series are skipped to the range start, so the limit is spent within the range
+		next := NextAfter(e, from.Add(-time.Nanosecond))
+		if next == nil {
+			return nil
+		}
+		e.DateTime = *next
+	}
+
+	for len(res) < maxOccurrences && e.DateTime.Before(to) {
+		res = append(res, e.DateTime)
+
+		next := NextTime(e)
+		if next == nil || !next.After(e.DateTime) {
+			break
+		}
+		e.DateTime = *next
+	}
+
+	return res
+}
+
+// Weekday returns day number in Monday=1..Sunday=7 notation used by Event.Weekdays.
+func Weekday(t time.Time) int {
+	if t.Weekday() == time.Sunday {
+		return 7
+	}
+	return int(t.Weekday())
+}
+
+func nextWeekday(currentTime time.Time, weekdays []int) *time.Time {
+	if len(weekdays) == 0 {
+		return nil
+	}
+
+	nextTime := currentTime.AddDate(0, 0, 1)
+
+	for i := 1; i < 8; i++ {
+		if slices.Contains(weekdays, Weekday(nextTime)) {
+			t := time.Date(
+				nextTime.Year(), nextTime.Month(), nextTime.Day(),
+				currentTime.Hour(), currentTime.Minute(), 0, 0, currentTime.Location(),
+			)
+			return &t
+		}
+		nextTime = nextTime.AddDate(0, 0, 1)
+	}
+
+	return nil
+}
+
+// NextAfter returns the first occurrence of periodic event after t or nil for one-off events.
+// Event time is returned as is if it is already after t.
+func NextAfter(e ReminderEvent, t time.Time) *time.Time {
+	for e.DateTime.Before(t) || e.DateTime.Equal(t) {
+		next := NextTime(e)
+		if next == nil || !next.After(e.DateTime) {
+			return nil
+		}
+		e.DateTime = *next
+	}
+
+	return &e.DateTime
+}
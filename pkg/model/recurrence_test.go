@@ -0,0 +1,40 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+)
+
+func TestOccurrences(t *testing.T) {
+	loc := LoadLocation(DefaultTimeZone)
+	hourly, daily := db.PeriodicityHour, db.PeriodicityDay
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		name  string
+		event ReminderEvent
+		to    time.Time
+		count int
+		first time.Time
+	}{
+		{"old hourly series", ReminderEvent{DateTime: time.Date(2024, 1, 1, 9, 30, 0, 0, loc), Periodicity: &hourly}, from.AddDate(0, 0, 1), 24, from.Add(30 * time.Minute)},
+		{"limit within range", ReminderEvent{DateTime: time.Date(2024, 1, 1, 9, 30, 0, 0, loc), Periodicity: &hourly}, from.AddDate(0, 0, 62), maxOccurrences, from.Add(30 * time.Minute)},
+		{"daily from range start", ReminderEvent{DateTime: from, Periodicity: &daily}, from.AddDate(0, 0, 7), 7, from},
+		{"starts within range", ReminderEvent{DateTime: from.AddDate(0, 0, 5), Periodicity: &daily}, from.AddDate(0, 0, 7), 2, from.AddDate(0, 0, 5)},
+		{"one-off", ReminderEvent{DateTime: from.Add(time.Hour)}, from.AddDate(0, 0, 1), 1, from.Add(time.Hour)},
+		{"past one-off", ReminderEvent{DateTime: from.Add(-time.Hour)}, from.AddDate(0, 0, 1), 0, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		got := Occurrences(tt.event, from, tt.to, loc)
+		if len(got) != tt.count {
+			t.Errorf("%s: got %d occurrences, want %d", tt.name, len(got), tt.count)
+			continue
+		}
+		if len(got) > 0 && !got[0].Equal(tt.first) {
+			t.Errorf("%s: first occurrence %v, want %v", tt.name, got[0], tt.first)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package model
+
+import "time"
+
+// Smart snooze presets. Other presets are durations in minutes. Values are stored in user settings and callback data.
+const (
+	SnoozeEvening    = -1 // today at EveningHour, tomorrow if it has passed
+	SnoozeMorning    = -2 // tomorrow at MorningHour
+	SnoozeNextMonday = -3 // next Monday at MorningHour
+)
+
+const (
+	MorningHour = 9
+	EveningHour = 19
+)
+
+// SnoozeAt returns new event time for snooze preset. Durations are added to from,
+// smart targets are calculated from now in its location. It returns false for unknown presets.
+func SnoozeAt(preset int, from, now time.Time) (time.Time, bool) {
+	day := func(offset, hour int) time.Time {
+		return time.Date(now.Year(), now.Month(), now.Day()+offset, hour, 0, 0, 0, now.Location())
+	}
+
+	switch {
+	case preset > 0:
+		return from.Add(time.Duration(preset) * time.Minute), true
+	case preset == SnoozeEvening:
+		if t := day(0, EveningHour); t.After(now) {
+			return t, true
+		}
+		return day(1, EveningHour), true
+	case preset == SnoozeMorning:
+		return day(1, MorningHour), true
+	case preset == SnoozeNextMonday:
+		return day(8-Weekday(now), MorningHour), true
+	}
+
+	return time.Time{}, false
+}
+
+// SnoozeHour returns time of day of smart snooze preset.
+func SnoozeHour(preset int) int {
+	if preset == SnoozeEvening {
+		return EveningHour
+	}
+
+	return MorningHour
+}
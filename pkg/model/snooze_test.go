@@ -0,0 +1,42 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSnoozeAt(t *testing.T) {
+	loc := LoadLocation(DefaultTimeZone)
+	at := func(day, h, m int) time.Time {
+		return time.Date(2026, 3, day, h, m, 0, 0, loc) // 2026-03-02 is Monday
+	}
+
+	tests := []struct {
+		name   string
+		preset int
+		from   time.Time
+		now    time.Time
+		want   time.Time
+	}{
+		{"duration", 30, at(4, 10, 0), at(3, 10, 0), at(4, 10, 30)},
+		{"evening today", SnoozeEvening, at(3, 10, 0), at(3, 10, 0), at(3, 19, 0)},
+		{"evening passed", SnoozeEvening, at(3, 19, 0), at(3, 19, 0), at(4, 19, 0)},
+		{"morning", SnoozeMorning, at(3, 1, 0), at(3, 1, 0), at(4, 9, 0)},
+		{"monday from wednesday", SnoozeNextMonday, at(4, 10, 0), at(4, 10, 0), at(9, 9, 0)},
+		{"monday from monday", SnoozeNextMonday, at(2, 8, 0), at(2, 8, 0), at(9, 9, 0)},
+		{"monday from sunday", SnoozeNextMonday, at(8, 23, 0), at(8, 23, 0), at(9, 9, 0)},
+	}
+
+	for _, tt := range tests {
+		got, ok := SnoozeAt(tt.preset, tt.from, tt.now)
+		if !ok || !got.Equal(tt.want) {
+			t.Errorf("%s: got %v, %v, want %v", tt.name, got, ok, tt.want)
+		}
+	}
+
+	for _, preset := range []int{0, -100} {
+		if _, ok := SnoozeAt(preset, at(2, 8, 0), at(2, 8, 0)); ok {
+			t.Errorf("%d: unknown preset accepted", preset)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package model
+
+import (
+	"sync"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+)
+
+// DefaultTimeZone is used for users without settings.
+const DefaultTimeZone = "Europe/Moscow"
+
+// DefaultDigestTime is the daily digest time in minutes after midnight for users without settings.
+const DefaultDigestTime = 8 * 60
+
+// DefaultSnoozePresets are reminder snooze buttons in minutes for users without settings.
+var DefaultSnoozePresets = []int{5, 10}
+
+type User struct {
+	ID           int64
+	Name         string
+	LanguageCode string
+	Language     string
+	Location     *time.Location
+	// DigestTime is the daily digest time in minutes after midnight, nil if digest is off.
+	DigestTime *int
+	// PreviewTime is the evening preview time of tomorrow's events, nil if preview is off.
+	PreviewTime *int
+	// WeeklyTime is the Sunday review time of next week's events, nil if review is off.
+	WeeklyTime *int
+	// QuietFrom and QuietTo are quiet hours in minutes after midnight, nil if quiet hours are off.
+	QuietFrom     *int
+	QuietTo       *int
+	SnoozePresets []int
+	// VacationMode is how one-off events skipped during vacation are handled, see db.VacationModeSummary.
+	VacationMode string
+	// IcalToken is the secret of calendar subscription feed URL, empty if the feed is off.
+	IcalToken  string
+	LastSeenAt time.Time
+}
+
+// NewDefaultUser returns user with default settings.
+func NewDefaultUser(id int64) *User {
+	digestTime := DefaultDigestTime
+	return &User{
+		ID:            id,
+		Location:      LoadLocation(DefaultTimeZone),
+		DigestTime:    &digestTime,
+		SnoozePresets: DefaultSnoozePresets,
+		VacationMode:  db.VacationModeSummary,
+	}
+}
+
+func NewUser(dbUser *db.User) *User {
+	if dbUser == nil {
+		return nil
+	}
+
+	u := &User{
+		ID:            dbUser.UserTgID,
+		Location:      LoadLocation(dbUser.TimeZone),
+		DigestTime:    dbUser.DigestTime,
+		PreviewTime:   dbUser.PreviewTime,
+		WeeklyTime:    dbUser.WeeklyTime,
+		QuietFrom:     dbUser.QuietFrom,
+		QuietTo:       dbUser.QuietTo,
+		SnoozePresets: dbUser.SnoozePresets,
+		VacationMode:  dbUser.VacationMode,
+		LastSeenAt:    dbUser.LastSeenAt,
+	}
+	if dbUser.Name != nil {
+		u.Name = *dbUser.Name
+	}
+	if dbUser.LanguageCode != nil {
+		u.LanguageCode = *dbUser.LanguageCode
+	}
+	if dbUser.Language != nil {
+		u.Language = *dbUser.Language
+	}
+	if dbUser.IcalToken != nil {
+		u.IcalToken = *dbUser.IcalToken
+	}
+	if len(u.SnoozePresets) == 0 {
+		u.SnoozePresets = DefaultSnoozePresets
+	}
+	if u.VacationMode == "" {
+		u.VacationMode = db.VacationModeSummary
+	}
+
+	return u
+}
+
+// Quiet returns true if t is within user quiet hours. Quiet hours may span midnight, e.g. 23:00-08:00.
+func (u User) Quiet(t time.Time) bool {
+	if u.QuietFrom == nil || u.QuietTo == nil || *u.QuietFrom == *u.QuietTo {
+		return false
+	}
+
+	t = t.In(u.Location)
+	m := t.Hour()*60 + t.Minute()
+	if *u.QuietFrom < *u.QuietTo {
+		return m >= *u.QuietFrom && m < *u.QuietTo
+	}
+
+	return m >= *u.QuietFrom || m < *u.QuietTo
+}
+
+// locations caches loaded time zones by name.
+var locations sync.Map
+
+// LoadLocation returns time zone by IANA name. Unknown names get DefaultTimeZone.
+func LoadLocation(name string) *time.Location {
+	if loc, ok := locations.Load(name); ok {
+		return loc.(*time.Location)
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		if name == DefaultTimeZone {
+			return time.Local
+		}
+		return LoadLocation(DefaultTimeZone)
+	}
+
+	locations.Store(name, loc)
+	return loc
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUserQuiet(t *testing.T) {
+	minutes := func(h, m int) *int {
+		v := h*60 + m
+		return &v
+	}
+	at := func(h, m int) time.Time {
+		return time.Date(2026, 3, 5, h, m, 0, 0, LoadLocation(DefaultTimeZone))
+	}
+
+	tests := []struct {
+		name     string
+		from, to *int
+		t        time.Time
+		want     bool
+	}{
+		{"off", nil, nil, at(23, 30), false},
+		{"empty range", minutes(22, 0), minutes(22, 0), at(22, 0), false},
+		{"overnight start", minutes(22, 0), minutes(7, 0), at(22, 0), true},
+		{"overnight midnight", minutes(22, 0), minutes(7, 0), at(0, 0), true},
+		{"overnight end", minutes(22, 0), minutes(7, 0), at(7, 0), false},
+		{"overnight day", minutes(22, 0), minutes(7, 0), at(12, 0), false},
+		{"same day inside", minutes(0, 0), minutes(9, 0), at(8, 59), true},
+		{"same day outside", minutes(0, 0), minutes(9, 0), at(9, 0), false},
+		{"other zone", minutes(22, 0), minutes(7, 0), at(3, 0).In(time.UTC), true},
+	}
+
+	for _, tt := range tests {
+		u := NewDefaultUser(1)
+		u.QuietFrom, u.QuietTo = tt.from, tt.to
+		if got := u.Quiet(tt.t); got != tt.want {
+			t.Errorf("%s: got %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -19,7 +19,8 @@ type ReminderManager struct {
 
 type BotMessenger interface {
 	SendReminder(ctx context.Context, chatID int64, text string, eventID int)
-	SendReminderPeriodicity(ctx context.Context, id int64, text string)
+	SendReminderPeriodicity(ctx context.Context, chatID int64, text string, eventID int)
+	SendVacationSummary(ctx context.Context, chatID int64, events []db.Event)
 }
 
 func NewReminderManager(bm BotMessenger, eventsRepo db.EventsRepo, logger embedlog.Logger) *ReminderManager {
@@ -31,25 +32,163 @@ func NewReminderManager(bm BotMessenger, eventsRepo db.EventsRepo, logger embedl
 }
 
 func (rm *ReminderManager) ProcessReminders(ctx context.Context) error {
+	rm.finishVacations(ctx)
+
+	vacations, err := rm.eventsRepo.ActiveVacations(ctx, time.Now())
+	if err != nil {
+		rm.Errorf("Ошибка получения отпусков: %v", err)
+		return err
+	}
+
 	events, err := rm.eventsRepo.EventsToSend(ctx)
 	if err != nil {
 		rm.Errorf("Ошибка получения событий для отправки: %v", err)
 		return err
 	}
 
+	snoozes, err := rm.eventsRepo.SnoozesToSend(ctx, time.Now())
+	if err != nil {
+		rm.Errorf("Ошибка получения отложенных повторов: %v", err)
+		return err
+	}
+
+	userIDs := eventUserIDs(events)
+	for _, snooze := range snoozes {
+		if !slices.Contains(userIDs, snooze.UserTgID) {
+			userIDs = append(userIDs, snooze.UserTgID)
+		}
+	}
+
+	users, err := rm.eventsRepo.UsersByIDs(ctx, userIDs)
+	if err != nil {
+		rm.Errorf("Ошибка получения настроек пользователей: %v", err)
+		return err
+	}
+
+	now := time.Now()
 	for _, event := range events {
+		if vacation, ok := vacations[event.UserTgID]; ok {
+			rm.skipEvent(ctx, &event, vacation)
+			continue
+		}
+
+		// reminders are delayed until quiet hours end
+		if user, ok := users[event.UserTgID]; ok && model.NewUser(&user).Quiet(now) {
+			continue
+		}
 		rm.processEvent(ctx, &event)
 	}
 
+	for _, snooze := range snoozes {
+		if user, ok := users[snooze.UserTgID]; ok && model.NewUser(&user).Quiet(now) {
+			continue
+		}
+		_, onVacation := vacations[snooze.UserTgID]
+		rm.processSnooze(ctx, &snooze, onVacation)
+	}
+
 	return nil
 }
 
+// processSnooze re-delivers snoozed occurrence of periodic event. Occurrences of inactive events
+// and occurrences due during vacation are dropped, the series itself is not changed.
+func (rm *ReminderManager) processSnooze(ctx context.Context, snooze *db.Snooze, onVacation bool) {
+	if event := snooze.Event; event != nil && event.StatusID == db.StatusEnabled && !onVacation {
+		rm.bm.SendReminderPeriodicity(ctx, snooze.UserTgID, model.NewReminderEvent(event).FullText(), event.ID)
+	}
+
+	_, err := rm.eventsRepo.DeleteSnooze(ctx, snooze.ID)
+	if err != nil {
+		rm.Errorf("Ошибка удаления отложенного повтора %d: %v", snooze.ID, err)
+	}
+}
+
+// skipEvent moves periodic event to the first occurrence after vacation.
+// Skipped occurrence is kept in skippedAt, so the series could be rewound if vacation is cancelled.
+// One-off events are left as is and processed when vacation ends.
+func (rm *ReminderManager) skipEvent(ctx context.Context, event *db.Event, vacation db.Vacation) {
+	next := model.NextAfter(model.NewReminderEvent(event), vacation.EndsAt)
+	if next == nil {
+		return
+	}
+
+	if event.SkippedAt == nil {
+		event.SkippedAt = &event.SendAt
+	}
+	event.SendAt = *next
+	_, err := rm.eventsRepo.UpdateEvent(ctx, event, db.WithColumns(db.Columns.Event.SendAt, db.Columns.Event.SkippedAt))
+	if err != nil {
+		rm.Errorf("Ошибка обновления времени события %d: %v", event.ID, err)
+	}
+}
+
+// finishVacations processes one-off events skipped during ended vacations according to user vacation mode.
+func (rm *ReminderManager) finishVacations(ctx context.Context) {
+	vacations, err := rm.eventsRepo.EndedVacations(ctx)
+	if err != nil {
+		rm.Errorf("Ошибка получения завершённых отпусков: %v", err)
+		return
+	}
+
+	userIDs := make([]int64, 0, len(vacations))
+	for _, vacation := range vacations {
+		userIDs = append(userIDs, vacation.UserTgID)
+	}
+
+	users, err := rm.eventsRepo.UsersByIDs(ctx, userIDs)
+	if err != nil {
+		rm.Errorf("Ошибка получения настроек пользователей: %v", err)
+		return
+	}
+
+	for _, vacation := range vacations {
+		shift := users[vacation.UserTgID].VacationMode == db.VacationModeShift
+
+		events, err := rm.eventsRepo.SkippedEvents(ctx, vacation.UserTgID, vacation.StartsAt, vacation.EndsAt)
+		if err != nil {
+			rm.Errorf("Ошибка получения пропущенных событий пользователя %d: %v", vacation.UserTgID, err)
+			continue
+		}
+
+		for _, event := range events {
+			if shift {
+				event.SendAt = vacation.EndsAt
+				_, err = rm.eventsRepo.UpdateEvent(ctx, &event, db.WithColumns(db.Columns.Event.SendAt))
+			} else {
+				_, err = rm.eventsRepo.TrashEvent(ctx, event.ID)
+			}
+			if err != nil {
+				rm.Errorf("Ошибка обработки пропущенного события %d: %v", event.ID, err)
+			}
+		}
+
+		if !shift && len(events) > 0 {
+			rm.bm.SendVacationSummary(ctx, vacation.UserTgID, events)
+		}
+
+		if err = rm.eventsRepo.ClearSkippedEvents(ctx, vacation.UserTgID); err != nil {
+			rm.Errorf("Ошибка сброса пропущенных повторов пользователя %d: %v", vacation.UserTgID, err)
+		}
+
+		vacation.StatusID = db.StatusDisabled
+		_, err = rm.eventsRepo.UpdateVacation(ctx, &vacation, db.WithColumns(db.Columns.Vacation.StatusID))
+		if err != nil {
+			rm.Errorf("Ошибка завершения отпуска %d: %v", vacation.ID, err)
+		}
+	}
+}
+
 func (rm *ReminderManager) processEvent(ctx context.Context, event *db.Event) {
+	reminderEvent := model.NewReminderEvent(event)
+
 	if event.Periodicity != nil {
-		rm.bm.SendReminderPeriodicity(ctx, event.UserTgID, event.Message)
+		rm.bm.SendReminderPeriodicity(ctx, event.UserTgID, reminderEvent.FullText(), event.ID)
 
-		reminderEvent := model.NewReminderEvent(event)
 		nextTime := rm.CalculateNextTime(reminderEvent)
+		if nextTime != nil && !nextTime.After(time.Now()) {
+			// skip occurrences missed during quiet hours
+			nextTime = model.NextAfter(reminderEvent, time.Now())
+		}
 
 		if nextTime != nil {
 			event.SendAt = *nextTime
@@ -58,64 +197,29 @@ func (rm *ReminderManager) processEvent(ctx context.Context, event *db.Event) {
 				rm.Errorf("Ошибка обновления времени события %d: %v", event.ID, err)
 			}
 		} else {
-			_, err := rm.eventsRepo.DeleteEvent(ctx, event.ID)
+			// finished series goes to trash like other deleted events
+			_, err := rm.eventsRepo.TrashEvent(ctx, event.ID)
 			if err != nil {
 				rm.Errorf("Ошибка деактивации события %d: %v", event.ID, err)
 			}
 		}
 	} else {
-		rm.bm.SendReminder(ctx, event.UserTgID, event.Message, event.ID)
-	}
-}
-
-func (rm *ReminderManager) CalculateNextTime(e model.ReminderEvent) *time.Time {
-	if e.Periodicity == nil {
-		return nil
-	}
-
-	currentTime := e.DateTime
-
-	switch *e.Periodicity {
-	case "hour":
-		t := currentTime.Add(time.Hour)
-		return &t
-	case "day":
-		t := currentTime.Add(24 * time.Hour)
-		return &t
-	case "week":
-		t := currentTime.Add(7 * 24 * time.Hour)
-		return &t
-	case "weekdays":
-		return rm.calculateNextWeekday(currentTime, e.Weekdays)
-	default:
-		return nil
+		rm.bm.SendReminder(ctx, event.UserTgID, reminderEvent.FullText(), event.ID)
 	}
 }
 
-func (rm *ReminderManager) calculateNextWeekday(currentTime time.Time, weekdays []int) *time.Time {
-	if len(weekdays) == 0 {
-		return nil
-	}
-
-	nextTime := currentTime.Add(24 * time.Hour)
-
-	for i := 1; i < 8; i++ {
-		weekday := int(nextTime.Weekday())
-		if weekday == 0 {
-			weekday = 7
-		} else {
-			weekday = weekday - 1
-		}
-
-		if slices.Contains(weekdays, weekday) {
-			t := time.Date(
-				nextTime.Year(), nextTime.Month(), nextTime.Day(),
-				currentTime.Hour(), currentTime.Minute(), 0, 0, currentTime.Location(),
-			)
-			return &t
+// eventUserIDs returns unique users of events.
+func eventUserIDs(events []db.Event) []int64 {
+	var res []int64
+	for _, event := range events {
+		if !slices.Contains(res, event.UserTgID) {
+			res = append(res, event.UserTgID)
 		}
-		nextTime = nextTime.Add(24 * time.Hour)
 	}
 
-	return nil
+	return res
+}
+
+func (rm *ReminderManager) CalculateNextTime(e model.ReminderEvent) *time.Time {
+	return model.NextTime(e)
 }
@@ -0,0 +1,272 @@
+package reminder
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+	"event-reminder-bot/pkg/model"
+)
+
+const (
+	summaryUserID int64 = 900000031
+	shiftUserID   int64 = 900000032
+)
+
+// testMessenger records sent messages instead of sending them to Telegram.
+type testMessenger struct {
+	reminders []int
+	summaries map[int64]int
+}
+
+func (m *testMessenger) SendReminder(_ context.Context, _ int64, _ string, eventID int) {
+	m.reminders = append(m.reminders, eventID)
+}
+
+func (m *testMessenger) SendReminderPeriodicity(_ context.Context, _ int64, _ string, eventID int) {
+	m.reminders = append(m.reminders, eventID)
+}
+
+func (m *testMessenger) SendVacationSummary(_ context.Context, chatID int64, events []db.Event) {
+	m.summaries[chatID] += len(events)
+}
+
+// newTestManager returns ReminderManager with recording messenger and clean data of test users.
+func newTestManager(t *testing.T) (*ReminderManager, *testMessenger) {
+	t.Helper()
+
+	dbc, logger := test.Setup(t)
+
+	cleanup := func() {
+		for _, q := range []string{
+			`DELETE FROM "snoozes" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "events" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "vacations" WHERE "userTgId" IN (?, ?)`,
+			`DELETE FROM "users" WHERE "userTgId" IN (?, ?)`,
+		} {
+			if _, err := dbc.Exec(q, summaryUserID, shiftUserID); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	m := &testMessenger{summaries: map[int64]int{}}
+	return NewReminderManager(m, db.NewEventsRepo(dbc), logger), m
+}
+
+func TestDBSkipEvent(t *testing.T) {
+	rm, _ := newTestManager(t)
+	ctx := context.Background()
+
+	loc := model.LoadLocation(model.DefaultTimeZone)
+	at := func(day, h, m int) time.Time {
+		return time.Date(2026, 3, day, h, m, 0, 0, loc) // 2026-03-02 is Monday
+	}
+	vacation := db.Vacation{UserTgID: summaryUserID, StartsAt: at(2, 0, 0), EndsAt: at(9, 0, 0)}
+
+	tests := []struct {
+		name        string
+		periodicity *string
+		weekdays    []int
+		sendAt      time.Time
+		want        time.Time
+	}{
+		{"hourly", test.Ptr(db.PeriodicityHour), nil, at(2, 9, 30), at(9, 0, 30)},
+		{"daily", test.Ptr(db.PeriodicityDay), nil, at(2, 9, 0), at(9, 9, 0)},
+		{"weekly", test.Ptr(db.PeriodicityWeek), nil, at(4, 9, 0), at(11, 9, 0)},
+		{"weekdays", test.Ptr(db.PeriodicityWeekdays), []int{1, 5}, at(6, 9, 0), at(9, 9, 0)},
+		{"one-off", nil, nil, at(2, 9, 0), at(2, 9, 0)},
+	}
+
+	for _, tt := range tests {
+		event, err := rm.eventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID:    summaryUserID,
+			Message:     tt.name,
+			SendAt:      tt.sendAt,
+			StatusID:    db.StatusEnabled,
+			Weekdays:    tt.weekdays,
+			Periodicity: tt.periodicity,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rm.skipEvent(ctx, event, vacation)
+
+		got, err := rm.eventsRepo.EventByID(ctx, event.ID)
+		switch {
+		case err != nil:
+			t.Fatal(err)
+		case !got.SendAt.Equal(tt.want):
+			t.Errorf("%s: got sendAt %v, want %v", tt.name, got.SendAt, tt.want)
+		case tt.periodicity == nil && got.SkippedAt != nil:
+			t.Errorf("%s: one-off event marked as skipped", tt.name)
+		case tt.periodicity != nil && (got.SkippedAt == nil || !got.SkippedAt.Equal(tt.sendAt)):
+			t.Errorf("%s: got skippedAt %v, want %v", tt.name, got.SkippedAt, tt.sendAt)
+		}
+	}
+}
+
+func TestDBFinishVacations(t *testing.T) {
+	rm, m := newTestManager(t)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Minute)
+	tests := []struct {
+		userTgID int64
+		mode     string
+	}{
+		{summaryUserID, db.VacationModeSummary},
+		{shiftUserID, db.VacationModeShift},
+	}
+
+	type testCase struct {
+		vacation         *db.Vacation
+		oneOff, periodic *db.Event
+	}
+	cases := make([]testCase, len(tests))
+	for i, tt := range tests {
+		if _, err := rm.eventsRepo.AddUser(ctx, &db.User{UserTgID: tt.userTgID, TimeZone: model.DefaultTimeZone, SnoozePresets: []int{5}, VacationMode: tt.mode}); err != nil {
+			t.Fatal(err)
+		}
+
+		vacation, err := rm.eventsRepo.AddVacation(ctx, &db.Vacation{
+			UserTgID: tt.userTgID,
+			StartsAt: now.Add(-48 * time.Hour),
+			EndsAt:   now.Add(-time.Minute),
+			StatusID: db.StatusEnabled,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		oneOff, err := rm.eventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID: tt.userTgID,
+			Message:  "one-off",
+			SendAt:   now.Add(-24 * time.Hour),
+			StatusID: db.StatusEnabled,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		periodic, err := rm.eventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID:    tt.userTgID,
+			Message:     "periodic",
+			SendAt:      now.Add(23 * time.Hour),
+			StatusID:    db.StatusEnabled,
+			Periodicity: test.Ptr(db.PeriodicityDay),
+			SkippedAt:   test.Ptr(now.Add(-25 * time.Hour)),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cases[i] = testCase{vacation: vacation, oneOff: oneOff, periodic: periodic}
+	}
+
+	rm.finishVacations(ctx)
+
+	for i, tt := range tests {
+		c := cases[i]
+
+		trashed, err := rm.eventsRepo.TrashedEventByID(ctx, tt.userTgID, c.oneOff.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		oneOff, err := rm.eventsRepo.EventByID(ctx, c.oneOff.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		switch tt.mode {
+		case db.VacationModeSummary:
+			if trashed == nil {
+				t.Errorf("%s: skipped event is not moved to trash", tt.mode)
+			}
+			if m.summaries[tt.userTgID] != 1 {
+				t.Errorf("%s: got %d events in summary, want 1", tt.mode, m.summaries[tt.userTgID])
+			}
+		case db.VacationModeShift:
+			if oneOff == nil || !oneOff.SendAt.Equal(c.vacation.EndsAt) {
+				t.Errorf("%s: skipped event is not moved to vacation end: %v", tt.mode, oneOff)
+			}
+			if m.summaries[tt.userTgID] != 0 {
+				t.Errorf("%s: summary sent", tt.mode)
+			}
+		}
+
+		periodic, err := rm.eventsRepo.EventByID(ctx, c.periodic.ID)
+		switch {
+		case err != nil:
+			t.Fatal(err)
+		case !periodic.SendAt.Equal(c.periodic.SendAt):
+			t.Errorf("%s: periodic event moved to %v", tt.mode, periodic.SendAt)
+		case periodic.SkippedAt != nil:
+			t.Errorf("%s: skippedAt is not cleared", tt.mode)
+		}
+
+		vacation, err := rm.eventsRepo.VacationByID(ctx, c.vacation.ID)
+		if err != nil {
+			t.Fatal(err)
+		} else if vacation.StatusID != db.StatusDisabled {
+			t.Errorf("%s: vacation is not finished", tt.mode)
+		}
+	}
+}
+
+// TestDBProcessSnooze checks that snoozed occurrences are sent only for active events of users not on vacation.
+func TestDBProcessSnooze(t *testing.T) {
+	rm, m := newTestManager(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name       string
+		statusID   int
+		onVacation bool
+		sent       bool
+	}{
+		{"active", db.StatusEnabled, false, true},
+		{"on vacation", db.StatusEnabled, true, false},
+		{"paused", db.StatusDisabled, false, false},
+	}
+
+	for _, tt := range tests {
+		event, err := rm.eventsRepo.AddEvent(ctx, &db.Event{
+			UserTgID:    summaryUserID,
+			Message:     tt.name,
+			SendAt:      time.Now().Add(24 * time.Hour).Truncate(time.Minute),
+			StatusID:    tt.statusID,
+			Weekdays:    []int{},
+			Periodicity: test.Ptr(db.PeriodicityDay),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		snooze, err := rm.eventsRepo.AddSnooze(ctx, &db.Snooze{EventID: event.ID, UserTgID: summaryUserID, SendAt: time.Now()})
+		if err != nil {
+			t.Fatal(err)
+		}
+		snooze.Event = event
+
+		m.reminders = nil
+		rm.processSnooze(ctx, snooze, tt.onVacation)
+
+		if sent := slices.Contains(m.reminders, event.ID); sent != tt.sent {
+			t.Errorf("%s: sent %v, want %v", tt.name, sent, tt.sent)
+		}
+
+		count, err := rm.eventsRepo.CountSnoozes(ctx, &db.SnoozeSearch{EventID: &event.ID})
+		if err != nil {
+			t.Fatal(err)
+		} else if count != 0 {
+			t.Errorf("%s: snooze is not removed", tt.name)
+		}
+	}
+}
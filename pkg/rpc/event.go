@@ -0,0 +1,213 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"event-reminder-bot/pkg/db"
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/vmkteam/embedlog"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+// maxPageSize limits events per page of list and search.
+const maxPageSize = 100
+
+// EventService manages events of the authenticated user.
+type EventService struct {
+	zenrpc.Service
+	embedlog.Logger
+	bm *botManager.BotManager
+}
+
+func NewEventService(bm *botManager.BotManager, logger embedlog.Logger) *EventService {
+	return &EventService{
+		Logger: logger,
+		bm:     bm,
+	}
+}
+
+// Get returns active or paused event.
+//
+//zenrpc:id event ID
+//zenrpc:404 event not found
+func (s EventService) Get(ctx context.Context, id int) (*Event, error) {
+	event, err := s.bm.UserEvent(ctx, userID(ctx), id)
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	res := newEvent(model.NewEvent(event))
+	return &res, nil
+}
+
+// List returns active and paused events in the order of /list command.
+//
+//zenrpc:page=1 page number
+//zenrpc:pageSize=20 events per page, up to 100
+//zenrpc:tag tag without "#", all events if empty
+func (s EventService) List(ctx context.Context, page, pageSize int, tag *string) (*EventList, error) {
+	chatID := userID(ctx)
+	page, pageSize = pager(page, pageSize)
+
+	var tagID int
+	if tag != nil && *tag != "" {
+		t, err := s.bm.EventsRepo.TagByTitle(ctx, chatID, strings.ToLower(strings.TrimPrefix(*tag, "#")))
+		if err != nil {
+			return nil, s.eventError(err)
+		} else if t == nil {
+			return &EventList{Events: []Event{}}, nil
+		}
+		tagID = t.ID
+	}
+
+	events, total, err := s.bm.GetUserEventsPaged(ctx, chatID, page, pageSize, tagID)
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	res := newEventList(events, total)
+	return &res, nil
+}
+
+// Search returns active events matching query by title and notes, best matches first.
+//
+//zenrpc:query search words, typos are tolerated
+//zenrpc:page=1 page number
+//zenrpc:pageSize=20 events per page, up to 100
+//zenrpc:400 empty query
+func (s EventService) Search(ctx context.Context, query string, page, pageSize int) (*EventList, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, zenrpc.NewStringError(http.StatusBadRequest, "empty_query")
+	}
+
+	page, pageSize = pager(page, pageSize)
+	events, total, err := s.bm.EventsRepo.SearchUserEvents(ctx, userID(ctx), query, db.NewPager(page, pageSize))
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	res := newEventList(model.NewEvents(events), total)
+	return &res, nil
+}
+
+// Create adds event with tags from its title and notes. Title and sendAt are required.
+//
+//zenrpc:event new event
+//zenrpc:400 invalid event: empty_title, text_too_long, notes_too_long, past_date, invalid_periodicity or periodic limit
+func (s EventService) Create(ctx context.Context, event EventInput) (*Event, error) {
+	chatID := userID(ctx)
+	if event.Title == nil || event.SendAt == nil {
+		return nil, zenrpc.NewStringError(http.StatusBadRequest, "title and sendAt are required")
+	}
+
+	var notes string
+	if event.Notes != nil {
+		notes = strings.TrimSpace(*event.Notes)
+	}
+
+	var periodicity string
+	if event.Periodicity != nil {
+		periodicity = *event.Periodicity
+	}
+
+	added, err := s.bm.CreatePeriodicEvent(ctx, chatID, strings.TrimSpace(*event.Title), notes, *event.SendAt, periodicity, event.Weekdays)
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	res := newEvent(model.NewEvent(added))
+	return &res, nil
+}
+
+// Update changes event fields, omitted fields are kept.
+//
+//zenrpc:id event ID
+//zenrpc:event changed fields
+//zenrpc:400 invalid event: empty_title, text_too_long, notes_too_long, past_date, invalid_periodicity or periodic limit
+//zenrpc:404 event not found
+func (s EventService) Update(ctx context.Context, id int, event EventInput) (*Event, error) {
+	chatID := userID(ctx)
+	if event.Title != nil {
+		*event.Title = strings.TrimSpace(*event.Title)
+	}
+	if event.Notes != nil {
+		*event.Notes = strings.TrimSpace(*event.Notes)
+	}
+
+	updated, err := s.bm.EditEvent(ctx, chatID, id, event.Title, event.Notes, event.SendAt, event.Periodicity, event.Weekdays)
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	res := newEvent(model.NewEvent(updated))
+	return &res, nil
+}
+
+// Delete moves event to trash, it could be restored with /trash bot command.
+//
+//zenrpc:id event ID
+//zenrpc:404 event not found
+func (s EventService) Delete(ctx context.Context, id int) (bool, error) {
+	if err := s.bm.DeleteEventByID(ctx, userID(ctx), id); err != nil {
+		return false, s.eventError(err)
+	}
+
+	return true, nil
+}
+
+// Snooze reschedules active event. One-off events are moved, periodic events get one more reminder of the current occurrence.
+//
+//zenrpc:id event ID
+//zenrpc:snooze new reminder time
+//zenrpc:400 past_date or event not active
+//zenrpc:404 event not found
+func (s EventService) Snooze(ctx context.Context, id int, snooze SnoozeInput) (*Event, error) {
+	chatID := userID(ctx)
+	event, err := s.bm.UserEvent(ctx, chatID, id)
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	if event.Periodicity != nil {
+		err = s.bm.SnoozeOccurrence(ctx, chatID, id, snooze.SendAt)
+	} else {
+		err = s.bm.SnoozeEvent(ctx, id, chatID, snooze.SendAt)
+		event.SendAt = snooze.SendAt
+	}
+	if err != nil {
+		return nil, s.eventError(err)
+	}
+
+	res := newEvent(model.NewEvent(event))
+	return &res, nil
+}
+
+// eventError converts bot manager errors to RPC errors, unexpected errors are logged.
+func (s EventService) eventError(err error) error {
+	switch {
+	case errors.Is(err, botManager.ErrNotFound), errors.Is(err, botManager.ErrAccessDenied):
+		return ErrNotFound
+	case errors.Is(err, botManager.ErrPastDate), errors.Is(err, botManager.ErrInactive),
+		errors.Is(err, botManager.ErrInvalidPeriodicity), errors.Is(err, botManager.ErrPeriodicLimit):
+		return zenrpc.NewError(http.StatusBadRequest, err)
+	}
+
+	switch err.Error() {
+	case "empty_title", "text_too_long", "notes_too_long", "past_date":
+		return zenrpc.NewError(http.StatusBadRequest, err)
+	}
+
+	s.Errorf("Ошибка API событий: %v", err)
+	return ErrInternal
+}
+
+// pager returns page and page size within limits.
+func pager(page, pageSize int) (int, int) {
+	return max(page, 1), min(max(pageSize, 1), maxPageSize)
+}
@@ -0,0 +1,122 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/db/test"
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+
+	"github.com/vmkteam/embedlog"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+const testUserID int64 = 900000011
+
+func invalidPeriodicityInputs() []EventInput {
+	return []EventInput{
+		{Periodicity: test.Ptr("month")},
+		{Periodicity: test.Ptr(db.PeriodicityWeekdays)},
+		{Periodicity: test.Ptr(db.PeriodicityWeekdays), Weekdays: []int{1, 8}},
+	}
+}
+
+func assertBadRequest(t *testing.T, name string, err error) {
+	t.Helper()
+
+	var rpcErr *zenrpc.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != http.StatusBadRequest {
+		t.Errorf("%s: got %v, want bad request", name, err)
+	}
+}
+
+// TestCreateInvalidPeriodicity checks that invalid periodicity is rejected before insert:
+// the repository has no connection, so any query would panic.
+func TestCreateInvalidPeriodicity(t *testing.T) {
+	s := NewEventService(botManager.NewBotManager(nil, db.EventsRepo{}, "test", embedlog.Logger{}), embedlog.Logger{})
+	ctx := context.WithValue(context.Background(), userIDKey, testUserID)
+
+	for _, in := range invalidPeriodicityInputs() {
+		in.Title, in.SendAt = test.Ptr("Gym"), test.Ptr(time.Now().Add(time.Hour))
+		res, err := s.Create(ctx, in)
+		if res != nil {
+			t.Errorf("%s: event created", *in.Periodicity)
+		}
+		assertBadRequest(t, *in.Periodicity, err)
+	}
+}
+
+func TestDBInvalidPeriodicity(t *testing.T) {
+	dbc, logger := test.Setup(t)
+	repo := db.NewEventsRepo(dbc)
+	s := NewEventService(botManager.NewBotManager(nil, repo, "test", logger), logger)
+	ctx := context.WithValue(context.Background(), userIDKey, testUserID)
+
+	cleanup := func() {
+		if _, err := dbc.Exec(`DELETE FROM "events" WHERE "userTgId" = ?`, testUserID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cleanup()
+	t.Cleanup(cleanup)
+
+	sendAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	for _, in := range invalidPeriodicityInputs() {
+		in.Title, in.SendAt = test.Ptr("Gym"), &sendAt
+		_, err := s.Create(ctx, in)
+		assertBadRequest(t, "create "+*in.Periodicity, err)
+	}
+	count, err := repo.CountEvents(ctx, &db.EventSearch{UserTgID: test.Ptr(testUserID)})
+	if err != nil {
+		t.Fatal(err)
+	} else if count != 0 {
+		t.Errorf("events created with invalid periodicity: %d", count)
+	}
+
+	event, err := s.Create(ctx, EventInput{Title: test.Ptr("Gym"), SendAt: &sendAt})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, in := range invalidPeriodicityInputs() {
+		in.Title, in.SendAt = test.Ptr("Changed"), test.Ptr(sendAt.Add(time.Hour))
+		_, err = s.Update(ctx, event.ID, in)
+		assertBadRequest(t, "update "+*in.Periodicity, err)
+	}
+
+	got, err := s.Get(ctx, event.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "Gym" || !got.SendAt.Equal(sendAt) || got.Periodicity != "" {
+		t.Errorf("event changed by invalid update: %+v", got)
+	}
+
+	// periodic events are inserted and updated at once with normalized weekdays
+	periodic, err := s.Create(ctx, EventInput{Title: test.Ptr("Yoga"), SendAt: &sendAt, Periodicity: test.Ptr(db.PeriodicityWeekdays), Weekdays: []int{5, 1, 5}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if periodic.Periodicity != db.PeriodicityWeekdays || !slices.Equal(periodic.Weekdays, []int{1, 5}) {
+		t.Errorf("periodic event created as %+v", periodic)
+	}
+
+	updated, err := s.Update(ctx, periodic.ID, EventInput{Title: test.Ptr("Run"), Periodicity: test.Ptr(db.PeriodicityDay)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "Run" || updated.Periodicity != db.PeriodicityDay || len(updated.Weekdays) != 0 {
+		t.Errorf("periodic event updated as %+v", updated)
+	}
+
+	trashed, err := repo.TrashedEvents(ctx, testUserID, time.Time{}, db.PagerNoLimit)
+	if err != nil {
+		t.Fatal(err)
+	} else if len(trashed) > 0 {
+		t.Errorf("events moved to trash: %d", len(trashed))
+	}
+}
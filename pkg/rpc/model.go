@@ -0,0 +1,82 @@
+package rpc
+
+import (
+	"time"
+
+	"event-reminder-bot/pkg/model"
+)
+
+// Event statuses.
+const (
+	StatusActive = "active"
+	StatusPaused = "paused"
+)
+
+type Event struct {
+	ID    int     `json:"id"`
+	Title string  `json:"title"`
+	Notes *string `json:"notes,omitempty"`
+	// SendAt is the next reminder time.
+	SendAt time.Time `json:"sendAt"`
+	// Status is active or paused.
+	Status string `json:"status"`
+	// Periodicity is hour, day, week or weekdays, empty for one-off events.
+	Periodicity string `json:"periodicity,omitempty"`
+	// Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.
+	Weekdays []int `json:"weekdays,omitempty"`
+	// ResumeAt is the time when paused event is resumed automatically.
+	ResumeAt *time.Time `json:"resumeAt,omitempty"`
+}
+
+type EventList struct {
+	Events []Event `json:"events"`
+	Total  int     `json:"total"`
+}
+
+// EventInput is a new event or changed fields of event, nil fields are kept.
+type EventInput struct {
+	Title *string `json:"title"`
+	// Notes are removed if empty.
+	Notes  *string    `json:"notes"`
+	SendAt *time.Time `json:"sendAt"`
+	// Periodicity is hour, day, week or weekdays, empty makes the event one-off.
+	Periodicity *string `json:"periodicity"`
+	// Weekdays are required for weekdays periodicity, Monday is 1 and Sunday is 7.
+	Weekdays []int `json:"weekdays"`
+}
+
+// SnoozeInput is a new reminder time of event.
+type SnoozeInput struct {
+	SendAt time.Time `json:"sendAt"`
+}
+
+func newEvent(e *model.Event) Event {
+	res := Event{
+		ID:       e.ID,
+		Title:    e.Text,
+		SendAt:   e.DateTime,
+		Status:   StatusActive,
+		ResumeAt: e.ResumeAt,
+	}
+	if e.Notes != "" {
+		res.Notes = &e.Notes
+	}
+	if e.Paused {
+		res.Status = StatusPaused
+	}
+	if e.Periodicity != nil {
+		res.Periodicity = *e.Periodicity
+		res.Weekdays = e.Weekdays
+	}
+
+	return res
+}
+
+func newEventList(events []model.Event, total int) EventList {
+	res := EventList{Events: make([]Event, 0, len(events)), Total: total}
+	for i := range events {
+		res.Events = append(res.Events, newEvent(&events[i]))
+	}
+
+	return res
+}
@@ -0,0 +1,40 @@
+package rpc
+
+import (
+	"testing"
+	"time"
+
+	"event-reminder-bot/pkg/db"
+	"event-reminder-bot/pkg/model"
+)
+
+func TestNewEvent(t *testing.T) {
+	at := time.Date(2026, time.October, 18, 9, 30, 0, 0, time.UTC)
+	weekdays := db.PeriodicityWeekdays
+
+	got := newEvent(&model.Event{ID: 1, Text: "Gym", DateTime: at, Periodicity: &weekdays, Weekdays: []int{1, 3}, Paused: true, ResumeAt: &at})
+	if got.Status != StatusPaused || got.Periodicity != weekdays || len(got.Weekdays) != 2 || got.Notes != nil || got.ResumeAt == nil {
+		t.Errorf("periodic paused event = %+v", got)
+	}
+
+	got = newEvent(&model.Event{ID: 2, Text: "Dentist", Notes: "Bring card", DateTime: at, Weekdays: []int{}})
+	if got.Status != StatusActive || got.Periodicity != "" || got.Weekdays != nil || got.Notes == nil || *got.Notes != "Bring card" {
+		t.Errorf("one-off event = %+v", got)
+	}
+}
+
+func TestPager(t *testing.T) {
+	tests := []struct {
+		page, pageSize, wantPage, wantSize int
+	}{
+		{0, 0, 1, 1},
+		{2, 20, 2, 20},
+		{-1, 1000, 1, maxPageSize},
+	}
+
+	for _, tt := range tests {
+		if page, size := pager(tt.page, tt.pageSize); page != tt.wantPage || size != tt.wantSize {
+			t.Errorf("pager(%d, %d) = %d, %d", tt.page, tt.pageSize, page, size)
+		}
+	}
+}
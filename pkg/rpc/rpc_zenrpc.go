@@ -0,0 +1,739 @@
+// Code generated by zenrpc v2.2.12; DO NOT EDIT.
+
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/vmkteam/zenrpc/v2"
+	"github.com/vmkteam/zenrpc/v2/smd"
+)
+
+var RPC = struct {
+	EventService struct{ Get, List, Search, Create, Update, Delete, Snooze string }
+}{
+	EventService: struct{ Get, List, Search, Create, Update, Delete, Snooze string }{
+		Get:    "get",
+		List:   "list",
+		Search: "search",
+		Create: "create",
+		Update: "update",
+		Delete: "delete",
+		Snooze: "snooze",
+	},
+}
+
+func (EventService) SMD() smd.ServiceInfo {
+	return smd.ServiceInfo{
+		Methods: map[string]smd.Service{
+			"Get": {
+				Description: `Get returns active or paused event.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `event ID`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Optional: true,
+					Type:     smd.Object,
+					TypeName: "Event",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name:     "notes",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:        "sendAt",
+							Description: `SendAt is the next reminder time.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "status",
+							Description: `Status is active or paused.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "periodicity",
+							Description: `Periodicity is hour, day, week or weekdays, empty for one-off events.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "weekdays",
+							Description: `Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.`,
+							Type:        smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:        "resumeAt",
+							Optional:    true,
+							Description: `ResumeAt is the time when paused event is resumed automatically.`,
+							Type:        smd.String,
+						},
+					},
+				},
+				Errors: map[int]string{
+					404: "event not found",
+				},
+			},
+			"List": {
+				Description: `List returns active and paused events in the order of /list command.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "page",
+						Optional:    true,
+						Description: `page number`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "pageSize",
+						Optional:    true,
+						Description: `events per page, up to 100`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "tag",
+						Optional:    true,
+						Description: `tag without "#", all events if empty`,
+						Type:        smd.String,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Optional: true,
+					Type:     smd.Object,
+					TypeName: "EventList",
+					Properties: smd.PropertyList{
+						{
+							Name: "events",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/Event",
+							},
+						},
+						{
+							Name: "total",
+							Type: smd.Integer,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Event": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "notes",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:        "sendAt",
+									Description: `SendAt is the next reminder time.`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "status",
+									Description: `Status is active or paused.`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "periodicity",
+									Description: `Periodicity is hour, day, week or weekdays, empty for one-off events.`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "weekdays",
+									Description: `Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.`,
+									Type:        smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name:        "resumeAt",
+									Optional:    true,
+									Description: `ResumeAt is the time when paused event is resumed automatically.`,
+									Type:        smd.String,
+								},
+							},
+						},
+					},
+				},
+			},
+			"Search": {
+				Description: `Search returns active events matching query by title and notes, best matches first.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "query",
+						Description: `search words, typos are tolerated`,
+						Type:        smd.String,
+					},
+					{
+						Name:        "page",
+						Optional:    true,
+						Description: `page number`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "pageSize",
+						Optional:    true,
+						Description: `events per page, up to 100`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Optional: true,
+					Type:     smd.Object,
+					TypeName: "EventList",
+					Properties: smd.PropertyList{
+						{
+							Name: "events",
+							Type: smd.Array,
+							Items: map[string]string{
+								"$ref": "#/definitions/Event",
+							},
+						},
+						{
+							Name: "total",
+							Type: smd.Integer,
+						},
+					},
+					Definitions: map[string]smd.Definition{
+						"Event": {
+							Type: "object",
+							Properties: smd.PropertyList{
+								{
+									Name: "id",
+									Type: smd.Integer,
+								},
+								{
+									Name: "title",
+									Type: smd.String,
+								},
+								{
+									Name:     "notes",
+									Optional: true,
+									Type:     smd.String,
+								},
+								{
+									Name:        "sendAt",
+									Description: `SendAt is the next reminder time.`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "status",
+									Description: `Status is active or paused.`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "periodicity",
+									Description: `Periodicity is hour, day, week or weekdays, empty for one-off events.`,
+									Type:        smd.String,
+								},
+								{
+									Name:        "weekdays",
+									Description: `Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.`,
+									Type:        smd.Array,
+									Items: map[string]string{
+										"type": smd.Integer,
+									},
+								},
+								{
+									Name:        "resumeAt",
+									Optional:    true,
+									Description: `ResumeAt is the time when paused event is resumed automatically.`,
+									Type:        smd.String,
+								},
+							},
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "empty query",
+				},
+			},
+			"Create": {
+				Description: `Create adds event with tags from its title and notes. Title and sendAt are required.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "event",
+						Description: `new event`,
+						Type:        smd.Object,
+						TypeName:    "EventInput",
+						Properties: smd.PropertyList{
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:        "notes",
+								Optional:    true,
+								Description: `Notes are removed if empty.`,
+								Type:        smd.String,
+							},
+							{
+								Name:     "sendAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:        "periodicity",
+								Optional:    true,
+								Description: `Periodicity is hour, day, week or weekdays, empty makes the event one-off.`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "weekdays",
+								Description: `Weekdays are required for weekdays periodicity, Monday is 1 and Sunday is 7.`,
+								Type:        smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Optional: true,
+					Type:     smd.Object,
+					TypeName: "Event",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name:     "notes",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:        "sendAt",
+							Description: `SendAt is the next reminder time.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "status",
+							Description: `Status is active or paused.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "periodicity",
+							Description: `Periodicity is hour, day, week or weekdays, empty for one-off events.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "weekdays",
+							Description: `Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.`,
+							Type:        smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:        "resumeAt",
+							Optional:    true,
+							Description: `ResumeAt is the time when paused event is resumed automatically.`,
+							Type:        smd.String,
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "invalid event: empty_title, text_too_long, notes_too_long, past_date, invalid_periodicity or periodic limit",
+				},
+			},
+			"Update": {
+				Description: `Update changes event fields, omitted fields are kept.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `event ID`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "event",
+						Description: `changed fields`,
+						Type:        smd.Object,
+						TypeName:    "EventInput",
+						Properties: smd.PropertyList{
+							{
+								Name:     "title",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:        "notes",
+								Optional:    true,
+								Description: `Notes are removed if empty.`,
+								Type:        smd.String,
+							},
+							{
+								Name:     "sendAt",
+								Optional: true,
+								Type:     smd.String,
+							},
+							{
+								Name:        "periodicity",
+								Optional:    true,
+								Description: `Periodicity is hour, day, week or weekdays, empty makes the event one-off.`,
+								Type:        smd.String,
+							},
+							{
+								Name:        "weekdays",
+								Description: `Weekdays are required for weekdays periodicity, Monday is 1 and Sunday is 7.`,
+								Type:        smd.Array,
+								Items: map[string]string{
+									"type": smd.Integer,
+								},
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Optional: true,
+					Type:     smd.Object,
+					TypeName: "Event",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name:     "notes",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:        "sendAt",
+							Description: `SendAt is the next reminder time.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "status",
+							Description: `Status is active or paused.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "periodicity",
+							Description: `Periodicity is hour, day, week or weekdays, empty for one-off events.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "weekdays",
+							Description: `Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.`,
+							Type:        smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:        "resumeAt",
+							Optional:    true,
+							Description: `ResumeAt is the time when paused event is resumed automatically.`,
+							Type:        smd.String,
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "invalid event: empty_title, text_too_long, notes_too_long, past_date, invalid_periodicity or periodic limit",
+					404: "event not found",
+				},
+			},
+			"Delete": {
+				Description: `Delete moves event to trash, it could be restored with /trash bot command.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `event ID`,
+						Type:        smd.Integer,
+					},
+				},
+				Returns: smd.JSONSchema{
+					Type: smd.Boolean,
+				},
+				Errors: map[int]string{
+					404: "event not found",
+				},
+			},
+			"Snooze": {
+				Description: `Snooze reschedules active event. One-off events are moved, periodic events get one more reminder of the current occurrence.`,
+				Parameters: []smd.JSONSchema{
+					{
+						Name:        "id",
+						Description: `event ID`,
+						Type:        smd.Integer,
+					},
+					{
+						Name:        "snooze",
+						Description: `new reminder time`,
+						Type:        smd.Object,
+						TypeName:    "SnoozeInput",
+						Properties: smd.PropertyList{
+							{
+								Name: "sendAt",
+								Type: smd.String,
+							},
+						},
+					},
+				},
+				Returns: smd.JSONSchema{
+					Optional: true,
+					Type:     smd.Object,
+					TypeName: "Event",
+					Properties: smd.PropertyList{
+						{
+							Name: "id",
+							Type: smd.Integer,
+						},
+						{
+							Name: "title",
+							Type: smd.String,
+						},
+						{
+							Name:     "notes",
+							Optional: true,
+							Type:     smd.String,
+						},
+						{
+							Name:        "sendAt",
+							Description: `SendAt is the next reminder time.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "status",
+							Description: `Status is active or paused.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "periodicity",
+							Description: `Periodicity is hour, day, week or weekdays, empty for one-off events.`,
+							Type:        smd.String,
+						},
+						{
+							Name:        "weekdays",
+							Description: `Weekdays are days of weekdays periodicity, Monday is 1 and Sunday is 7.`,
+							Type:        smd.Array,
+							Items: map[string]string{
+								"type": smd.Integer,
+							},
+						},
+						{
+							Name:        "resumeAt",
+							Optional:    true,
+							Description: `ResumeAt is the time when paused event is resumed automatically.`,
+							Type:        smd.String,
+						},
+					},
+				},
+				Errors: map[int]string{
+					400: "past_date or event not active",
+					404: "event not found",
+				},
+			},
+		},
+	}
+}
+
+// Invoke is as generated code from zenrpc cmd
+func (s EventService) Invoke(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+	resp := zenrpc.Response{}
+	var err error
+
+	switch method {
+	case RPC.EventService.Get:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Get(ctx, args.Id))
+
+	case RPC.EventService.List:
+		var args = struct {
+			Page     *int    `json:"page"`
+			PageSize *int    `json:"pageSize"`
+			Tag      *string `json:"tag"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"page", "pageSize", "tag"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:page=1 page number
+		if args.Page == nil {
+			var v int = 1
+			args.Page = &v
+		}
+
+		//zenrpc:pageSize=20 events per page, up to 100
+		if args.PageSize == nil {
+			var v int = 20
+			args.PageSize = &v
+		}
+
+		resp.Set(s.List(ctx, *args.Page, *args.PageSize, args.Tag))
+
+	case RPC.EventService.Search:
+		var args = struct {
+			Query    string `json:"query"`
+			Page     *int   `json:"page"`
+			PageSize *int   `json:"pageSize"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"query", "page", "pageSize"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		//zenrpc:page=1 page number
+		if args.Page == nil {
+			var v int = 1
+			args.Page = &v
+		}
+
+		//zenrpc:pageSize=20 events per page, up to 100
+		if args.PageSize == nil {
+			var v int = 20
+			args.PageSize = &v
+		}
+
+		resp.Set(s.Search(ctx, args.Query, *args.Page, *args.PageSize))
+
+	case RPC.EventService.Create:
+		var args = struct {
+			Event EventInput `json:"event"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"event"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Create(ctx, args.Event))
+
+	case RPC.EventService.Update:
+		var args = struct {
+			Id    int        `json:"id"`
+			Event EventInput `json:"event"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "event"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Update(ctx, args.Id, args.Event))
+
+	case RPC.EventService.Delete:
+		var args = struct {
+			Id int `json:"id"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Delete(ctx, args.Id))
+
+	case RPC.EventService.Snooze:
+		var args = struct {
+			Id     int         `json:"id"`
+			Snooze SnoozeInput `json:"snooze"`
+		}{}
+
+		if zenrpc.IsArray(params) {
+			if params, err = zenrpc.ConvertToObject([]string{"id", "snooze"}, params); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		if len(params) > 0 {
+			if err := json.Unmarshal(params, &args); err != nil {
+				return zenrpc.NewResponseError(nil, zenrpc.InvalidParams, "", err.Error())
+			}
+		}
+
+		resp.Set(s.Snooze(ctx, args.Id, args.Snooze))
+
+	default:
+		resp = zenrpc.NewResponseError(nil, zenrpc.MethodNotFound, "", nil)
+	}
+
+	return resp
+}
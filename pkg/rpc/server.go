@@ -0,0 +1,87 @@
+// Package rpc is JSON-RPC 2.0 API for scripts, requests are authenticated by personal API tokens issued with /token bot command.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmkteam/embedlog"
+	zm "github.com/vmkteam/zenrpc-middleware"
+	"github.com/vmkteam/zenrpc/v2"
+)
+
+//go:generate go tool zenrpc
+
+const (
+	NSEvent = "event"
+
+	userIDKey contextKey = "userID"
+)
+
+var (
+	ErrUnauthorized = zenrpc.NewStringError(http.StatusUnauthorized, "unauthorized")
+	ErrNotFound     = zenrpc.NewStringError(http.StatusNotFound, "not found")
+	ErrInternal     = zenrpc.NewStringError(http.StatusInternalServerError, "internal error")
+)
+
+type contextKey string
+
+// New returns JSON-RPC server with SMD schema.
+func New(bm *botManager.BotManager, logger embedlog.Logger, isDevel bool) *zenrpc.Server {
+	rpc := zenrpc.NewServer(zenrpc.Options{
+		ExposeSMD: true,
+		AllowCORS: true,
+	})
+
+	rpc.Use(
+		zm.WithHeaders(),
+		zm.WithDevel(isDevel),
+		zm.WithNoCancelContext(),
+		zm.WithMetrics(zm.DefaultServerName),
+		zm.WithSLog(logger.Print, zm.DefaultServerName, nil),
+		zm.WithErrorSLog(logger.Print, zm.DefaultServerName, nil),
+		zm.WithSentry(zm.DefaultServerName),
+		withAuth(bm, logger),
+	)
+
+	rpc.RegisterAll(map[string]zenrpc.Invoker{
+		NSEvent: NewEventService(bm, logger),
+	})
+
+	return &rpc
+}
+
+// withAuth authenticates requests by "Authorization: Bearer <token>" header and puts user ID into context.
+func withAuth(bm *botManager.BotManager, logger embedlog.Logger) zenrpc.MiddlewareFunc {
+	return func(h zenrpc.InvokeFunc) zenrpc.InvokeFunc {
+		return func(ctx context.Context, method string, params json.RawMessage) zenrpc.Response {
+			var token string
+			if req, ok := zenrpc.RequestFromContext(ctx); ok && req != nil {
+				token, _ = strings.CutPrefix(req.Header.Get(echo.HeaderAuthorization), "Bearer ")
+			}
+
+			userID, err := bm.APITokenUser(ctx, strings.TrimSpace(token))
+			switch {
+			case errors.Is(err, botManager.ErrNotFound):
+				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrUnauthorized.Code, ErrUnauthorized.Message, nil)
+			case err != nil:
+				logger.Errorf("Ошибка проверки токена API: %v", err)
+				return zenrpc.NewResponseError(zenrpc.IDFromContext(ctx), ErrInternal.Code, ErrInternal.Message, nil)
+			}
+
+			return h(context.WithValue(ctx, userIDKey, userID), method, params)
+		}
+	}
+}
+
+// userID returns ID of authenticated user.
+func userID(ctx context.Context) int64 {
+	id, _ := ctx.Value(userIDKey).(int64)
+	return id
+}
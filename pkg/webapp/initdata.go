@@ -0,0 +1,76 @@
+package webapp
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+)
+
+var (
+	ErrInvalidInitData = errors.New("invalid init data")
+	ErrInitDataExpired = errors.New("init data expired")
+)
+
+// ValidateInitData checks Telegram Web App init data signed with bot token and returns the user who opened the app.
+// Init data older than maxAge is rejected, zero maxAge disables the check.
+// See https://core.telegram.org/bots/webapps#validating-data-received-via-the-mini-app
+func ValidateInitData(initData, botToken string, maxAge time.Duration, now time.Time) (*models.User, error) {
+	values, err := url.ParseQuery(initData)
+	if err != nil {
+		return nil, ErrInvalidInitData
+	}
+
+	hash := values.Get("hash")
+	if hash == "" {
+		return nil, ErrInvalidInitData
+	}
+
+	mac, err := hex.DecodeString(hash)
+	if err != nil || !hmac.Equal(mac, signInitData(values, botToken)) {
+		return nil, ErrInvalidInitData
+	}
+
+	authDate, err := strconv.ParseInt(values.Get("auth_date"), 10, 64)
+	if err != nil {
+		return nil, ErrInvalidInitData
+	}
+	if maxAge > 0 && now.Sub(time.Unix(authDate, 0)) > maxAge {
+		return nil, ErrInitDataExpired
+	}
+
+	var user models.User
+	if err = json.Unmarshal([]byte(values.Get("user")), &user); err != nil || user.ID == 0 {
+		return nil, ErrInvalidInitData
+	}
+
+	return &user, nil
+}
+
+// signInitData returns HMAC-SHA256 of sorted "key=value" lines of all fields except hash.
+// The key is HMAC-SHA256 of bot token with "WebAppData" key.
+func signInitData(values url.Values, botToken string) []byte {
+	lines := make([]string, 0, len(values))
+	for k := range values {
+		if k != "hash" {
+			lines = append(lines, k+"="+values.Get(k))
+		}
+	}
+	sort.Strings(lines)
+
+	secret := hmac.New(sha256.New, []byte("WebAppData"))
+	secret.Write([]byte(botToken))
+
+	h := hmac.New(sha256.New, secret.Sum(nil))
+	h.Write([]byte(strings.Join(lines, "\n")))
+
+	return h.Sum(nil)
+}
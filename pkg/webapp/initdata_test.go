@@ -0,0 +1,57 @@
+package webapp
+
+import (
+	"encoding/hex"
+	"errors"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+)
+
+const testToken = "123456:test-token"
+
+// testInitData returns init data signed like Telegram does.
+func testInitData(authDate time.Time, token string) url.Values {
+	values := url.Values{}
+	values.Set("query_id", "AAHdF6IQAAAAAN0XohDhrOrc")
+	values.Set("user", `{"id":279058397,"first_name":"Vladislav","language_code":"ru"}`)
+	values.Set("auth_date", strconv.FormatInt(authDate.Unix(), 10))
+	values.Set("hash", hex.EncodeToString(signInitData(values, token)))
+
+	return values
+}
+
+func TestValidateInitData(t *testing.T) {
+	now := time.Now()
+
+	user, err := ValidateInitData(testInitData(now, testToken).Encode(), testToken, time.Hour, now)
+	if err != nil {
+		t.Fatalf("valid init data: %v", err)
+	}
+	if user.ID != 279058397 || user.LanguageCode != "ru" {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+
+	tampered := testInitData(now, testToken)
+	tampered.Set("user", `{"id":1,"first_name":"Mallory"}`)
+	if _, err = ValidateInitData(tampered.Encode(), testToken, time.Hour, now); !errors.Is(err, ErrInvalidInitData) {
+		t.Fatalf("tampered init data: got %v", err)
+	}
+
+	if _, err = ValidateInitData(testInitData(now, "654321:other").Encode(), testToken, time.Hour, now); !errors.Is(err, ErrInvalidInitData) {
+		t.Fatalf("init data of other bot: got %v", err)
+	}
+
+	if _, err = ValidateInitData("auth_date=1", testToken, time.Hour, now); !errors.Is(err, ErrInvalidInitData) {
+		t.Fatalf("init data without hash: got %v", err)
+	}
+
+	expired := testInitData(now.Add(-2*time.Hour), testToken).Encode()
+	if _, err = ValidateInitData(expired, testToken, time.Hour, now); !errors.Is(err, ErrInitDataExpired) {
+		t.Fatalf("expired init data: got %v", err)
+	}
+	if _, err = ValidateInitData(expired, testToken, 0, now); err != nil {
+		t.Fatalf("expired init data without max age: %v", err)
+	}
+}
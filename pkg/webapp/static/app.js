@@ -0,0 +1,266 @@
+(function () {
+    'use strict';
+
+    const tg = window.Telegram.WebApp;
+    const messages = {
+        ru: {
+            month: 'Месяц', week: 'Неделя', save: 'Сохранить', cancel: 'Отмена', title: 'Название', notes: 'Заметки',
+            periodic: 'Повторяющееся событие: изменение даты переносит всю серию',
+            error: 'Не удалось загрузить события', past_date: 'Дата не может быть в прошлом',
+            empty_title: 'Укажите название события', text_too_long: 'Слишком длинное название',
+            notes_too_long: 'Слишком длинные заметки',
+            weekdays: ['Пн', 'Вт', 'Ср', 'Чт', 'Пт', 'Сб', 'Вс'],
+            months: ['Январь', 'Февраль', 'Март', 'Апрель', 'Май', 'Июнь', 'Июль', 'Август', 'Сентябрь', 'Октябрь', 'Ноябрь', 'Декабрь'],
+        },
+        en: {
+            month: 'Month', week: 'Week', save: 'Save', cancel: 'Cancel', title: 'Title', notes: 'Notes',
+            periodic: 'Repeating event: changing the date moves the whole series',
+            error: 'Could not load events', past_date: 'The date cannot be in the past',
+            empty_title: 'Enter the event title', text_too_long: 'The title is too long',
+            notes_too_long: 'The notes are too long',
+            weekdays: ['Mon', 'Tue', 'Wed', 'Thu', 'Fri', 'Sat', 'Sun'],
+            months: ['January', 'February', 'March', 'April', 'May', 'June', 'July', 'August', 'September', 'October', 'November', 'December'],
+        },
+    };
+    const user = tg.initDataUnsafe.user || {};
+    const t = messages[(user.language_code || '').startsWith('ru') ? 'ru' : 'en'];
+
+    const $ = (id) => document.getElementById(id);
+    const state = {view: 'month', anchor: today(), events: [], editing: null};
+
+    // Dates are "YYYY-MM-DD" strings, arithmetic is done in UTC to avoid DST shifts.
+    function today() {
+        const d = new Date();
+        return fmt(new Date(Date.UTC(d.getFullYear(), d.getMonth(), d.getDate())));
+    }
+
+    function parse(s) {
+        const [y, m, d] = s.split('-').map(Number);
+        return new Date(Date.UTC(y, m - 1, d));
+    }
+
+    function fmt(d) {
+        return d.toISOString().slice(0, 10);
+    }
+
+    function addDays(s, n) {
+        const d = parse(s);
+        d.setUTCDate(d.getUTCDate() + n);
+        return fmt(d);
+    }
+
+    function weekStart(s) {
+        return addDays(s, -((parse(s).getUTCDay() + 6) % 7));
+    }
+
+    function range() {
+        if (state.view === 'week') {
+            const from = weekStart(state.anchor);
+            return [from, addDays(from, 7)];
+        }
+        const d = parse(state.anchor);
+        const first = fmt(new Date(Date.UTC(d.getUTCFullYear(), d.getUTCMonth(), 1)));
+        const from = weekStart(first);
+        const next = fmt(new Date(Date.UTC(d.getUTCFullYear(), d.getUTCMonth() + 1, 1)));
+        return [from, weekStart(addDays(next, 6))];
+    }
+
+    async function api(method, path, body) {
+        const res = await fetch('api' + path, {
+            method: method,
+            headers: {'Authorization': 'tma ' + tg.initData, 'Content-Type': 'application/json'},
+            body: body ? JSON.stringify(body) : undefined,
+        });
+        const data = await res.json();
+        if (!res.ok) {
+            throw new Error(data.message || res.statusText);
+        }
+        return data;
+    }
+
+    function showError(err) {
+        const msg = t[err.message] || err.message || t.error;
+        if (tg.showAlert) {
+            tg.showAlert(msg);
+        } else {
+            $('error').textContent = msg;
+            $('error').hidden = false;
+        }
+    }
+
+    async function load() {
+        const [from, to] = range();
+        try {
+            state.events = await api('GET', '/events?from=' + from + '&to=' + to);
+        } catch (err) {
+            state.events = [];
+            showError(err);
+        }
+        render();
+    }
+
+    function render() {
+        const [from, to] = range();
+        const cal = $('calendar');
+        const month = parse(state.anchor).getUTCMonth();
+        cal.className = state.view;
+        cal.innerHTML = '';
+
+        const a = parse(state.anchor);
+        $('title').textContent = state.view === 'month'
+            ? t.months[month] + ' ' + a.getUTCFullYear()
+            : from.slice(8) + '.' + from.slice(5, 7) + ' — ' + addDays(to, -1).slice(8) + '.' + addDays(to, -1).slice(5, 7);
+        $('view-month').classList.toggle('active', state.view === 'month');
+        $('view-week').classList.toggle('active', state.view === 'week');
+
+        if (state.view === 'month') {
+            t.weekdays.forEach((w) => cal.appendChild(el('div', 'weekday', w)));
+        }
+
+        for (let day = from; day < to; day = addDays(day, 1)) {
+            const cell = el('div', 'day');
+            cell.dataset.date = day;
+            cell.classList.toggle('today', day === today());
+            cell.classList.toggle('other', state.view === 'month' && parse(day).getUTCMonth() !== month);
+
+            const label = state.view === 'week'
+                ? t.weekdays[(parse(day).getUTCDay() + 6) % 7] + ', ' + day.slice(8) + '.' + day.slice(5, 7)
+                : String(parse(day).getUTCDate());
+            cell.appendChild(el('div', 'date', label));
+
+            state.events.filter((e) => e.date === day).forEach((e) => {
+                const item = el('div', 'event', e.time + ' ' + (e.periodicity ? '🔄 ' : '') + e.text);
+                item.addEventListener('pointerdown', (ev) => startDrag(ev, e, item));
+                cell.appendChild(item);
+            });
+            cal.appendChild(cell);
+        }
+    }
+
+    function el(tag, cls, text) {
+        const e = document.createElement(tag);
+        e.className = cls;
+        if (text !== undefined) {
+            e.textContent = text;
+        }
+        return e;
+    }
+
+    // Drag an event to another day to reschedule it keeping time of day. A tap without moving opens editor.
+    function startDrag(ev, event, item) {
+        ev.preventDefault();
+        const start = {x: ev.clientX, y: ev.clientY};
+        let dragging = false;
+        let target = null;
+
+        function dayAt(e) {
+            const under = document.elementFromPoint(e.clientX, e.clientY);
+            return under ? under.closest('.day') : null;
+        }
+
+        function move(e) {
+            if (!dragging && Math.hypot(e.clientX - start.x, e.clientY - start.y) > 8) {
+                dragging = true;
+                item.classList.add('dragging');
+            }
+            if (!dragging) {
+                return;
+            }
+            const day = dayAt(e);
+            if (day !== target) {
+                target && target.classList.remove('over');
+                target = day;
+                target && target.classList.add('over');
+            }
+        }
+
+        async function up() {
+            document.removeEventListener('pointermove', move);
+            document.removeEventListener('pointerup', up);
+            item.classList.remove('dragging');
+            target && target.classList.remove('over');
+
+            if (!dragging) {
+                openEditor(event);
+                return;
+            }
+            if (target && target.dataset.date !== event.date) {
+                try {
+                    await api('PUT', '/events/' + event.id, {date: target.dataset.date, time: event.time});
+                    tg.HapticFeedback && tg.HapticFeedback.notificationOccurred('success');
+                } catch (err) {
+                    showError(err);
+                }
+                load();
+            }
+        }
+
+        document.addEventListener('pointermove', move);
+        document.addEventListener('pointerup', up);
+    }
+
+    function openEditor(event) {
+        state.editing = event;
+        $('event-text').value = event ? event.text : '';
+        $('event-notes').value = event ? (event.notes || '') : '';
+        $('event-date').value = event ? event.date : state.anchor;
+        $('event-time').value = event ? event.time : '09:00';
+        $('event-periodic').hidden = !(event && event.periodicity);
+        $('editor').showModal();
+    }
+
+    async function save(e) {
+        e.preventDefault();
+        const ev = state.editing;
+        const body = {text: $('event-text').value, notes: $('event-notes').value};
+        const date = $('event-date').value;
+        const time = $('event-time').value;
+        if (!ev || date !== ev.date || time !== ev.time) {
+            body.date = date;
+            body.time = time;
+        }
+
+        try {
+            await api(ev ? 'PUT' : 'POST', ev ? '/events/' + ev.id : '/events', body);
+            $('editor').close();
+            load();
+        } catch (err) {
+            showError(err);
+        }
+    }
+
+    function shift(dir) {
+        if (state.view === 'week') {
+            state.anchor = addDays(state.anchor, 7 * dir);
+        } else {
+            const d = parse(state.anchor);
+            state.anchor = fmt(new Date(Date.UTC(d.getUTCFullYear(), d.getUTCMonth() + dir, 1)));
+        }
+        load();
+    }
+
+    function init() {
+        tg.ready();
+        tg.expand();
+
+        $('view-month').textContent = t.month;
+        $('view-week').textContent = t.week;
+        $('save').textContent = t.save;
+        $('cancel').textContent = t.cancel;
+        $('event-text').placeholder = t.title;
+        $('event-notes').placeholder = t.notes;
+        $('event-periodic').textContent = t.periodic;
+
+        $('prev').addEventListener('click', () => shift(-1));
+        $('next').addEventListener('click', () => shift(1));
+        $('view-month').addEventListener('click', () => { state.view = 'month'; load(); });
+        $('view-week').addEventListener('click', () => { state.view = 'week'; load(); });
+        $('add').addEventListener('click', () => openEditor(null));
+        $('cancel').addEventListener('click', () => $('editor').close());
+        $('editor').querySelector('form').addEventListener('submit', save);
+
+        load();
+    }
+
+    init();
+})();
@@ -0,0 +1,225 @@
+// Package webapp serves Telegram Mini App with calendar of user events and its JSON API.
+package webapp
+
+import (
+	"embed"
+	"errors"
+	"io/fs"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	botManager "event-reminder-bot/pkg/event-reminder-bot"
+	"event-reminder-bot/pkg/model"
+
+	"github.com/labstack/echo/v4"
+	"github.com/vmkteam/embedlog"
+)
+
+const (
+	// Path is the Mini App URL path on HTTP server.
+	Path = "/webapp"
+
+	// initDataMaxAge limits how long the app could be used without reopening.
+	initDataMaxAge = 24 * time.Hour
+
+	// maxRangeDays limits events request to about two months.
+	maxRangeDays = 62
+
+	userKey = "webappUser"
+)
+
+//go:embed static
+var staticFS embed.FS
+
+// Event is an event occurrence in user time zone.
+type Event struct {
+	ID          int    `json:"id"`
+	Text        string `json:"text"`
+	Notes       string `json:"notes,omitempty"`
+	Date        string `json:"date"`
+	Time        string `json:"time"`
+	Periodicity string `json:"periodicity,omitempty"`
+}
+
+// EventInput is a new event or changed fields of event. Date and time are in user time zone.
+type EventInput struct {
+	Text  *string `json:"text"`
+	Notes *string `json:"notes"`
+	Date  string  `json:"date"`
+	Time  string  `json:"time"`
+}
+
+type Server struct {
+	embedlog.Logger
+	bm       *botManager.BotManager
+	botToken string
+}
+
+func New(bm *botManager.BotManager, botToken string, logger embedlog.Logger) *Server {
+	return &Server{
+		Logger:   logger,
+		bm:       bm,
+		botToken: botToken,
+	}
+}
+
+// Register adds Mini App static files and API handlers.
+func (s *Server) Register(e *echo.Echo) {
+	static, _ := fs.Sub(staticFS, "static")
+	e.GET(Path+"/*", echo.WrapHandler(http.StripPrefix(Path+"/", http.FileServer(http.FS(static)))))
+
+	api := e.Group(Path+"/api", s.auth)
+	api.GET("/events", s.events)
+	api.POST("/events", s.addEvent)
+	api.PUT("/events/:id", s.editEvent)
+}
+
+// auth authenticates request by init data from "Authorization: tma <initData>" header.
+func (s *Server) auth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		initData, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "tma ")
+		if !ok {
+			return echo.ErrUnauthorized
+		}
+
+		user, err := ValidateInitData(initData, s.botToken, initDataMaxAge, time.Now())
+		if err != nil {
+			return echo.NewHTTPError(http.StatusUnauthorized, err.Error())
+		}
+
+		s.bm.TouchUser(c.Request().Context(), user.ID, user)
+		c.Set(userKey, user.ID)
+
+		return next(c)
+	}
+}
+
+// events returns event occurrences within [from, to) dates.
+func (s *Server) events(c echo.Context) error {
+	ctx, chatID := c.Request().Context(), c.Get(userKey).(int64)
+	loc := s.bm.UserLocation(ctx, chatID)
+
+	from, err1 := time.ParseInLocation(time.DateOnly, c.QueryParam("from"), loc)
+	to, err2 := time.ParseInLocation(time.DateOnly, c.QueryParam("to"), loc)
+	if err1 != nil || err2 != nil || !to.After(from) || to.Sub(from) > maxRangeDays*24*time.Hour {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid date range")
+	}
+
+	items, err := s.bm.Agenda(ctx, chatID, from, to, loc, 0)
+	if err != nil {
+		s.Errorf("Ошибка загрузки событий: %v", err)
+		return echo.ErrInternalServerError
+	}
+
+	res := make([]Event, 0, len(items))
+	for _, it := range items {
+		res = append(res, newEvent(&it.Event, it.At.In(loc)))
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+func (s *Server) addEvent(c echo.Context) error {
+	ctx, chatID := c.Request().Context(), c.Get(userKey).(int64)
+
+	var in EventInput
+	if err := c.Bind(&in); err != nil || in.Text == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid event")
+	}
+
+	at, err := s.inputTime(c, in)
+	if err != nil || at == nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid date")
+	}
+
+	var notes string
+	if in.Notes != nil {
+		notes = *in.Notes
+	}
+
+	event, err := s.bm.CreateEvent(ctx, chatID, strings.TrimSpace(*in.Text), strings.TrimSpace(notes), *at)
+	if err != nil {
+		return s.eventError(err)
+	}
+
+	return c.JSON(http.StatusOK, newEvent(model.NewEvent(event), at.In(s.bm.UserLocation(ctx, chatID))))
+}
+
+// editEvent changes event fields. Dragging an occurrence of periodic event moves the whole series.
+func (s *Server) editEvent(c echo.Context) error {
+	ctx, chatID := c.Request().Context(), c.Get(userKey).(int64)
+
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		return echo.ErrNotFound
+	}
+
+	var in EventInput
+	if err = c.Bind(&in); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid event")
+	}
+
+	at, err := s.inputTime(c, in)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid date")
+	}
+	if in.Text != nil {
+		*in.Text = strings.TrimSpace(*in.Text)
+	}
+	if in.Notes != nil {
+		*in.Notes = strings.TrimSpace(*in.Notes)
+	}
+
+	event, err := s.bm.EditEvent(ctx, chatID, id, in.Text, in.Notes, at, nil, nil)
+	if err != nil {
+		return s.eventError(err)
+	}
+
+	return c.JSON(http.StatusOK, newEvent(model.NewEvent(event), event.SendAt.In(s.bm.UserLocation(ctx, chatID))))
+}
+
+// inputTime returns event time in user time zone or nil if date is not set.
+func (s *Server) inputTime(c echo.Context, in EventInput) (*time.Time, error) {
+	if in.Date == "" {
+		return nil, nil
+	}
+
+	loc := s.bm.UserLocation(c.Request().Context(), c.Get(userKey).(int64))
+	at, err := time.ParseInLocation("2006-01-02 15:04", in.Date+" "+in.Time, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &at, nil
+}
+
+func (s *Server) eventError(err error) error {
+	if errors.Is(err, botManager.ErrNotFound) || errors.Is(err, botManager.ErrAccessDenied) {
+		return echo.ErrNotFound
+	}
+
+	switch err.Error() {
+	case "empty_title", "text_too_long", "notes_too_long", "past_date":
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error())
+	}
+
+	s.Errorf("Ошибка сохранения события: %v", err)
+	return echo.ErrInternalServerError
+}
+
+func newEvent(e *model.Event, at time.Time) Event {
+	res := Event{
+		ID:    e.ID,
+		Text:  e.Text,
+		Notes: e.Notes,
+		Date:  at.Format(time.DateOnly),
+		Time:  at.Format("15:04"),
+	}
+	if e.Periodicity != nil {
+		res.Periodicity = *e.Periodicity
+	}
+
+	return res
+}